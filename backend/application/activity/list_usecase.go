@@ -0,0 +1,62 @@
+package activity
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+var (
+	ErrInvalidResource = apperror.InvalidField("resource", errors.New("resource must be todo, category or tag"))
+	ErrInvalidPageSize = apperror.InvalidField("limit", errors.New("limit must be between 1 and 200"))
+)
+
+type ListUseCase interface {
+	// Execute returns one page of the caller's activity feed, newest first.
+	// q.UserID is overwritten with userID.
+	Execute(ctx context.Context, userID string, q repository.ActivityQuery) (*repository.ActivityPage, error)
+}
+
+type listUseCase struct {
+	activityRepo repository.ActivityRepository
+}
+
+func NewListUseCase(activityRepo repository.ActivityRepository) ListUseCase {
+	return &listUseCase{activityRepo}
+}
+
+func (uc *listUseCase) Execute(ctx context.Context, userID string, q repository.ActivityQuery) (*repository.ActivityPage, error) {
+	q.UserID = userID
+
+	switch q.Resource {
+	case "", entity.ActivityTodo, entity.ActivityCategory, entity.ActivityTag:
+	default:
+		return nil, ErrInvalidResource
+	}
+	if err := NormalizePage(&q); err != nil {
+		return nil, err
+	}
+	return uc.activityRepo.FindByQuery(ctx, q)
+}
+
+// NormalizePage applies the default page size and rejects out-of-range ones.
+func NormalizePage(q *repository.ActivityQuery) error {
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return ErrInvalidPageSize
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package activity
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// TodoEntry describes a write to a todo by actorID. before is nil for a
+// creation and after is nil for a deletion.
+func TodoEntry(actorID string, action entity.ActivityAction, before, after *entity.Todo) *entity.Activity {
+	t := after
+	if t == nil {
+		t = before
+	}
+	return newEntry(t.UserID, actorID, entity.ActivityTodo, t.ID, action, entity.DiffTodo(before, after))
+}
+
+// CategoryEntry describes a write to a category; see TodoEntry.
+func CategoryEntry(actorID string, action entity.ActivityAction, before, after *entity.Category) *entity.Activity {
+	c := after
+	if c == nil {
+		c = before
+	}
+	return newEntry(c.UserID, actorID, entity.ActivityCategory, c.ID, action, entity.DiffCategory(before, after))
+}
+
+// TagEntry describes a write to a tag; see TodoEntry.
+func TagEntry(actorID string, action entity.ActivityAction, before, after *entity.Tag) *entity.Activity {
+	t := after
+	if t == nil {
+		t = before
+	}
+	return newEntry(t.UserID, actorID, entity.ActivityTag, t.ID, action, entity.DiffTag(before, after))
+}
+
+func newEntry(
+	userID, actorID string,
+	resource entity.ActivityResource,
+	resourceID string,
+	action entity.ActivityAction,
+	changes []entity.FieldChange,
+) *entity.Activity {
+	return &entity.Activity{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		ActorID:    actorID,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Action:     action,
+		Changes:    changes,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+// Record appends entries to the audit log, dropping updates that changed no
+// field. The writes they describe have already been made, so a failure is
+// logged rather than returned to the caller.
+func Record(ctx context.Context, repo repository.ActivityRepository, entries ...*entity.Activity) {
+	kept := entries[:0:0]
+	for _, e := range entries {
+		if e == nil || (e.Action == entity.ActivityUpdated && len(e.Changes) == 0) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	if len(kept) == 0 {
+		return
+	}
+	if err := repo.CreateMany(ctx, kept); err != nil {
+		log.Printf("activity: failed to record %d entries: %v", len(kept), err)
+	}
+}
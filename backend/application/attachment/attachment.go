@@ -0,0 +1,56 @@
+package attachment
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// DownloadURLTTL is how long a download link stays valid.
+const DownloadURLTTL = 5 * time.Minute
+
+var ErrAttachmentNotFound = apperror.NotFound("attachment not found")
+
+// blobKey names an attachment's contents in the blob store.
+func blobKey(todoID, attachmentID string) string {
+	return "attachments/" + todoID + "/" + attachmentID
+}
+
+// findAttachment loads an attachment of a todo the user can reach, for
+// editing when edit is set. Like the todo endpoints, a todo the user cannot
+// reach is reported as not found; a viewer trying to edit is forbidden.
+func findAttachment(
+	ctx context.Context,
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	attachmentRepo repository.AttachmentRepository,
+	userID, todoID, attachmentID string,
+	edit bool,
+) (*entity.Todo, *entity.Attachment, error) {
+	t, err := todo.FindAccessible(ctx, todoRepo, workspaceRepo, userID, todoID, edit)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := attachmentRepo.FindByID(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a == nil || a.TodoID != todoID {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	return t, a, nil
+}
+
+// publish announces an attachment write to everyone who can see its todo.
+func publish(events event.Publisher, t *entity.Todo, action event.Action, id string, a *entity.Attachment) {
+	var e any // a nil *entity.Attachment would make a non-nil any
+	if a != nil {
+		e = a
+	}
+	events.Publish(event.New(t.UserID, event.ResourceAttachment, action, id, e).In(t.WorkspaceID))
+}
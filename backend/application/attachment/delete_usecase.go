@@ -0,0 +1,49 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/blob"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type DeleteUseCase interface {
+	// Execute removes the attachment and its file. Anyone who may edit the
+	// todo may delete its attachments, not only the uploader.
+	Execute(ctx context.Context, userID, todoID, attachmentID string) error
+}
+
+type deleteUseCase struct {
+	todoRepo       repository.TodoRepository
+	workspaceRepo  repository.WorkspaceRepository
+	attachmentRepo repository.AttachmentRepository
+	blobs          blob.BlobStore
+	events         event.Publisher
+}
+
+func NewDeleteUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	attachmentRepo repository.AttachmentRepository,
+	blobs blob.BlobStore,
+	events event.Publisher,
+) DeleteUseCase {
+	return &deleteUseCase{todoRepo, workspaceRepo, attachmentRepo, blobs, events}
+}
+
+func (uc *deleteUseCase) Execute(ctx context.Context, userID, todoID, attachmentID string) error {
+	t, a, err := findAttachment(ctx, uc.todoRepo, uc.workspaceRepo, uc.attachmentRepo, userID, todoID, attachmentID, true)
+	if err != nil {
+		return err
+	}
+	// the blob goes first, so a failure leaves the record to retry the delete with
+	if err := uc.blobs.Delete(ctx, a.Key); err != nil {
+		return err
+	}
+	if err := uc.attachmentRepo.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+	publish(uc.events, t, event.ActionDeleted, a.ID, nil)
+	return nil
+}
@@ -0,0 +1,38 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/blob"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type DownloadUseCase interface {
+	// Execute returns a URL the attachment can be downloaded from for the
+	// next DownloadURLTTL, under its original file name.
+	Execute(ctx context.Context, userID, todoID, attachmentID string) (string, error)
+}
+
+type downloadUseCase struct {
+	todoRepo       repository.TodoRepository
+	workspaceRepo  repository.WorkspaceRepository
+	attachmentRepo repository.AttachmentRepository
+	blobs          blob.BlobStore
+}
+
+func NewDownloadUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	attachmentRepo repository.AttachmentRepository,
+	blobs blob.BlobStore,
+) DownloadUseCase {
+	return &downloadUseCase{todoRepo, workspaceRepo, attachmentRepo, blobs}
+}
+
+func (uc *downloadUseCase) Execute(ctx context.Context, userID, todoID, attachmentID string) (string, error) {
+	_, a, err := findAttachment(ctx, uc.todoRepo, uc.workspaceRepo, uc.attachmentRepo, userID, todoID, attachmentID, false)
+	if err != nil {
+		return "", err
+	}
+	return uc.blobs.SignedURL(ctx, a.Key, DownloadURLTTL, a.FileName)
+}
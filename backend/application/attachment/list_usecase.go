@@ -0,0 +1,35 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type ListUseCase interface {
+	// Execute returns the todo's attachments, oldest first.
+	Execute(ctx context.Context, userID, todoID string) ([]*entity.Attachment, error)
+}
+
+type listUseCase struct {
+	todoRepo       repository.TodoRepository
+	workspaceRepo  repository.WorkspaceRepository
+	attachmentRepo repository.AttachmentRepository
+}
+
+func NewListUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	attachmentRepo repository.AttachmentRepository,
+) ListUseCase {
+	return &listUseCase{todoRepo, workspaceRepo, attachmentRepo}
+}
+
+func (uc *listUseCase) Execute(ctx context.Context, userID, todoID string) ([]*entity.Attachment, error) {
+	if _, err := todo.FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, userID, todoID, false); err != nil {
+		return nil, err
+	}
+	return uc.attachmentRepo.FindAllByTodo(ctx, todoID)
+}
@@ -0,0 +1,52 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/blob"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// sweepBatchSize is how many orphaned attachments are removed per round trip.
+const sweepBatchSize = 100
+
+type SweepUseCase interface {
+	// Execute removes the files of todos that have been deleted for good,
+	// by a trash purge or with their workspace, and reports how many there were.
+	Execute(ctx context.Context) (int, error)
+}
+
+type sweepUseCase struct {
+	attachmentRepo repository.AttachmentRepository
+	blobs          blob.BlobStore
+}
+
+func NewSweepUseCase(attachmentRepo repository.AttachmentRepository, blobs blob.BlobStore) SweepUseCase {
+	return &sweepUseCase{attachmentRepo, blobs}
+}
+
+func (uc *sweepUseCase) Execute(ctx context.Context) (int, error) {
+	swept := 0
+	for {
+		orphans, err := uc.attachmentRepo.FindOrphaned(ctx, sweepBatchSize)
+		if err != nil || len(orphans) == 0 {
+			return swept, err
+		}
+		keys := make([]string, 0, len(orphans))
+		for _, a := range orphans {
+			keys = append(keys, a.Key)
+		}
+		if err := uc.blobs.Delete(ctx, keys...); err != nil {
+			return swept, err
+		}
+		for _, a := range orphans {
+			if err := uc.attachmentRepo.Delete(ctx, a.ID); err != nil {
+				return swept, err
+			}
+			swept++
+		}
+		if len(orphans) < sweepBatchSize {
+			return swept, nil
+		}
+	}
+}
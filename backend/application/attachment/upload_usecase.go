@@ -0,0 +1,74 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/domain/blob"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// Upload is one file sent to a todo. ContentType must have been sniffed from
+// the contents, not taken from the client.
+type Upload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Body        io.Reader
+}
+
+type UploadUseCase interface {
+	// Execute stores the file and attaches it to the todo, which userID must
+	// be allowed to edit.
+	Execute(ctx context.Context, userID, todoID string, u Upload) (*entity.Attachment, error)
+}
+
+type uploadUseCase struct {
+	todoRepo       repository.TodoRepository
+	workspaceRepo  repository.WorkspaceRepository
+	attachmentRepo repository.AttachmentRepository
+	blobs          blob.BlobStore
+	events         event.Publisher
+}
+
+func NewUploadUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	attachmentRepo repository.AttachmentRepository,
+	blobs blob.BlobStore,
+	events event.Publisher,
+) UploadUseCase {
+	return &uploadUseCase{todoRepo, workspaceRepo, attachmentRepo, blobs, events}
+}
+
+func (uc *uploadUseCase) Execute(ctx context.Context, userID, todoID string, u Upload) (*entity.Attachment, error) {
+	a, err := entity.NewAttachment(uuid.NewString(), todoID, userID, u.FileName, u.ContentType, u.Size)
+	if err != nil {
+		return nil, err
+	}
+	t, err := todo.FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, userID, todoID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// the blob goes first: a record must never point at contents that are not there
+	a.Key = blobKey(todoID, a.ID)
+	if err := uc.blobs.Put(ctx, a.Key, u.Body, a.ContentType); err != nil {
+		return nil, err
+	}
+	created, err := uc.attachmentRepo.Create(ctx, a)
+	if err != nil {
+		if delErr := uc.blobs.Delete(ctx, a.Key); delErr != nil {
+			log.Printf("attachment %s: failed to remove blob after failed insert: %v", a.ID, delErr)
+		}
+		return nil, err
+	}
+	publish(uc.events, t, event.ActionCreated, created.ID, created)
+	return created, nil
+}
@@ -0,0 +1,26 @@
+package calendar
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+var (
+	// ErrFeedNotFound is also returned for revoked and rotated-away tokens, so
+	// a stale URL cannot be told apart from one that never existed.
+	ErrFeedNotFound     = apperror.NotFound("calendar feed not found")
+	ErrInvalidComponent = apperror.InvalidField("type", errors.New(`type must be "event" or "todo"`))
+)
+
+// Feed is everything a calendar export needs: the owner's todos that have a
+// due date, soonest first, and the names their categories and tags show as.
+type Feed struct {
+	UserID     string
+	Location   *time.Location
+	Todos      []*entity.Todo
+	Categories map[string]string // category ID -> name
+	Tags       map[string]string // tag ID -> name
+}
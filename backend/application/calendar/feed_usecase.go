@@ -0,0 +1,98 @@
+package calendar
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+)
+
+type FeedUseCase interface {
+	// Execute resolves a feed token to its owner's calendar data. It needs no
+	// session: the token is the credential.
+	Execute(ctx context.Context, token string) (*Feed, error)
+}
+
+type feedUseCase struct {
+	feedRepo     repository.CalendarFeedRepository
+	userRepo     repository.UserRepository
+	todoRepo     repository.TodoRepository
+	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+}
+
+func NewFeedUseCase(
+	feedRepo repository.CalendarFeedRepository,
+	userRepo repository.UserRepository,
+	todoRepo repository.TodoRepository,
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+) FeedUseCase {
+	return &feedUseCase{feedRepo, userRepo, todoRepo, categoryRepo, tagRepo}
+}
+
+func (uc *feedUseCase) Execute(ctx context.Context, token string) (*Feed, error) {
+	if token == "" {
+		return nil, ErrFeedNotFound
+	}
+	feed, err := uc.feedRepo.FindByTokenHash(ctx, auth.HashFeedToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return nil, ErrFeedNotFound
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if u != nil {
+		loc = u.Location()
+	}
+
+	all, err := uc.todoRepo.FindAllByUser(ctx, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var todos []*entity.Todo
+	for _, t := range all {
+		if t.DueDate != nil {
+			todos = append(todos, t)
+		}
+	}
+	sort.Slice(todos, func(i, j int) bool {
+		if !todos[i].DueDate.Equal(*todos[j].DueDate) {
+			return todos[i].DueDate.Before(*todos[j].DueDate)
+		}
+		return todos[i].ID < todos[j].ID
+	})
+
+	categories, err := uc.categoryRepo.FindAllByUser(ctx, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := uc.tagRepo.FindAllByUser(ctx, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Feed{
+		UserID:     feed.UserID,
+		Location:   loc,
+		Todos:      todos,
+		Categories: make(map[string]string, len(categories)),
+		Tags:       make(map[string]string, len(tags)),
+	}
+	for _, c := range categories {
+		out.Categories[c.ID] = c.Name
+	}
+	for _, t := range tags {
+		out.Tags[t.ID] = t.Name
+	}
+	return out, nil
+}
@@ -0,0 +1,25 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type GetUseCase interface {
+	// Execute returns the user's feed, or nil when they have none.
+	Execute(ctx context.Context, userID string) (*entity.CalendarFeed, error)
+}
+
+type getUseCase struct {
+	feedRepo repository.CalendarFeedRepository
+}
+
+func NewGetUseCase(feedRepo repository.CalendarFeedRepository) GetUseCase {
+	return &getUseCase{feedRepo}
+}
+
+func (uc *getUseCase) Execute(ctx context.Context, userID string) (*entity.CalendarFeed, error) {
+	return uc.feedRepo.FindByUser(ctx, userID)
+}
@@ -0,0 +1,24 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type RevokeUseCase interface {
+	// Execute turns the user's feed off. Revoking when there is no feed is a no-op.
+	Execute(ctx context.Context, userID string) error
+}
+
+type revokeUseCase struct {
+	feedRepo repository.CalendarFeedRepository
+}
+
+func NewRevokeUseCase(feedRepo repository.CalendarFeedRepository) RevokeUseCase {
+	return &revokeUseCase{feedRepo}
+}
+
+func (uc *revokeUseCase) Execute(ctx context.Context, userID string) error {
+	return uc.feedRepo.Delete(ctx, userID)
+}
@@ -0,0 +1,40 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+)
+
+type RotateUseCase interface {
+	// Execute issues a new feed token, creating the feed if needed. The
+	// previous URL stops working. The token is only ever returned here.
+	Execute(ctx context.Context, userID string) (token string, feed *entity.CalendarFeed, err error)
+}
+
+type rotateUseCase struct {
+	feedRepo repository.CalendarFeedRepository
+}
+
+func NewRotateUseCase(feedRepo repository.CalendarFeedRepository) RotateUseCase {
+	return &rotateUseCase{feedRepo}
+}
+
+func (uc *rotateUseCase) Execute(ctx context.Context, userID string) (string, *entity.CalendarFeed, error) {
+	token, hash, err := auth.NewFeedToken()
+	if err != nil {
+		return "", nil, err
+	}
+	feed, err := uc.feedRepo.Save(ctx, &entity.CalendarFeed{
+		UserID:    userID,
+		TokenHash: hash,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return token, feed, nil
+}
@@ -5,28 +5,48 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
 )
 
 type CreateUseCase interface {
-	Execute(ctx context.Context, userID, name, color string, description *string) (*entity.Category, error)
+	// workspaceID: the workspace to create the category in, where the caller
+	// must be an editor; nil creates a personal one
+	Execute(ctx context.Context, userID string, workspaceID *string, name, color string, description *string) (*entity.Category, error)
 }
 
 type createUseCase struct {
-	categoryRepo repository.CategoryRepository
+	categoryRepo  repository.CategoryRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
 }
 
-func NewCreateUseCase(categoryRepo repository.CategoryRepository) CreateUseCase {
-	return &createUseCase{categoryRepo}
+func NewCreateUseCase(
+	categoryRepo repository.CategoryRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) CreateUseCase {
+	return &createUseCase{categoryRepo, workspaceRepo, activityRepo, events}
 }
 
-func (uc *createUseCase) Execute(ctx context.Context, userID, name, color string, description *string) (*entity.Category, error) {
+func (uc *createUseCase) Execute(
+	ctx context.Context,
+	userID string,
+	workspaceID *string,
+	name, color string,
+	description *string,
+) (*entity.Category, error) {
 	// Validate NameVO
 	nameVO, err := valueobject.NewTitleVO(name) // reuse TitleVO for non-empty check
 	if err != nil {
-		return nil, err
+		return nil, apperror.InvalidField("name", err)
 	}
 	// Color is pre-validated by front-end
 
@@ -45,5 +65,15 @@ func (uc *createUseCase) Execute(ctx context.Context, userID, name, color string
 	if err != nil {
 		return nil, err
 	}
-	return uc.categoryRepo.Create(ctx, catEntity)
+	if err := workspace.CheckScope(ctx, uc.workspaceRepo, userID, workspaceID, true); err != nil {
+		return nil, err
+	}
+	catEntity.WorkspaceID = workspaceID
+	created, err := uc.categoryRepo.Create(ctx, catEntity)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, uc.activityRepo, activity.CategoryEntry(userID, entity.ActivityCreated, nil, created))
+	uc.events.Publish(event.New(created.UserID, event.ResourceCategory, event.ActionCreated, created.ID, created).In(created.WorkspaceID))
+	return created, nil
 }
@@ -3,21 +3,55 @@ package category
 import (
 	"context"
 
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
 
 type DeleteUseCase interface {
-	Execute(ctx context.Context, id string) error
+	// userID: the caller's ID
+	// id: the category to delete
+	// ifMatch: versions the caller last saw; empty skips the check
+	Execute(ctx context.Context, userID, id string, ifMatch []int) error
 }
 
 type deleteUseCase struct {
-	categoryRepo repository.CategoryRepository
+	categoryRepo  repository.CategoryRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
 }
 
-func NewDeleteUseCase(categoryRepo repository.CategoryRepository) DeleteUseCase {
-	return &deleteUseCase{categoryRepo}
+func NewDeleteUseCase(
+	categoryRepo repository.CategoryRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) DeleteUseCase {
+	return &deleteUseCase{categoryRepo, workspaceRepo, activityRepo, events}
 }
 
-func (uc *deleteUseCase) Execute(ctx context.Context, id string) error {
-	return uc.categoryRepo.Delete(ctx, id)
+func (uc *deleteUseCase) Execute(ctx context.Context, userID, id string, ifMatch []int) error {
+	existing, err := uc.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrCategoryNotFound
+	}
+	if err := workspace.CheckRecord(ctx, uc.workspaceRepo, userID, existing.UserID, existing.WorkspaceID, true, ErrCategoryForbidden); err != nil {
+		return err
+	}
+	if !repository.VersionMatches(ifMatch, existing.Version) {
+		return repository.ErrStaleVersion
+	}
+	if err := uc.categoryRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	activity.Record(ctx, uc.activityRepo, activity.CategoryEntry(userID, entity.ActivityDeleted, existing, nil))
+	// the todos that used it drop the reference; clients do the same on this event
+	uc.events.Publish(event.New(userID, event.ResourceCategory, event.ActionDeleted, id, nil).In(existing.WorkspaceID))
+	return nil
 }
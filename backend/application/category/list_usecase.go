@@ -3,22 +3,32 @@ package category
 import (
 	"context"
 
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
 
 type ListUseCase interface {
-	Execute(ctx context.Context, userID string) ([]*entity.Category, error)
+	// Execute lists the caller's personal categories, or with a workspaceID
+	// the categories of a workspace they belong to.
+	Execute(ctx context.Context, userID string, workspaceID *string) ([]*entity.Category, error)
 }
 
 type listUseCase struct {
-	categoryRepo repository.CategoryRepository
+	categoryRepo  repository.CategoryRepository
+	workspaceRepo repository.WorkspaceRepository
 }
 
-func NewListUseCase(categoryRepo repository.CategoryRepository) ListUseCase {
-	return &listUseCase{categoryRepo}
+func NewListUseCase(categoryRepo repository.CategoryRepository, workspaceRepo repository.WorkspaceRepository) ListUseCase {
+	return &listUseCase{categoryRepo, workspaceRepo}
 }
 
-func (uc *listUseCase) Execute(ctx context.Context, userID string) ([]*entity.Category, error) {
-	return uc.categoryRepo.FindAllByUser(ctx, userID)
+func (uc *listUseCase) Execute(ctx context.Context, userID string, workspaceID *string) ([]*entity.Category, error) {
+	if workspaceID == nil {
+		return uc.categoryRepo.FindAllByUser(ctx, userID)
+	}
+	if err := workspace.CheckScope(ctx, uc.workspaceRepo, userID, workspaceID, false); err != nil {
+		return nil, err
+	}
+	return uc.categoryRepo.FindAllByWorkspace(ctx, *workspaceID)
 }
@@ -2,36 +2,49 @@ package category
 
 import (
 	"context"
-	"errors"
 	"time"
 
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
 
 var (
-	ErrCategoryNotFound  = errors.New("category not found")
-	ErrCategoryForbidden = errors.New("cannot modify another user's category")
+	ErrCategoryNotFound  = apperror.NotFound("category not found")
+	ErrCategoryForbidden = apperror.Forbidden("cannot modify another user's category")
 )
 
 type UpdateUseCase interface {
 	// userID: the caller's ID
 	// id: the category to update
 	// name, color, description: new values
-	Execute(ctx context.Context, userID, id, name, color, description string) (*entity.Category, error)
+	// ifMatch: versions the caller last saw; empty skips the check
+	Execute(ctx context.Context, userID, id, name, color, description string, ifMatch []int) (*entity.Category, error)
 }
 
 type updateUseCase struct {
-	categoryRepo repository.CategoryRepository
+	categoryRepo  repository.CategoryRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
 }
 
-func NewUpdateUseCase(categoryRepo repository.CategoryRepository) UpdateUseCase {
-	return &updateUseCase{categoryRepo}
+func NewUpdateUseCase(
+	categoryRepo repository.CategoryRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) UpdateUseCase {
+	return &updateUseCase{categoryRepo, workspaceRepo, activityRepo, events}
 }
 
 func (uc *updateUseCase) Execute(
 	ctx context.Context,
 	userID, id, name, color, description string,
+	ifMatch []int,
 ) (*entity.Category, error) {
 	// 1) Fetch existing
 	existing, err := uc.categoryRepo.FindByID(ctx, id)
@@ -42,11 +55,17 @@ func (uc *updateUseCase) Execute(
 		return nil, ErrCategoryNotFound
 	}
 
-	// 2) Authorization
-	if existing.UserID != userID {
-		return nil, ErrCategoryForbidden
+	// 2) Authorization: the owner, or an editor of its workspace
+	if err := workspace.CheckRecord(ctx, uc.workspaceRepo, userID, existing.UserID, existing.WorkspaceID, true, ErrCategoryForbidden); err != nil {
+		return nil, err
+	}
+	if !repository.VersionMatches(ifMatch, existing.Version) {
+		return nil, repository.ErrStaleVersion
 	}
 
+	// the old values go to the audit log
+	before := *existing
+
 	// 3) Apply updates
 	existing.Name = name
 	existing.Color = color
@@ -54,5 +73,11 @@ func (uc *updateUseCase) Execute(
 	existing.UpdatedAt = time.Now().UTC()
 
 	// 4) Persist
-	return uc.categoryRepo.Update(ctx, existing)
+	updated, err := uc.categoryRepo.Update(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, uc.activityRepo, activity.CategoryEntry(userID, entity.ActivityUpdated, &before, updated))
+	uc.events.Publish(event.New(userID, event.ResourceCategory, event.ActionUpdated, updated.ID, updated).In(updated.WorkspaceID))
+	return updated, nil
 }
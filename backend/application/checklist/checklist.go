@@ -0,0 +1,58 @@
+package checklist
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+var ErrItemNotFound = apperror.NotFound("checklist item not found")
+
+// ownedTodo loads the todo an item operation targets; edit asks for write
+// access, as for the todo itself. A todo the user cannot reach is reported as
+// not found, as the todo endpoints do.
+func ownedTodo(
+	ctx context.Context,
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	userID, todoID string,
+	edit bool,
+) (*entity.Todo, error) {
+	return todo.FindAccessible(ctx, todoRepo, workspaceRepo, userID, todoID, edit)
+}
+
+// ownedItem loads an item of a todo the user may change.
+func ownedItem(
+	ctx context.Context,
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	itemRepo repository.ChecklistItemRepository,
+	userID, todoID, itemID string,
+) (*entity.ChecklistItem, error) {
+	if _, err := ownedTodo(ctx, todoRepo, workspaceRepo, userID, todoID, true); err != nil {
+		return nil, err
+	}
+	item, err := itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil || item.TodoID != todoID {
+		return nil, ErrItemNotFound
+	}
+	return item, nil
+}
+
+// publishParent announces the todo an item write changed, since its progress
+// and version moved with the item. It is best effort: the write has already
+// succeeded, so a failed re-read only costs the other devices a live update.
+func publishParent(ctx context.Context, todoRepo repository.TodoRepository, events event.Publisher, todoID string) {
+	t, err := todoRepo.FindByID(ctx, todoID)
+	if err != nil || t == nil {
+		return
+	}
+	events.Publish(event.New(t.UserID, event.ResourceTodo, event.ActionUpdated, t.ID, t).In(t.WorkspaceID))
+}
@@ -0,0 +1,64 @@
+package checklist
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
+)
+
+type CreateUseCase interface {
+	// Execute appends a new, unchecked item to the end of the todo's checklist.
+	Execute(ctx context.Context, userID, todoID, title string) (*entity.ChecklistItem, error)
+}
+
+type createUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	itemRepo      repository.ChecklistItemRepository
+	events        event.Publisher
+}
+
+func NewCreateUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	itemRepo repository.ChecklistItemRepository,
+	events event.Publisher,
+) CreateUseCase {
+	return &createUseCase{todoRepo, workspaceRepo, itemRepo, events}
+}
+
+func (uc *createUseCase) Execute(ctx context.Context, userID, todoID, title string) (*entity.ChecklistItem, error) {
+	titleVO, err := valueobject.NewTitleVO(title)
+	if err != nil {
+		return nil, apperror.InvalidField("title", err)
+	}
+	if _, err := ownedTodo(ctx, uc.todoRepo, uc.workspaceRepo, userID, todoID, true); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.itemRepo.FindAllByTodo(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	position := 0
+	if n := len(existing); n > 0 {
+		position = existing[n-1].Position + 1
+	}
+
+	item, err := entity.NewChecklistItem(uuid.NewString(), todoID, titleVO.String(), position)
+	if err != nil {
+		return nil, err
+	}
+	created, err := uc.itemRepo.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	publishParent(ctx, uc.todoRepo, uc.events, todoID)
+	return created, nil
+}
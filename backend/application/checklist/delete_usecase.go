@@ -0,0 +1,39 @@
+package checklist
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type DeleteUseCase interface {
+	Execute(ctx context.Context, userID, todoID, itemID string) error
+}
+
+type deleteUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	itemRepo      repository.ChecklistItemRepository
+	events        event.Publisher
+}
+
+func NewDeleteUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	itemRepo repository.ChecklistItemRepository,
+	events event.Publisher,
+) DeleteUseCase {
+	return &deleteUseCase{todoRepo, workspaceRepo, itemRepo, events}
+}
+
+func (uc *deleteUseCase) Execute(ctx context.Context, userID, todoID, itemID string) error {
+	if _, err := ownedItem(ctx, uc.todoRepo, uc.workspaceRepo, uc.itemRepo, userID, todoID, itemID); err != nil {
+		return err
+	}
+	if err := uc.itemRepo.Delete(ctx, itemID); err != nil {
+		return err
+	}
+	publishParent(ctx, uc.todoRepo, uc.events, todoID)
+	return nil
+}
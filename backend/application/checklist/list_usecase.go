@@ -0,0 +1,33 @@
+package checklist
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type ListUseCase interface {
+	Execute(ctx context.Context, userID, todoID string) ([]*entity.ChecklistItem, error)
+}
+
+type listUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	itemRepo      repository.ChecklistItemRepository
+}
+
+func NewListUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	itemRepo repository.ChecklistItemRepository,
+) ListUseCase {
+	return &listUseCase{todoRepo, workspaceRepo, itemRepo}
+}
+
+func (uc *listUseCase) Execute(ctx context.Context, userID, todoID string) ([]*entity.ChecklistItem, error) {
+	if _, err := ownedTodo(ctx, uc.todoRepo, uc.workspaceRepo, userID, todoID, false); err != nil {
+		return nil, err
+	}
+	return uc.itemRepo.FindAllByTodo(ctx, todoID)
+}
@@ -0,0 +1,65 @@
+package checklist
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+var ErrInvalidOrder = apperror.InvalidField("itemIds", errors.New("itemIds must list every item of the todo exactly once"))
+
+type ReorderUseCase interface {
+	// Execute puts the todo's items in the order of itemIDs, which must be a
+	// permutation of the current items.
+	Execute(ctx context.Context, userID, todoID string, itemIDs []string) ([]*entity.ChecklistItem, error)
+}
+
+type reorderUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	itemRepo      repository.ChecklistItemRepository
+	events        event.Publisher
+}
+
+func NewReorderUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	itemRepo repository.ChecklistItemRepository,
+	events event.Publisher,
+) ReorderUseCase {
+	return &reorderUseCase{todoRepo, workspaceRepo, itemRepo, events}
+}
+
+func (uc *reorderUseCase) Execute(ctx context.Context, userID, todoID string, itemIDs []string) ([]*entity.ChecklistItem, error) {
+	if _, err := ownedTodo(ctx, uc.todoRepo, uc.workspaceRepo, userID, todoID, true); err != nil {
+		return nil, err
+	}
+
+	current, err := uc.itemRepo.FindAllByTodo(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(itemIDs) != len(current) {
+		return nil, ErrInvalidOrder
+	}
+	remaining := make(map[string]struct{}, len(current))
+	for _, item := range current {
+		remaining[item.ID] = struct{}{}
+	}
+	for _, id := range itemIDs {
+		if _, ok := remaining[id]; !ok {
+			return nil, ErrInvalidOrder
+		}
+		delete(remaining, id)
+	}
+
+	if err := uc.itemRepo.Reorder(ctx, todoID, itemIDs); err != nil {
+		return nil, err
+	}
+	publishParent(ctx, uc.todoRepo, uc.events, todoID)
+	return uc.itemRepo.FindAllByTodo(ctx, todoID)
+}
@@ -0,0 +1,97 @@
+package checklist
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
+)
+
+// ItemUpdate is the outcome of renaming or checking off an item.
+type ItemUpdate struct {
+	Item *entity.ChecklistItem
+	// Todo is the parent with refreshed progress counts.
+	Todo *entity.Todo
+	// Next is set when auto-completing a recurring parent created its next occurrence.
+	Next *entity.Todo
+}
+
+type UpdateUseCase interface {
+	// Execute applies the non-nil fields. When the last open item is checked
+	// and the parent has AutoComplete set, the parent is completed too.
+	Execute(ctx context.Context, userID, todoID, itemID string, title *string, done *bool) (*ItemUpdate, error)
+}
+
+type updateUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	itemRepo      repository.ChecklistItemRepository
+	toggleStatus  todo.ToggleStatusUseCase
+	events        event.Publisher
+}
+
+func NewUpdateUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	itemRepo repository.ChecklistItemRepository,
+	toggleStatus todo.ToggleStatusUseCase,
+	events event.Publisher,
+) UpdateUseCase {
+	return &updateUseCase{todoRepo, workspaceRepo, itemRepo, toggleStatus, events}
+}
+
+func (uc *updateUseCase) Execute(
+	ctx context.Context,
+	userID, todoID, itemID string,
+	title *string,
+	done *bool,
+) (*ItemUpdate, error) {
+	item, err := ownedItem(ctx, uc.todoRepo, uc.workspaceRepo, uc.itemRepo, userID, todoID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if title != nil {
+		titleVO, err := valueobject.NewTitleVO(*title)
+		if err != nil {
+			return nil, apperror.InvalidField("title", err)
+		}
+		item.Title = titleVO.String()
+	}
+	checked := done != nil && *done && !item.Done
+	if done != nil {
+		item.Done = *done
+	}
+	item.UpdatedAt = time.Now().UTC()
+
+	updated, err := uc.itemRepo.Update(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := uc.todoRepo.FindByID(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, todo.ErrTodoNotFound
+	}
+	result := &ItemUpdate{Item: updated, Todo: parent}
+
+	if checked && parent.AutoComplete && parent.Status != entity.StatusCompleted &&
+		parent.ItemsDone == parent.ItemsTotal {
+		// ToggleStatusUseCase announces and records the completed parent itself
+		result.Todo, result.Next, err = uc.toggleStatus.Execute(ctx, userID, todoID, entity.StatusCompleted)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	publishParent(ctx, uc.todoRepo, uc.events, todoID)
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package comment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+var (
+	ErrCommentNotFound = apperror.NotFound("comment not found")
+	ErrNotAuthor       = apperror.Forbidden("only the author can change a comment")
+	ErrInvalidPageSize = apperror.InvalidField("limit", errors.New("limit must be between 1 and 200"))
+)
+
+// visibleTodo loads the todo a comment operation targets. Anyone who can read
+// the todo may read and post comments, viewers included; a todo the user
+// cannot reach is reported as not found, as the todo endpoints do.
+func visibleTodo(
+	ctx context.Context,
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	userID, todoID string,
+) (*entity.Todo, error) {
+	return todo.FindAccessible(ctx, todoRepo, workspaceRepo, userID, todoID, false)
+}
+
+// ownComment loads a comment on a todo the user can see and checks they wrote it.
+func ownComment(
+	ctx context.Context,
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	commentRepo repository.CommentRepository,
+	userID, todoID, commentID string,
+) (*entity.Todo, *entity.Comment, error) {
+	t, err := visibleTodo(ctx, todoRepo, workspaceRepo, userID, todoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c == nil || c.TodoID != todoID {
+		return nil, nil, ErrCommentNotFound
+	}
+	if c.UserID != userID {
+		return nil, nil, ErrNotAuthor
+	}
+	return t, c, nil
+}
+
+// publish announces a comment write to everyone who can see its todo.
+func publish(events event.Publisher, t *entity.Todo, action event.Action, id string, c *entity.Comment) {
+	var e any // a nil *entity.Comment would make a non-nil any
+	if c != nil {
+		e = c
+	}
+	events.Publish(event.New(t.UserID, event.ResourceComment, action, id, e).In(t.WorkspaceID))
+}
@@ -0,0 +1,50 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type CreateUseCase interface {
+	// Execute posts a comment by userID under the todo.
+	Execute(ctx context.Context, userID, todoID, body string) (*entity.Comment, error)
+}
+
+type createUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	commentRepo   repository.CommentRepository
+	events        event.Publisher
+}
+
+func NewCreateUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	commentRepo repository.CommentRepository,
+	events event.Publisher,
+) CreateUseCase {
+	return &createUseCase{todoRepo, workspaceRepo, commentRepo, events}
+}
+
+func (uc *createUseCase) Execute(ctx context.Context, userID, todoID, body string) (*entity.Comment, error) {
+	c, err := entity.NewComment(uuid.NewString(), todoID, userID, body)
+	if err != nil {
+		return nil, err
+	}
+	t, err := visibleTodo(ctx, uc.todoRepo, uc.workspaceRepo, userID, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.commentRepo.Create(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	publish(uc.events, t, event.ActionCreated, created.ID, created)
+	return created, nil
+}
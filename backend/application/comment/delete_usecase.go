@@ -0,0 +1,42 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type DeleteUseCase interface {
+	// Execute deletes one of userID's own comments; anyone else's comment is
+	// ErrNotAuthor.
+	Execute(ctx context.Context, userID, todoID, commentID string) error
+}
+
+type deleteUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	commentRepo   repository.CommentRepository
+	events        event.Publisher
+}
+
+func NewDeleteUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	commentRepo repository.CommentRepository,
+	events event.Publisher,
+) DeleteUseCase {
+	return &deleteUseCase{todoRepo, workspaceRepo, commentRepo, events}
+}
+
+func (uc *deleteUseCase) Execute(ctx context.Context, userID, todoID, commentID string) error {
+	t, c, err := ownComment(ctx, uc.todoRepo, uc.workspaceRepo, uc.commentRepo, userID, todoID, commentID)
+	if err != nil {
+		return err
+	}
+	if err := uc.commentRepo.Delete(ctx, c.ID); err != nil {
+		return err
+	}
+	publish(uc.events, t, event.ActionDeleted, c.ID, nil)
+	return nil
+}
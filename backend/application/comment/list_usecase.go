@@ -0,0 +1,43 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type ListUseCase interface {
+	// Execute returns one page of the comments on the todo, oldest first.
+	Execute(ctx context.Context, userID string, q repository.CommentQuery) (*repository.CommentPage, error)
+}
+
+type listUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	commentRepo   repository.CommentRepository
+}
+
+func NewListUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	commentRepo repository.CommentRepository,
+) ListUseCase {
+	return &listUseCase{todoRepo, workspaceRepo, commentRepo}
+}
+
+func (uc *listUseCase) Execute(ctx context.Context, userID string, q repository.CommentQuery) (*repository.CommentPage, error) {
+	if _, err := visibleTodo(ctx, uc.todoRepo, uc.workspaceRepo, userID, q.TodoID); err != nil {
+		return nil, err
+	}
+
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return nil, ErrInvalidPageSize
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	return uc.commentRepo.FindByQuery(ctx, q)
+}
@@ -0,0 +1,52 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type UpdateUseCase interface {
+	// Execute replaces the body of one of userID's own comments and marks it
+	// edited; anyone else's comment is ErrNotAuthor.
+	Execute(ctx context.Context, userID, todoID, commentID, body string) (*entity.Comment, error)
+}
+
+type updateUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	commentRepo   repository.CommentRepository
+	events        event.Publisher
+}
+
+func NewUpdateUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	commentRepo repository.CommentRepository,
+	events event.Publisher,
+) UpdateUseCase {
+	return &updateUseCase{todoRepo, workspaceRepo, commentRepo, events}
+}
+
+func (uc *updateUseCase) Execute(ctx context.Context, userID, todoID, commentID, body string) (*entity.Comment, error) {
+	t, c, err := ownComment(ctx, uc.todoRepo, uc.workspaceRepo, uc.commentRepo, userID, todoID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	before := c.Body
+	if err := c.Edit(body); err != nil {
+		return nil, err
+	}
+	if c.Body == before {
+		return c, nil
+	}
+
+	updated, err := uc.commentRepo.Update(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	publish(uc.events, t, event.ActionUpdated, updated.ID, updated)
+	return updated, nil
+}
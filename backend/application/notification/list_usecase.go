@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// InboxSize is how many of the most recent notifications the inbox shows.
+const InboxSize = 100
+
+type ListUseCase interface {
+	// Execute returns the caller's most recent in-app notifications, newest first.
+	Execute(ctx context.Context, userID string) ([]*entity.Notification, error)
+}
+
+type listUseCase struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewListUseCase(notificationRepo repository.NotificationRepository) ListUseCase {
+	return &listUseCase{notificationRepo}
+}
+
+func (uc *listUseCase) Execute(ctx context.Context, userID string) ([]*entity.Notification, error) {
+	return uc.notificationRepo.FindByUser(ctx, userID, InboxSize)
+}
@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+var ErrNotificationNotFound = apperror.NotFound("notification not found")
+
+type MarkReadUseCase interface {
+	// Execute marks one of the caller's notifications as read; marking it
+	// again keeps the first read time.
+	Execute(ctx context.Context, userID, id string) (*entity.Notification, error)
+}
+
+type markReadUseCase struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewMarkReadUseCase(notificationRepo repository.NotificationRepository) MarkReadUseCase {
+	return &markReadUseCase{notificationRepo}
+}
+
+func (uc *markReadUseCase) Execute(ctx context.Context, userID, id string) (*entity.Notification, error) {
+	n, err := uc.notificationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil || n.UserID != userID {
+		return nil, ErrNotificationNotFound
+	}
+	if err := uc.notificationRepo.MarkRead(ctx, id, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return uc.notificationRepo.FindByID(ctx, id)
+}
@@ -0,0 +1,98 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/notify"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// CreateRequest describes a new reminder; exactly one of RemindAt and
+// OffsetMinutes is set.
+type CreateRequest struct {
+	// RemindAt is RFC 3339, or a wall-clock time such as "2026-11-02T09:00"
+	// read in the owner's timezone.
+	RemindAt *string
+	// OffsetMinutes reminds that many minutes before the todo's due date.
+	OffsetMinutes *int
+	// Channel defaults to in-app.
+	Channel entity.ReminderChannel
+}
+
+type CreateUseCase interface {
+	Execute(ctx context.Context, userID, todoID string, req CreateRequest) (*entity.Reminder, error)
+}
+
+type createUseCase struct {
+	todoRepo      repository.TodoRepository
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	reminderRepo  repository.ReminderRepository
+	notifiers     map[entity.ReminderChannel]notify.Notifier
+}
+
+// NewCreateUseCase only accepts reminders on the channels in notifiers.
+func NewCreateUseCase(
+	todoRepo repository.TodoRepository,
+	userRepo repository.UserRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	reminderRepo repository.ReminderRepository,
+	notifiers map[entity.ReminderChannel]notify.Notifier,
+) CreateUseCase {
+	return &createUseCase{todoRepo, userRepo, workspaceRepo, reminderRepo, notifiers}
+}
+
+func (uc *createUseCase) Execute(ctx context.Context, userID, todoID string, req CreateRequest) (*entity.Reminder, error) {
+	if req.Channel == "" {
+		req.Channel = entity.ChannelInApp
+	}
+	if _, ok := uc.notifiers[req.Channel]; !ok {
+		return nil, ErrChannelUnavailable
+	}
+
+	t, err := ownedTodo(ctx, uc.todoRepo, uc.workspaceRepo, userID, todoID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := uc.reminderRepo.FindByTodo(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ownReminders(existing, userID)) >= MaxPerTodo {
+		return nil, ErrTooManyReminders
+	}
+
+	r := &entity.Reminder{
+		ID:            uuid.NewString(),
+		TodoID:        todoID,
+		UserID:        userID,
+		OffsetMinutes: req.OffsetMinutes,
+		Channel:       req.Channel,
+	}
+	if req.RemindAt != nil {
+		u, err := uc.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		loc := time.UTC
+		if u != nil {
+			loc = u.Location()
+		}
+		at, err := parseRemindAt(*req.RemindAt, loc)
+		if err != nil {
+			return nil, err
+		}
+		r.RemindAt = &at
+	}
+	if err := r.Schedule(t.DueDate); err != nil {
+		return nil, err
+	}
+	if !r.FireAt.After(time.Now()) {
+		return nil, ErrReminderInPast
+	}
+	return uc.reminderRepo.Create(ctx, r)
+}
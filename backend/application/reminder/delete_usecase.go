@@ -0,0 +1,39 @@
+package reminder
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type DeleteUseCase interface {
+	Execute(ctx context.Context, userID, todoID, id string) error
+}
+
+type deleteUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	reminderRepo  repository.ReminderRepository
+}
+
+func NewDeleteUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	reminderRepo repository.ReminderRepository,
+) DeleteUseCase {
+	return &deleteUseCase{todoRepo, workspaceRepo, reminderRepo}
+}
+
+func (uc *deleteUseCase) Execute(ctx context.Context, userID, todoID, id string) error {
+	if _, err := ownedTodo(ctx, uc.todoRepo, uc.workspaceRepo, userID, todoID); err != nil {
+		return err
+	}
+	r, err := uc.reminderRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if r == nil || r.TodoID != todoID || r.UserID != userID {
+		return ErrReminderNotFound
+	}
+	return uc.reminderRepo.Delete(ctx, id)
+}
@@ -0,0 +1,139 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/notify"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+const (
+	// claimLease is how long a claimed reminder is hidden from other
+	// schedulers; a crash mid-delivery retries it after this.
+	claimLease = 5 * time.Minute
+	// dispatchBatch is how many reminders are claimed at once.
+	dispatchBatch = 100
+)
+
+type DispatchUseCase interface {
+	// Execute delivers every reminder due at now and reports how many were
+	// sent. A failed delivery is retried later with backoff, up to
+	// entity.MaxReminderAttempts; reminders of completed or trashed todos, and
+	// of todos their user can no longer see, are cancelled instead of sent.
+	Execute(ctx context.Context, now time.Time) (int, error)
+}
+
+type dispatchUseCase struct {
+	todoRepo      repository.TodoRepository
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	reminderRepo  repository.ReminderRepository
+	notifiers     map[entity.ReminderChannel]notify.Notifier
+}
+
+func NewDispatchUseCase(
+	todoRepo repository.TodoRepository,
+	userRepo repository.UserRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	reminderRepo repository.ReminderRepository,
+	notifiers map[entity.ReminderChannel]notify.Notifier,
+) DispatchUseCase {
+	return &dispatchUseCase{todoRepo, userRepo, workspaceRepo, reminderRepo, notifiers}
+}
+
+func (uc *dispatchUseCase) Execute(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	for {
+		// claimed reminders are pushed past now, so the next claim moves on
+		batch, err := uc.reminderRepo.ClaimDue(ctx, now, claimLease, dispatchBatch)
+		if err != nil {
+			return sent, err
+		}
+		for _, r := range batch {
+			if uc.deliver(ctx, r, now) {
+				sent++
+			}
+		}
+		if len(batch) < dispatchBatch || ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+	}
+}
+
+// deliver sends one claimed reminder and records the outcome on it.
+func (uc *dispatchUseCase) deliver(ctx context.Context, r *entity.Reminder, now time.Time) bool {
+	// the user may have left the todo's workspace since setting the reminder
+	t, err := ownedTodo(ctx, uc.todoRepo, uc.workspaceRepo, r.UserID, r.TodoID)
+	if err != nil && !errors.Is(err, todo.ErrTodoNotFound) {
+		uc.retry(ctx, r, now, err)
+		return false
+	}
+	if t == nil || t.Status == entity.StatusCompleted {
+		r.Status = entity.ReminderCancelled
+		uc.save(ctx, r)
+		return false
+	}
+	u, err := uc.userRepo.FindByID(ctx, r.UserID)
+	if err != nil {
+		uc.retry(ctx, r, now, err)
+		return false
+	}
+
+	notifier, ok := uc.notifiers[r.Channel]
+	if !ok {
+		// the channel was switched off after the reminder was set; retrying will not help
+		uc.giveUp(ctx, r, fmt.Errorf("channel %q is not enabled", r.Channel))
+		return false
+	}
+	if err := notifier.Notify(ctx, newMessage(r, t, u)); err != nil {
+		uc.retry(ctx, r, now, err)
+		return false
+	}
+
+	sentAt := now.UTC()
+	r.Status = entity.ReminderSent
+	r.Attempts++
+	r.SentAt = &sentAt
+	r.LastError = nil
+	uc.save(ctx, r)
+	return true
+}
+
+// retry records a failed attempt and schedules the next, or gives up once
+// the reminder has used all its attempts.
+func (uc *dispatchUseCase) retry(ctx context.Context, r *entity.Reminder, now time.Time, cause error) {
+	if r.Attempts+1 >= entity.MaxReminderAttempts {
+		uc.giveUp(ctx, r, cause)
+		return
+	}
+	r.Attempts++
+	msg := cause.Error()
+	r.LastError = &msg
+	r.NextAttemptAt = now.Add(entity.RetryDelay(r.Attempts)).UTC()
+	log.Printf("reminder %s attempt %d failed, retrying at %s: %v", r.ID, r.Attempts, r.NextAttemptAt.Format(time.RFC3339), cause)
+	uc.save(ctx, r)
+}
+
+// giveUp records a final failed attempt and marks the reminder failed.
+func (uc *dispatchUseCase) giveUp(ctx context.Context, r *entity.Reminder, cause error) {
+	r.Attempts++
+	msg := cause.Error()
+	r.LastError = &msg
+	r.Status = entity.ReminderFailed
+	log.Printf("reminder %s failed after %d attempts: %v", r.ID, r.Attempts, cause)
+	uc.save(ctx, r)
+}
+
+// save writes the delivery state. If it fails the claim simply expires and
+// the reminder is tried again; the message key lets receivers drop the repeat.
+func (uc *dispatchUseCase) save(ctx context.Context, r *entity.Reminder) {
+	if err := uc.reminderRepo.Update(ctx, r); err != nil {
+		log.Printf("reminder %s: failed to save delivery state: %v", r.ID, err)
+	}
+}
@@ -0,0 +1,39 @@
+package reminder
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type ListUseCase interface {
+	// Execute returns the reminders userID set on the todo, soonest first,
+	// whatever their status.
+	Execute(ctx context.Context, userID, todoID string) ([]*entity.Reminder, error)
+}
+
+type listUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	reminderRepo  repository.ReminderRepository
+}
+
+func NewListUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	reminderRepo repository.ReminderRepository,
+) ListUseCase {
+	return &listUseCase{todoRepo, workspaceRepo, reminderRepo}
+}
+
+func (uc *listUseCase) Execute(ctx context.Context, userID, todoID string) ([]*entity.Reminder, error) {
+	if _, err := ownedTodo(ctx, uc.todoRepo, uc.workspaceRepo, userID, todoID); err != nil {
+		return nil, err
+	}
+	reminders, err := uc.reminderRepo.FindByTodo(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	return ownReminders(reminders, userID), nil
+}
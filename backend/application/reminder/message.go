@@ -0,0 +1,39 @@
+package reminder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/notify"
+)
+
+// newMessage renders the reminder for u, with times in u's timezone. u may
+// be nil when the account is gone; times are then shown in UTC.
+func newMessage(r *entity.Reminder, t *entity.Todo, u *entity.User) notify.Message {
+	loc := time.UTC
+	m := notify.Message{
+		// the same firing always yields the same key; moving the reminder yields a new one
+		Key:     fmt.Sprintf("%s-%d", r.ID, r.FireAt.Unix()),
+		UserID:  r.UserID,
+		TodoID:  t.ID,
+		Title:   t.Title,
+		DueDate: t.DueDate,
+		Subject: "Reminder: " + t.Title,
+	}
+	if u != nil {
+		m.Email = u.Email
+		loc = u.Location()
+	}
+
+	lines := []string{t.Title}
+	if t.DueDate != nil {
+		lines = append(lines, "Due "+t.DueDate.In(loc).Format("Mon, 2 Jan 2006 15:04 MST"))
+	}
+	if t.Body != nil && *t.Body != "" {
+		lines = append(lines, "", *t.Body)
+	}
+	m.Text = strings.Join(lines, "\n")
+	return m
+}
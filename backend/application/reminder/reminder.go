@@ -0,0 +1,60 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// MaxPerTodo caps how many reminders one user may set on one todo.
+const MaxPerTodo = 10
+
+var (
+	ErrReminderNotFound   = apperror.NotFound("reminder not found")
+	ErrTooManyReminders   = apperror.Validation("a todo can have at most 10 reminders")
+	ErrInvalidRemindAt    = apperror.InvalidField("remindAt", errors.New(`remindAt must be RFC 3339 or a local time like "2026-11-02T09:00"`))
+	ErrReminderInPast     = apperror.InvalidField("remindAt", errors.New("the reminder time has already passed"))
+	ErrChannelUnavailable = apperror.InvalidField("channel", errors.New("this reminder channel is not enabled"))
+)
+
+// ownedTodo loads the todo a reminder belongs to. Reminders are personal, so
+// any member who can read a workspace todo may set their own on it; a todo
+// the user cannot read is reported as not found, as the todo endpoints do.
+func ownedTodo(
+	ctx context.Context,
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	userID, todoID string,
+) (*entity.Todo, error) {
+	return todo.FindAccessible(ctx, todoRepo, workspaceRepo, userID, todoID, false)
+}
+
+// ownReminders keeps the reminders userID set.
+func ownReminders(reminders []*entity.Reminder, userID string) []*entity.Reminder {
+	out := make([]*entity.Reminder, 0, len(reminders))
+	for _, r := range reminders {
+		if r.UserID == userID {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// parseRemindAt reads an RFC 3339 time, or a wall-clock time without offset
+// that is taken to be in loc.
+func parseRemindAt(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrInvalidRemindAt
+}
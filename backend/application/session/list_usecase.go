@@ -0,0 +1,37 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type ListUseCase interface {
+	// Execute returns the user's active sessions, newest first.
+	Execute(ctx context.Context, userID string) ([]*entity.Session, error)
+}
+
+type listUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+func NewListUseCase(sessionRepo repository.SessionRepository) ListUseCase {
+	return &listUseCase{sessionRepo}
+}
+
+func (uc *listUseCase) Execute(ctx context.Context, userID string) ([]*entity.Session, error) {
+	all, err := uc.sessionRepo.FindAllByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	active := []*entity.Session{}
+	for _, s := range all {
+		if s.Active(now) {
+			active = append(active, s)
+		}
+	}
+	return active, nil
+}
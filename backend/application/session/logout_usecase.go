@@ -0,0 +1,40 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+)
+
+type LogoutUseCase interface {
+	// Execute signs out the session refreshToken belongs to. An unknown or
+	// stale token is ignored so logging out always succeeds.
+	Execute(ctx context.Context, refreshToken string) error
+}
+
+type logoutUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+func NewLogoutUseCase(sessionRepo repository.SessionRepository) LogoutUseCase {
+	return &logoutUseCase{sessionRepo}
+}
+
+func (uc *logoutUseCase) Execute(ctx context.Context, refreshToken string) error {
+	sessionID, secret, ok := auth.ParseRefreshToken(refreshToken)
+	if !ok {
+		return nil
+	}
+	s, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if s == nil || !auth.RefreshSecretMatches(secret, s.RefreshTokenHash) {
+		return nil
+	}
+	s.Revoke(time.Now().UTC())
+	_, err = uc.sessionRepo.Update(ctx, s)
+	return err
+}
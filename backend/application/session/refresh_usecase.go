@@ -0,0 +1,71 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+)
+
+type RefreshUseCase interface {
+	// Execute trades a refresh token for a new access token and a new refresh
+	// token. Presenting an already rotated refresh token revokes the session,
+	// since it means the token was copied. Of two refreshes racing with the
+	// same token only one succeeds; the other is refused but leaves the
+	// session alone.
+	Execute(ctx context.Context, refreshToken string) (*Tokens, error)
+}
+
+type refreshUseCase struct {
+	sessionRepo repository.SessionRepository
+	authClient  auth.AuthClientInterface
+}
+
+func NewRefreshUseCase(sessionRepo repository.SessionRepository, authClient auth.AuthClientInterface) RefreshUseCase {
+	return &refreshUseCase{sessionRepo, authClient}
+}
+
+func (uc *refreshUseCase) Execute(ctx context.Context, refreshToken string) (*Tokens, error) {
+	sessionID, secret, ok := auth.ParseRefreshToken(refreshToken)
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+	s, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if s == nil || !s.Active(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if !auth.RefreshSecretMatches(secret, s.RefreshTokenHash) {
+		s.Revoke(now)
+		if _, err := uc.sessionRepo.Update(ctx, s); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	next, hash, err := auth.NewRefreshToken(s.ID)
+	if err != nil {
+		return nil, err
+	}
+	previousHash := s.RefreshTokenHash
+	s.RefreshTokenHash = hash
+	s.LastUsedAt = now
+	s.ExpiresAt = now.Add(RefreshTokenTTL)
+	rotated, err := uc.sessionRepo.Rotate(ctx, s, previousHash)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	accessToken, err := uc.authClient.GenerateToken(s.UserID, s.ID, AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{SessionID: s.ID, AccessToken: accessToken, RefreshToken: next}, nil
+}
@@ -0,0 +1,25 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type RevokeAllUseCase interface {
+	// Execute signs out every session of the user, the calling one included.
+	Execute(ctx context.Context, userID string) error
+}
+
+type revokeAllUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+func NewRevokeAllUseCase(sessionRepo repository.SessionRepository) RevokeAllUseCase {
+	return &revokeAllUseCase{sessionRepo}
+}
+
+func (uc *revokeAllUseCase) Execute(ctx context.Context, userID string) error {
+	return uc.sessionRepo.RevokeAllByUser(ctx, userID, time.Now().UTC())
+}
@@ -0,0 +1,35 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type RevokeUseCase interface {
+	// Execute signs out one of the user's sessions. Another user's session is
+	// reported as not found.
+	Execute(ctx context.Context, userID, sessionID string) error
+}
+
+type revokeUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+func NewRevokeUseCase(sessionRepo repository.SessionRepository) RevokeUseCase {
+	return &revokeUseCase{sessionRepo}
+}
+
+func (uc *revokeUseCase) Execute(ctx context.Context, userID, sessionID string) error {
+	s, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if s == nil || s.UserID != userID {
+		return ErrSessionNotFound
+	}
+	s.Revoke(time.Now().UTC())
+	_, err = uc.sessionRepo.Update(ctx, s)
+	return err
+}
@@ -0,0 +1,26 @@
+package session
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+const (
+	// AccessTokenTTL bounds how long a stolen access token stays usable.
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL is the idle lifetime of a session; each refresh extends it.
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidRefreshToken = apperror.Unauthorized("invalid or expired refresh token")
+	ErrSessionNotFound     = apperror.NotFound("session not found")
+)
+
+// Tokens is what a client receives when a session starts or is refreshed.
+type Tokens struct {
+	SessionID    string
+	AccessToken  string
+	RefreshToken string
+}
@@ -0,0 +1,46 @@
+package session
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+)
+
+type StartUseCase interface {
+	// Execute opens a new session for an already authenticated user.
+	Execute(ctx context.Context, userID, userAgent string) (*Tokens, error)
+}
+
+type startUseCase struct {
+	sessionRepo repository.SessionRepository
+	authClient  auth.AuthClientInterface
+}
+
+func NewStartUseCase(sessionRepo repository.SessionRepository, authClient auth.AuthClientInterface) StartUseCase {
+	return &startUseCase{sessionRepo, authClient}
+}
+
+func (uc *startUseCase) Execute(ctx context.Context, userID, userAgent string) (*Tokens, error) {
+	id := uuid.NewString()
+	refreshToken, hash, err := auth.NewRefreshToken(id)
+	if err != nil {
+		return nil, err
+	}
+	s, err := entity.NewSession(id, userID, hash, userAgent, RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.sessionRepo.Create(ctx, s); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := uc.authClient.GenerateToken(userID, id, AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{SessionID: id, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
@@ -6,30 +6,44 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
 )
 
 type CreateUseCase interface {
-	Execute(ctx context.Context, userID, name string) (*entity.Tag, error)
+	// workspaceID: the workspace to create the tag in, where the caller must
+	// be an editor; nil creates a personal one
+	Execute(ctx context.Context, userID string, workspaceID *string, name string) (*entity.Tag, error)
 }
 
 type createUseCase struct {
-	tagRepo repository.TagRepository
+	tagRepo       repository.TagRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
 }
 
-func NewCreateUseCase(tagRepo repository.TagRepository) CreateUseCase {
-	return &createUseCase{tagRepo}
+func NewCreateUseCase(
+	tagRepo repository.TagRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) CreateUseCase {
+	return &createUseCase{tagRepo, workspaceRepo, activityRepo, events}
 }
 
-func (uc *createUseCase) Execute(ctx context.Context, userID, name string) (*entity.Tag, error) {
+func (uc *createUseCase) Execute(ctx context.Context, userID string, workspaceID *string, name string) (*entity.Tag, error) {
 	fmt.Println("UC START: Creating tag with name =", name)
 
 	nameVO, err := valueobject.NewTitleVO(name)
 	if err != nil {
 		fmt.Println("UC ERROR: TitleVO failed:", err)
-		return nil, err
+		return nil, apperror.InvalidField("name", err)
 	}
 	fmt.Println("UC OK: TitleVO =", nameVO)
 
@@ -51,12 +65,19 @@ func (uc *createUseCase) Execute(ctx context.Context, userID, name string) (*ent
 	}
 	fmt.Println("UC OK: Created tagEntity =", tagEntity)
 
+	if err := workspace.CheckScope(ctx, uc.workspaceRepo, userID, workspaceID, true); err != nil {
+		return nil, err
+	}
+	tagEntity.WorkspaceID = workspaceID
+
 	created, err := uc.tagRepo.Create(ctx, tagEntity)
 	if err != nil {
 		fmt.Println("UC ERROR: tagRepo.Create failed:", err)
 		return nil, err
 	}
 	fmt.Println("UC SUCCESS: Tag created =", created)
+	activity.Record(ctx, uc.activityRepo, activity.TagEntry(userID, entity.ActivityCreated, nil, created))
+	uc.events.Publish(event.New(created.UserID, event.ResourceTag, event.ActionCreated, created.ID, created).In(created.WorkspaceID))
 
 	return created, nil
 }
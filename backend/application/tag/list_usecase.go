@@ -3,22 +3,32 @@ package tag
 import (
 	"context"
 
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
 
 type ListUseCase interface {
-	Execute(ctx context.Context, userID string) ([]*entity.Tag, error)
+	// Execute lists the caller's personal tags, or with a workspaceID the
+	// tags of a workspace they belong to.
+	Execute(ctx context.Context, userID string, workspaceID *string) ([]*entity.Tag, error)
 }
 
 type listUseCase struct {
-	tagRepo repository.TagRepository
+	tagRepo       repository.TagRepository
+	workspaceRepo repository.WorkspaceRepository
 }
 
-func NewListUseCase(tagRepo repository.TagRepository) ListUseCase {
-	return &listUseCase{tagRepo}
+func NewListUseCase(tagRepo repository.TagRepository, workspaceRepo repository.WorkspaceRepository) ListUseCase {
+	return &listUseCase{tagRepo, workspaceRepo}
 }
 
-func (uc *listUseCase) Execute(ctx context.Context, userID string) ([]*entity.Tag, error) {
-	return uc.tagRepo.FindAllByUser(ctx, userID)
+func (uc *listUseCase) Execute(ctx context.Context, userID string, workspaceID *string) ([]*entity.Tag, error) {
+	if workspaceID == nil {
+		return uc.tagRepo.FindAllByUser(ctx, userID)
+	}
+	if err := workspace.CheckScope(ctx, uc.workspaceRepo, userID, workspaceID, false); err != nil {
+		return nil, err
+	}
+	return uc.tagRepo.FindAllByWorkspace(ctx, *workspaceID)
 }
@@ -2,35 +2,81 @@ package tag
 
 import (
 	"context"
-	"errors"
 	"time"
 
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
 )
 
-var ErrTagNotFound = errors.New("tag not found")
+var (
+	ErrTagNotFound  = apperror.NotFound("tag not found")
+	ErrTagForbidden = apperror.Forbidden("cannot modify another user's tag")
+)
 
 type UpdateUseCase interface {
-	Execute(ctx context.Context, t *entity.Tag) (*entity.Tag, error)
+	// userID: the caller's ID
+	// id: the tag to update
+	// name: new value
+	// ifMatch: versions the caller last saw; empty skips the check
+	Execute(ctx context.Context, userID, id, name string, ifMatch []int) (*entity.Tag, error)
 }
 
 type updateUseCase struct {
-	tagRepo repository.TagRepository
+	tagRepo       repository.TagRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
 }
 
-func NewUpdateUseCase(tagRepo repository.TagRepository) UpdateUseCase {
-	return &updateUseCase{tagRepo}
+func NewUpdateUseCase(
+	tagRepo repository.TagRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) UpdateUseCase {
+	return &updateUseCase{tagRepo, workspaceRepo, activityRepo, events}
 }
 
-func (uc *updateUseCase) Execute(ctx context.Context, t *entity.Tag) (*entity.Tag, error) {
-	existing, err := uc.tagRepo.FindByID(ctx, t.ID)
+func (uc *updateUseCase) Execute(ctx context.Context, userID, id, name string, ifMatch []int) (*entity.Tag, error) {
+	// 1) Validate
+	nameVO, err := valueobject.NewTitleVO(name)
+	if err != nil {
+		return nil, apperror.InvalidField("name", err)
+	}
+
+	// 2) Fetch existing
+	existing, err := uc.tagRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	if existing == nil {
 		return nil, ErrTagNotFound
 	}
-	t.UpdatedAt = time.Now().UTC()
-	return uc.tagRepo.Update(ctx, t)
+
+	// 3) Authorization: the owner, or an editor of its workspace
+	if err := workspace.CheckRecord(ctx, uc.workspaceRepo, userID, existing.UserID, existing.WorkspaceID, true, ErrTagForbidden); err != nil {
+		return nil, err
+	}
+	if !repository.VersionMatches(ifMatch, existing.Version) {
+		return nil, repository.ErrStaleVersion
+	}
+
+	// the old values go to the audit log
+	before := *existing
+
+	// 4) Apply & persist
+	existing.Name = nameVO.String()
+	existing.UpdatedAt = time.Now().UTC()
+	updated, err := uc.tagRepo.Update(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, uc.activityRepo, activity.TagEntry(userID, entity.ActivityUpdated, &before, updated))
+	uc.events.Publish(event.New(userID, event.ResourceTag, event.ActionUpdated, updated.ID, updated).In(updated.WorkspaceID))
+	return updated, nil
 }
@@ -0,0 +1,48 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// FindAccessible loads a todo userID may read, or with edit also change: one
+// of their personal todos, or a todo of a workspace they belong to, where
+// changes take an editor. Any other todo is reported as not found, so IDs
+// cannot be probed. The checklist and reminder use cases share it.
+func FindAccessible(
+	ctx context.Context,
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	userID, id string,
+	edit bool,
+) (*entity.Todo, error) {
+	t, err := todoRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTodoNotFound
+	}
+	if err := checkAccess(ctx, workspaceRepo, userID, t, edit); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// checkAccess is FindAccessible's check for a todo already loaded, such as
+// one from the trash.
+func checkAccess(ctx context.Context, workspaceRepo repository.WorkspaceRepository, userID string, t *entity.Todo, edit bool) error {
+	return workspace.CheckRecord(ctx, workspaceRepo, userID, t.UserID, t.WorkspaceID, edit, ErrTodoNotFound)
+}
+
+// boardOf returns every todo on t's board: the workspace's todos, or its
+// owner's personal ones.
+func boardOf(ctx context.Context, repo repository.TodoRepository, t *entity.Todo) ([]*entity.Todo, error) {
+	if t.WorkspaceID != nil {
+		return repo.FindAllByWorkspace(ctx, *t.WorkspaceID)
+	}
+	return repo.FindAllByUser(ctx, t.UserID)
+}
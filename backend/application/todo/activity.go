@@ -0,0 +1,20 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// recordSaved logs an update to a todo and, when there is one, the creation of
+// the next occurrence that completing it spawned. It is publishSaved's
+// counterpart for the audit log.
+func recordSaved(ctx context.Context, repo repository.ActivityRepository, actorID string, before, updated, next *entity.Todo) {
+	entries := []*entity.Activity{activity.TodoEntry(actorID, entity.ActivityUpdated, before, updated)}
+	if next != nil {
+		entries = append(entries, activity.TodoEntry(actorID, entity.ActivityCreated, nil, next))
+	}
+	activity.Record(ctx, repo, entries...)
+}
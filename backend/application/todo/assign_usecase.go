@@ -0,0 +1,93 @@
+package todo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+var (
+	ErrAssigneeNotFound  = apperror.InvalidField("userId", errors.New("user does not exist"))
+	ErrAssigneeNoAccess  = apperror.InvalidField("userId", errors.New("user cannot see this todo"))
+	ErrAssigneeNotOnTodo = apperror.NotFound("user is not assigned to this todo")
+)
+
+type AssignUseCase interface {
+	// Execute assigns the user to the todo and returns it. The assignee must
+	// be able to read the todo: its owner for a personal todo, or a member of
+	// its workspace. Assigning someone twice changes nothing.
+	// actorID is who makes the change; see UpdateUseCase.
+	Execute(ctx context.Context, actorID, id, userID string) (*entity.Todo, error)
+}
+
+type assignUseCase struct {
+	todoRepo      repository.TodoRepository
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
+}
+
+func NewAssignUseCase(
+	todoRepo repository.TodoRepository,
+	userRepo repository.UserRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) AssignUseCase {
+	return &assignUseCase{todoRepo, userRepo, workspaceRepo, activityRepo, events}
+}
+
+func (uc *assignUseCase) Execute(ctx context.Context, actorID, id, userID string) (*entity.Todo, error) {
+	t, err := FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, actorID, id, true)
+	if err != nil {
+		return nil, err
+	}
+	u, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrAssigneeNotFound
+	}
+	if err := checkAccess(ctx, uc.workspaceRepo, u.ID, t, false); err != nil {
+		if errors.Is(err, ErrTodoNotFound) {
+			return nil, ErrAssigneeNoAccess
+		}
+		return nil, err
+	}
+	if t.IsAssigned(u.ID) {
+		return t, nil
+	}
+
+	if err := uc.todoRepo.AddAssignee(ctx, t.ID, u.ID); err != nil {
+		return nil, err
+	}
+	return saveAssignees(ctx, uc.todoRepo, uc.activityRepo, uc.events, actorID, t)
+}
+
+// saveAssignees reloads a todo whose assignees were just changed from before,
+// then records and announces the change.
+func saveAssignees(
+	ctx context.Context,
+	todoRepo repository.TodoRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+	actorID string,
+	before *entity.Todo,
+) (*entity.Todo, error) {
+	updated, err := todoRepo.FindByID(ctx, before.ID)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrTodoNotFound
+	}
+	recordSaved(ctx, activityRepo, actorID, before, updated, nil)
+	publishSaved(events, updated, nil)
+	return updated, nil
+}
@@ -0,0 +1,390 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// MaxBulkSize caps how many todos one bulk request may touch.
+const MaxBulkSize = 200
+
+type BulkAction string
+
+const (
+	BulkSetStatus   BulkAction = "set_status"
+	BulkSetCategory BulkAction = "set_category"
+	BulkAddTags     BulkAction = "add_tags"
+	BulkRemoveTags  BulkAction = "remove_tags"
+	BulkSetDueDate  BulkAction = "set_due_date"
+	BulkDelete      BulkAction = "delete"
+	BulkDuplicate   BulkAction = "duplicate"
+)
+
+var (
+	ErrInvalidBulkIDs    = apperror.InvalidField("ids", fmt.Errorf("ids must list between 1 and %d todos", MaxBulkSize))
+	ErrInvalidBulkAction = apperror.InvalidField("action", errors.New("unknown bulk action"))
+	ErrBulkTagsRequired  = apperror.InvalidField("tagIds", errors.New("tagIds must not be empty"))
+	ErrRecurringNeedsDue = apperror.InvalidField("dueDate", errors.New("a recurring todo needs a due date"))
+)
+
+// BulkRequest applies one action to many todos. Only the field the action
+// needs is read: Status, CategoryID (nil clears), TagIDs or DueDate (nil clears).
+// WorkspaceID picks where the todos live: the caller's personal todos when
+// nil, else the workspace, where the caller must be an editor. IDs from
+// anywhere else are reported as not found.
+type BulkRequest struct {
+	IDs         []string
+	WorkspaceID *string
+	Action      BulkAction
+	Status      entity.Status
+	CategoryID  *string
+	TagIDs      []string
+	DueDate     *time.Time
+}
+
+// BulkResult is the outcome for one requested ID. Todo is the todo after the
+// action (the copy for duplicate, nil for delete); Next is set when completing
+// a recurring todo created its next occurrence. Err is an *apperror.Error.
+type BulkResult struct {
+	ID   string
+	Todo *entity.Todo
+	Next *entity.Todo
+	Err  error
+}
+
+type BulkUseCase interface {
+	// Execute returns one result per distinct ID, in request order. Errors
+	// that concern a single todo are reported in its result; an error that
+	// concerns the whole request or a failed write is returned instead.
+	Execute(ctx context.Context, userID string, req BulkRequest) ([]BulkResult, error)
+}
+
+type bulkUseCase struct {
+	todoRepo      repository.TodoRepository
+	categoryRepo  repository.CategoryRepository
+	tagRepo       repository.TagRepository
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	reminderRepo  repository.ReminderRepository
+	events        event.Publisher
+}
+
+func NewBulkUseCase(
+	todoRepo repository.TodoRepository,
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+	userRepo repository.UserRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	reminderRepo repository.ReminderRepository,
+	events event.Publisher,
+) BulkUseCase {
+	return &bulkUseCase{todoRepo, categoryRepo, tagRepo, userRepo, workspaceRepo, activityRepo, reminderRepo, events}
+}
+
+func (uc *bulkUseCase) Execute(ctx context.Context, userID string, req BulkRequest) ([]BulkResult, error) {
+	ids := dedupe(req.IDs)
+	if len(ids) == 0 || len(ids) > MaxBulkSize {
+		return nil, ErrInvalidBulkIDs
+	}
+	if err := workspace.CheckScope(ctx, uc.workspaceRepo, userID, req.WorkspaceID, true); err != nil {
+		return nil, err
+	}
+	refs := newReferenceChecker(uc.categoryRepo, uc.tagRepo, userID, req.WorkspaceID)
+	if err := uc.validate(ctx, refs, req); err != nil {
+		return nil, err
+	}
+
+	// one scope check for the whole batch
+	found, err := uc.todoRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[string]*entity.Todo, len(found))
+	for _, t := range found {
+		if inBulkScope(t, userID, req.WorkspaceID) {
+			owned[t.ID] = t
+		}
+	}
+
+	results := make([]BulkResult, len(ids))
+	var targets []*entity.Todo
+	for i, id := range ids {
+		results[i].ID = id
+		if t, ok := owned[id]; ok {
+			targets = append(targets, t)
+		} else {
+			results[i].Err = ErrTodoNotFound
+		}
+	}
+	if len(targets) == 0 {
+		return results, nil
+	}
+
+	// apply may change targets in place; the audit log needs them as they were
+	before := make(map[string]*entity.Todo, len(targets))
+	for _, t := range targets {
+		snapshot := *t
+		before[t.ID] = &snapshot
+	}
+
+	outcome, err := uc.apply(ctx, userID, refs, req, targets)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		if r, ok := outcome[results[i].ID]; ok {
+			results[i] = r
+		}
+	}
+	uc.record(ctx, userID, req.Action, before, results)
+	uc.publish(userID, req.WorkspaceID, req.Action, results)
+	return results, nil
+}
+
+// inBulkScope reports whether t lives where the bulk request points.
+func inBulkScope(t *entity.Todo, userID string, workspaceID *string) bool {
+	if workspaceID == nil {
+		return t.WorkspaceID == nil && t.UserID == userID
+	}
+	return t.WorkspaceID != nil && *t.WorkspaceID == *workspaceID
+}
+
+// record logs every todo the action wrote, as publish announces them.
+func (uc *bulkUseCase) record(ctx context.Context, userID string, action BulkAction, before map[string]*entity.Todo, results []BulkResult) {
+	var entries []*entity.Activity
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+		case action == BulkDelete:
+			entries = append(entries, activity.TodoEntry(userID, entity.ActivityDeleted, before[r.ID], nil))
+		case action == BulkDuplicate:
+			entries = append(entries, activity.TodoEntry(userID, entity.ActivityCreated, nil, r.Todo))
+		case r.Todo != nil:
+			entries = append(entries, activity.TodoEntry(userID, entity.ActivityUpdated, before[r.ID], r.Todo))
+			if r.Next != nil {
+				entries = append(entries, activity.TodoEntry(userID, entity.ActivityCreated, nil, r.Next))
+			}
+		}
+	}
+	activity.Record(ctx, uc.activityRepo, entries...)
+}
+
+// publish announces every todo the action wrote, one event each.
+func (uc *bulkUseCase) publish(userID string, workspaceID *string, action BulkAction, results []BulkResult) {
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+		case action == BulkDelete:
+			uc.events.Publish(event.New(userID, event.ResourceTodo, event.ActionDeleted, r.ID, nil).In(workspaceID))
+		case action == BulkDuplicate:
+			publishTodo(uc.events, event.ActionCreated, r.Todo)
+		case r.Todo != nil:
+			publishSaved(uc.events, r.Todo, r.Next)
+		}
+	}
+}
+
+// validate checks the action's payload before any todo is loaded. The
+// category and tags an action attaches must live where the todos do.
+func (uc *bulkUseCase) validate(ctx context.Context, refs *referenceChecker, req BulkRequest) error {
+	switch req.Action {
+	case BulkSetStatus:
+		if !isValidStatus(req.Status) {
+			return ErrInvalidStatus
+		}
+	case BulkSetCategory:
+		return refs.check(ctx, req.CategoryID, nil)
+	case BulkAddTags:
+		if len(req.TagIDs) == 0 {
+			return ErrBulkTagsRequired
+		}
+		return refs.check(ctx, nil, req.TagIDs)
+	case BulkRemoveTags:
+		if len(req.TagIDs) == 0 {
+			return ErrBulkTagsRequired
+		}
+	case BulkSetDueDate, BulkDelete, BulkDuplicate:
+	default:
+		return ErrInvalidBulkAction
+	}
+	return nil
+}
+
+// apply runs the action over the todos in scope with batched writes and returns a result per todo ID.
+func (uc *bulkUseCase) apply(ctx context.Context, userID string, refs *referenceChecker, req BulkRequest, targets []*entity.Todo) (map[string]BulkResult, error) {
+	results := make(map[string]BulkResult, len(targets))
+	ids := make([]string, 0, len(targets))
+	for _, t := range targets {
+		ids = append(ids, t.ID)
+	}
+
+	switch req.Action {
+	case BulkDelete:
+		if err := uc.todoRepo.DeleteMany(ctx, ids); err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			results[id] = BulkResult{ID: id}
+		}
+		return results, nil
+
+	case BulkDuplicate:
+		// a copy attaches the original's category and tags anew; the caller owns it
+		var originals, copies []*entity.Todo
+		for _, t := range targets {
+			if err := refs.check(ctx, t.CategoryID, t.TagIDs); err != nil {
+				var appErr *apperror.Error
+				if !errors.As(err, &appErr) {
+					return nil, err
+				}
+				results[t.ID] = BulkResult{ID: t.ID, Err: err}
+				continue
+			}
+			originals = append(originals, t)
+			copies = append(copies, duplicateOf(t, userID))
+		}
+		if err := rankAtEnd(ctx, uc.todoRepo, copies...); err != nil {
+			return nil, err
+		}
+		created, err := uc.todoRepo.CreateMany(ctx, copies)
+		if err != nil {
+			return nil, err
+		}
+		for i, t := range originals {
+			results[t.ID] = BulkResult{ID: t.ID, Todo: created[i]}
+		}
+		return results, nil
+
+	case BulkSetStatus:
+		if err := uc.setStatus(ctx, req.Status, targets, results); err != nil {
+			return nil, err
+		}
+
+	case BulkSetCategory:
+		patch := repository.TodoPatch{SetCategory: true, CategoryID: req.CategoryID}
+		if err := uc.todoRepo.UpdateMany(ctx, ids, patch); err != nil {
+			return nil, err
+		}
+
+	case BulkSetDueDate:
+		var writable []string
+		for _, t := range targets {
+			if req.DueDate == nil && t.Recurrence != nil {
+				results[t.ID] = BulkResult{ID: t.ID, Err: ErrRecurringNeedsDue}
+				continue
+			}
+			writable = append(writable, t.ID)
+		}
+		patch := repository.TodoPatch{SetDueDate: true, DueDate: req.DueDate}
+		if err := uc.todoRepo.UpdateMany(ctx, writable, patch); err != nil {
+			return nil, err
+		}
+		rescheduleReminders(ctx, uc.reminderRepo, writable, req.DueDate)
+
+	case BulkAddTags:
+		if err := uc.todoRepo.AddTags(ctx, ids, req.TagIDs); err != nil {
+			return nil, err
+		}
+
+	case BulkRemoveTags:
+		if err := uc.todoRepo.RemoveTags(ctx, ids, req.TagIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	// read back every todo that was not rejected, in one query
+	fresh, err := uc.todoRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range fresh {
+		r, rejected := results[t.ID]
+		if rejected && r.Err != nil {
+			continue
+		}
+		r.ID, r.Todo = t.ID, t
+		results[t.ID] = r
+	}
+	return results, nil
+}
+
+// setStatus writes the new status to every todo not already in it and puts
+// them at the bottom of the new status column, in request order. Completing
+// a recurring todo spawns its next occurrence, as ToggleStatusUseCase does,
+// once the status has been written.
+func (uc *bulkUseCase) setStatus(ctx context.Context, status entity.Status, targets []*entity.Todo, results map[string]BulkResult) error {
+	var changed, stopped []string
+	var moved []*entity.Todo
+	nexts := map[string]*entity.Todo{}
+	for _, t := range targets {
+		if t.Status == status {
+			continue
+		}
+		changed = append(changed, t.ID)
+		m := *t
+		m.Status = status
+		moved = append(moved, &m)
+		if status != entity.StatusCompleted || t.Status == entity.StatusCompleted {
+			continue
+		}
+		// nextOccurrence moves the rule off a todo with a due date
+		if t.Recurrence != nil && t.DueDate != nil {
+			stopped = append(stopped, t.ID)
+		}
+		next, err := nextOccurrence(ctx, uc.userRepo, t)
+		if err != nil {
+			return err
+		}
+		nexts[t.ID] = next
+	}
+
+	if err := rankAtEnd(ctx, uc.todoRepo, moved...); err != nil {
+		return err
+	}
+	patch := repository.TodoPatch{Status: &status, Ranks: map[string]string{}}
+	for _, m := range moved {
+		patch.Ranks[m.ID] = m.Rank
+	}
+	if status == entity.StatusCompleted {
+		now := time.Now().UTC()
+		patch.CompletedAt = &now
+	}
+	if err := uc.todoRepo.UpdateMany(ctx, changed, patch); err != nil {
+		return err
+	}
+	if err := uc.todoRepo.UpdateMany(ctx, stopped, repository.TodoPatch{ClearRecurrence: true}); err != nil {
+		return err
+	}
+	for _, id := range stopped {
+		next, err := createOccurrence(ctx, uc.todoRepo, nexts[id])
+		if err != nil {
+			return err
+		}
+		results[id] = BulkResult{ID: id, Next: next}
+	}
+	return nil
+}
+
+func dedupe(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
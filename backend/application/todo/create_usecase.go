@@ -7,27 +7,39 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
 )
 
 type CreateUseCase interface {
-	Execute(ctx context.Context, userID, title string, body *string, status entity.Status, dueDate *valueobject.DueDateVO, categoryID *string, tagIDs []string) (*entity.Todo, error)
+	// workspaceID: the workspace to create the todo in, where the caller must
+	// be an editor; nil creates a personal todo
+	Execute(ctx context.Context, userID, title string, body *string, status entity.Status, priority entity.Priority, dueDate *valueobject.DueDateVO, categoryID *string, tagIDs []string, recurrence *entity.Recurrence, autoComplete bool, workspaceID *string) (*entity.Todo, error)
 }
 
 type createUseCase struct {
-	todoRepo     repository.TodoRepository
-	categoryRepo repository.CategoryRepository
-	tagRepo      repository.TagRepository
+	todoRepo      repository.TodoRepository
+	categoryRepo  repository.CategoryRepository
+	tagRepo       repository.TagRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
 }
 
 func NewCreateUseCase(
 	todoRepo repository.TodoRepository,
 	categoryRepo repository.CategoryRepository,
 	tagRepo repository.TagRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
 ) CreateUseCase {
-	return &createUseCase{todoRepo, categoryRepo, tagRepo}
+	return &createUseCase{todoRepo, categoryRepo, tagRepo, workspaceRepo, activityRepo, events}
 }
 
 func (uc *createUseCase) Execute(
@@ -35,9 +47,13 @@ func (uc *createUseCase) Execute(
 	userID, title string,
 	body *string,
 	status entity.Status,
+	priority entity.Priority,
 	dueDate *valueobject.DueDateVO,
 	categoryID *string,
 	tagIDs []string,
+	recurrence *entity.Recurrence,
+	autoComplete bool,
+	workspaceID *string,
 ) (*entity.Todo, error) {
 	fmt.Println("UC START: Execute called")
 
@@ -45,7 +61,7 @@ func (uc *createUseCase) Execute(
 	titleVO, err := valueobject.NewTitleVO(title)
 	if err != nil {
 		fmt.Println("UC ERROR: NewTitleVO failed:", err)
-		return nil, err
+		return nil, apperror.InvalidField("title", err)
 	}
 	fmt.Println("UC OK: TitleVO =", titleVO)
 
@@ -55,13 +71,29 @@ func (uc *createUseCase) Execute(
 		bodyVO, err := valueobject.NewBodyVO(*body)
 		if err != nil {
 			fmt.Println("UC ERROR: NewBodyVO failed:", err)
-			return nil, err
+			return nil, apperror.InvalidField("body", err)
 		}
 		s := bodyVO.String()
 		bodyStr = &s
 		fmt.Println("UC OK: BodyVO =", *bodyStr)
 	}
 
+	// validate status; an omitted status starts the todo in TODO
+	if status == "" {
+		status = entity.StatusTodo
+	}
+	if !isValidStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+
+	// validate priority; an omitted priority is NONE
+	if priority == "" {
+		priority = entity.PriorityNone
+	}
+	if !isValidPriority(priority) {
+		return nil, ErrInvalidPriority
+	}
+
 	// validate DueDateVO if provided
 	var dd *valueobject.DueDateVO
 	if dueDate != nil {
@@ -69,17 +101,14 @@ func (uc *createUseCase) Execute(
 		fmt.Println("UC OK: DueDate =", dd)
 	}
 
-	// check category exists if categoryID != nil
-	if categoryID != nil {
-		fmt.Println("UC CHECK: Looking up category:", *categoryID)
-		cat, err := uc.categoryRepo.FindByID(ctx, *categoryID)
-		if err != nil {
-			fmt.Println("UC ERROR: Category not found:", err)
-			return nil, fmt.Errorf("invalid category ID: %w", err)
-		}
-		fmt.Printf("UC OK: Category exists: %+v\n", cat)
+	if err := workspace.CheckScope(ctx, uc.workspaceRepo, userID, workspaceID, true); err != nil {
+		return nil, err
 	}
 
+	// check the category and tags exist and belong to the caller, or to the workspace
+	if err := newReferenceChecker(uc.categoryRepo, uc.tagRepo, userID, workspaceID).check(ctx, categoryID, tagIDs); err != nil {
+		return nil, err
+	}
 	fmt.Println("UC OK: TagIDs passed =", tagIDs)
 
 	// build domain entity
@@ -103,6 +132,16 @@ func (uc *createUseCase) Execute(
 		fmt.Println("UC ERROR: entity.NewTodo failed:", err)
 		return nil, err
 	}
+	if err := todoEntity.SetRecurrence(recurrence); err != nil {
+		return nil, err
+	}
+	todoEntity.AutoComplete = autoComplete
+	todoEntity.Priority = priority
+	todoEntity.WorkspaceID = workspaceID
+	// new todos go to the bottom of their status column
+	if err := rankAtEnd(ctx, uc.todoRepo, todoEntity); err != nil {
+		return nil, err
+	}
 	fmt.Println("UC OK: Created todoEntity =", todoEntity)
 
 	fmt.Println("UC FINAL: About to call todoRepo.Create with tagIDs =", tagIDs)
@@ -113,6 +152,8 @@ func (uc *createUseCase) Execute(
 		return nil, err
 	}
 	fmt.Println("UC SUCCESS: Todo created =", created)
+	activity.Record(ctx, uc.activityRepo, activity.TodoEntry(userID, entity.ActivityCreated, nil, created))
+	publishTodo(uc.events, event.ActionCreated, created)
 
 	return created, nil
 }
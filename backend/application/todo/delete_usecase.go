@@ -3,21 +3,44 @@ package todo
 import (
 	"context"
 
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
 
 type DeleteUseCase interface {
-	Execute(ctx context.Context, id string) error
+	// Execute moves the todo to the trash. userID is who deletes it: the
+	// owner of a personal todo or an editor of its workspace.
+	Execute(ctx context.Context, userID, id string) error
 }
 
 type deleteUseCase struct {
-	todoRepo repository.TodoRepository
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
 }
 
-func NewDeleteUseCase(todoRepo repository.TodoRepository) DeleteUseCase {
-	return &deleteUseCase{todoRepo}
+func NewDeleteUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) DeleteUseCase {
+	return &deleteUseCase{todoRepo, workspaceRepo, activityRepo, events}
 }
 
-func (uc *deleteUseCase) Execute(ctx context.Context, id string) error {
-	return uc.todoRepo.Delete(ctx, id)
+func (uc *deleteUseCase) Execute(ctx context.Context, userID, id string) error {
+	// the audit entry keeps what was deleted
+	existing, err := FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, userID, id, true)
+	if err != nil {
+		return err
+	}
+	if err := uc.todoRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	activity.Record(ctx, uc.activityRepo, activity.TodoEntry(userID, entity.ActivityDeleted, existing, nil))
+	uc.events.Publish(event.New(existing.UserID, event.ResourceTodo, event.ActionDeleted, id, nil).In(existing.WorkspaceID))
+	return nil
 }
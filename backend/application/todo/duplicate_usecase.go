@@ -6,43 +6,81 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
 
 type DuplicateUseCase interface {
-	Execute(ctx context.Context, id string) (*entity.Todo, error)
+	// Execute copies the todo into the same place: the owner's personal todos
+	// or its workspace, where actorID must be an editor. actorID becomes the
+	// copy's owner and is recorded in the audit log.
+	Execute(ctx context.Context, actorID, id string) (*entity.Todo, error)
 }
 
 type duplicateUseCase struct {
-	todoRepo repository.TodoRepository
+	todoRepo      repository.TodoRepository
+	categoryRepo  repository.CategoryRepository
+	tagRepo       repository.TagRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
 }
 
-func NewDuplicateUseCase(todoRepo repository.TodoRepository) DuplicateUseCase {
-	return &duplicateUseCase{todoRepo}
+func NewDuplicateUseCase(
+	todoRepo repository.TodoRepository,
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) DuplicateUseCase {
+	return &duplicateUseCase{todoRepo, categoryRepo, tagRepo, workspaceRepo, activityRepo, events}
 }
 
-func (uc *duplicateUseCase) Execute(ctx context.Context, id string) (*entity.Todo, error) {
-	original, err := uc.todoRepo.FindByID(ctx, id)
+func (uc *duplicateUseCase) Execute(ctx context.Context, actorID, id string) (*entity.Todo, error) {
+	original, err := FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, actorID, id, true)
 	if err != nil {
 		return nil, err
 	}
-	if original == nil {
-		return nil, ErrTodoNotFound
+	// the copy attaches the original's category and tags anew
+	refs := newReferenceChecker(uc.categoryRepo, uc.tagRepo, original.UserID, original.WorkspaceID)
+	if err := refs.check(ctx, original.CategoryID, original.TagIDs); err != nil {
+		return nil, err
+	}
+	dup := duplicateOf(original, actorID)
+	if err := rankAtEnd(ctx, uc.todoRepo, dup); err != nil {
+		return nil, err
+	}
+	created, err := uc.todoRepo.Create(ctx, dup)
+	if err != nil {
+		return nil, err
 	}
-	// create a new Todo entity that’s a copy (except new ID, CreatedAt, UpdatedAt, CompletedAt=nil)
-	newTodo := &entity.Todo{
-		ID:          uuid.NewString(),
-		Title:       original.Title + " (Copy)",
-		Body:        original.Body,
-		Status:      entity.StatusTodo,
-		DueDate:     original.DueDate,
-		CompletedAt: nil,
-		UserID:      original.UserID,
-		CategoryID:  original.CategoryID,
-		TagIDs:      original.TagIDs,
-		CreatedAt:   time.Now().UTC(),
-		UpdatedAt:   time.Now().UTC(),
+	activity.Record(ctx, uc.activityRepo, activity.TodoEntry(actorID, entity.ActivityCreated, nil, created))
+	publishTodo(uc.events, event.ActionCreated, created)
+	return created, nil
+}
+
+// duplicateOf builds a copy of original owned by ownerID (except new ID, CreatedAt, UpdatedAt,
+// CompletedAt=nil); the caller ranks it
+func duplicateOf(original *entity.Todo, ownerID string) *entity.Todo {
+	return &entity.Todo{
+		ID:           uuid.NewString(),
+		Title:        original.Title + " (Copy)",
+		Body:         original.Body,
+		Status:       entity.StatusTodo,
+		Priority:     original.Priority,
+		DueDate:      original.DueDate,
+		CompletedAt:  nil,
+		UserID:       ownerID,
+		WorkspaceID:  original.WorkspaceID,
+		CategoryID:   original.CategoryID,
+		TagIDs:       original.TagIDs,
+		Assignees:    original.Assignees,
+		Recurrence:   original.Recurrence,
+		AutoComplete: original.AutoComplete,
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
 	}
-	return uc.todoRepo.Create(ctx, newTodo)
 }
@@ -0,0 +1,21 @@
+package todo
+
+import (
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+)
+
+// publishTodo announces a write to t to its owner's other tabs and devices,
+// or to every member of its workspace.
+func publishTodo(events event.Publisher, action event.Action, t *entity.Todo) {
+	events.Publish(event.New(t.UserID, event.ResourceTodo, action, t.ID, t).In(t.WorkspaceID))
+}
+
+// publishSaved announces an updated todo and, when there is one, the next
+// occurrence that completing it created.
+func publishSaved(events event.Publisher, updated, next *entity.Todo) {
+	publishTodo(events, event.ActionUpdated, updated)
+	if next != nil {
+		publishTodo(events, event.ActionCreated, next)
+	}
+}
@@ -9,17 +9,19 @@ import (
 )
 
 type FindByIDUseCase interface {
-	Execute(ctx context.Context, id string) (*entity.Todo, error)
+	// Execute returns a todo userID may read; any other todo is ErrTodoNotFound.
+	Execute(ctx context.Context, userID, id string) (*entity.Todo, error)
 }
 
 type findByIDUseCase struct {
-	todoRepo repository.TodoRepository
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
 }
 
-func NewFindByIDUseCase(todoRepo repository.TodoRepository) FindByIDUseCase {
-	return &findByIDUseCase{todoRepo}
+func NewFindByIDUseCase(todoRepo repository.TodoRepository, workspaceRepo repository.WorkspaceRepository) FindByIDUseCase {
+	return &findByIDUseCase{todoRepo, workspaceRepo}
 }
 
-func (uc *findByIDUseCase) Execute(ctx context.Context, id string) (*entity.Todo, error) {
-	return uc.todoRepo.FindByID(ctx, id)
+func (uc *findByIDUseCase) Execute(ctx context.Context, userID, id string) (*entity.Todo, error) {
+	return FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, userID, id, false)
 }
@@ -0,0 +1,63 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type HistoryUseCase interface {
+	// Execute returns one page of a todo's audit log, newest first. Todos in
+	// the trash keep their history; a todo the caller cannot read is reported
+	// as not found. A workspace todo's history lists every member's changes.
+	Execute(ctx context.Context, userID, id string, limit, offset int) (*repository.ActivityPage, error)
+}
+
+type historyUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+}
+
+func NewHistoryUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+) HistoryUseCase {
+	return &historyUseCase{todoRepo, workspaceRepo, activityRepo}
+}
+
+func (uc *historyUseCase) Execute(ctx context.Context, userID, id string, limit, offset int) (*repository.ActivityPage, error) {
+	t, err := uc.todoRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		if t, err = uc.todoRepo.FindTrashedByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	if t == nil {
+		return nil, ErrTodoNotFound
+	}
+	if err := checkAccess(ctx, uc.workspaceRepo, userID, t, false); err != nil {
+		return nil, err
+	}
+
+	q := repository.ActivityQuery{
+		UserID:     userID,
+		Resource:   entity.ActivityTodo,
+		ResourceID: id,
+		Limit:      limit,
+		Offset:     offset,
+	}
+	if t.WorkspaceID != nil {
+		q.UserID = ""
+	}
+	if err := activity.NormalizePage(&q); err != nil {
+		return nil, err
+	}
+	return uc.activityRepo.FindByQuery(ctx, q)
+}
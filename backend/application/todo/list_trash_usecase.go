@@ -0,0 +1,34 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type ListTrashUseCase interface {
+	// Execute returns the caller's trashed personal todos, or with workspaceID
+	// the workspace's, most recently trashed first.
+	Execute(ctx context.Context, userID string, workspaceID *string) ([]*entity.Todo, error)
+}
+
+type listTrashUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewListTrashUseCase(todoRepo repository.TodoRepository, workspaceRepo repository.WorkspaceRepository) ListTrashUseCase {
+	return &listTrashUseCase{todoRepo, workspaceRepo}
+}
+
+func (uc *listTrashUseCase) Execute(ctx context.Context, userID string, workspaceID *string) ([]*entity.Todo, error) {
+	if workspaceID == nil {
+		return uc.todoRepo.FindTrashByUser(ctx, userID)
+	}
+	if err := workspace.CheckScope(ctx, uc.workspaceRepo, userID, workspaceID, false); err != nil {
+		return nil, err
+	}
+	return uc.todoRepo.FindTrashByWorkspace(ctx, *workspaceID)
+}
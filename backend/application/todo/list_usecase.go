@@ -2,23 +2,104 @@ package todo
 
 import (
 	"context"
+	"errors"
+	"slices"
 
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
 
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+var (
+	ErrInvalidStatus    = apperror.InvalidField("status", errors.New("status must be TODO, IN_PROGRESS or COMPLETED"))
+	ErrInvalidPriority  = apperror.InvalidField("priority", errors.New("priority must be NONE, LOW, MEDIUM, HIGH or URGENT"))
+	ErrInvalidSortField = apperror.InvalidField("sortBy", errors.New("invalid sort field"))
+	ErrInvalidPageSize  = apperror.InvalidField("limit", errors.New("limit must be between 1 and 200"))
+	ErrInvalidDueRange  = apperror.InvalidField("dueDateFrom", errors.New("dueDateFrom must not be after dueDateTo"))
+)
+
 type ListUseCase interface {
-	Execute(ctx context.Context, userID string) ([]*entity.Todo, error)
+	// Execute returns one page of the caller's personal todos, or with
+	// q.WorkspaceID of a workspace they belong to. q.UserID is overwritten with userID.
+	// With q.AssigneeID and no q.WorkspaceID the page spans the personal todos
+	// and those of every workspace the caller belongs to, so one listing shows
+	// everything assigned to someone.
+	Execute(ctx context.Context, userID string, q repository.TodoQuery) (*repository.TodoPage, error)
 }
 
 type listUseCase struct {
-	todoRepo repository.TodoRepository
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewListUseCase(todoRepo repository.TodoRepository, workspaceRepo repository.WorkspaceRepository) ListUseCase {
+	return &listUseCase{todoRepo, workspaceRepo}
+}
+
+func (uc *listUseCase) Execute(ctx context.Context, userID string, q repository.TodoQuery) (*repository.TodoPage, error) {
+	q.UserID = userID
+	if err := workspace.CheckScope(ctx, uc.workspaceRepo, userID, q.WorkspaceID, false); err != nil {
+		return nil, err
+	}
+	q.MemberOf = nil
+	if q.AssigneeID != nil && q.WorkspaceID == nil {
+		memberships, err := uc.workspaceRepo.FindMemberships(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range memberships {
+			q.MemberOf = append(q.MemberOf, m.WorkspaceID)
+		}
+	}
+
+	if q.Status != nil && !isValidStatus(*q.Status) {
+		return nil, ErrInvalidStatus
+	}
+	if q.DueDateFrom != nil && q.DueDateTo != nil && q.DueDateFrom.After(*q.DueDateTo) {
+		return nil, ErrInvalidDueRange
+	}
+
+	switch q.SortBy {
+	case "":
+		q.SortBy = repository.TodoSortCreatedAt
+	case repository.TodoSortCreatedAt,
+		repository.TodoSortUpdatedAt,
+		repository.TodoSortDueDate,
+		repository.TodoSortTitle,
+		repository.TodoSortStatus,
+		repository.TodoSortPriority,
+		repository.TodoSortRank:
+	default:
+		return nil, ErrInvalidSortField
+	}
+
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return nil, ErrInvalidPageSize
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+
+	return uc.todoRepo.FindByQuery(ctx, q)
 }
 
-func NewListUseCase(todoRepo repository.TodoRepository) ListUseCase {
-	return &listUseCase{todoRepo}
+func isValidStatus(s entity.Status) bool {
+	switch s {
+	case entity.StatusTodo, entity.StatusInProgress, entity.StatusCompleted:
+		return true
+	}
+	return false
 }
 
-func (uc *listUseCase) Execute(ctx context.Context, userID string) ([]*entity.Todo, error) {
-	return uc.todoRepo.FindAllByUser(ctx, userID)
+func isValidPriority(p entity.Priority) bool {
+	return slices.Contains(entity.Priorities, p)
 }
@@ -0,0 +1,158 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+var ErrMoveTwoAnchors = apperror.Validation("give either beforeId or afterId, not both")
+
+// MoveRequest places a todo on the board. An empty Status keeps the todo in
+// its column. BeforeID or AfterID names the todo it should sit directly above
+// or below; with neither it goes to the bottom of the column.
+type MoveRequest struct {
+	Status   entity.Status
+	BeforeID *string
+	AfterID  *string
+}
+
+type MoveUseCase interface {
+	// Execute sets the todo's status and rank in one write. Moving it into
+	// COMPLETED behaves like ToggleStatusUseCase, so a recurring todo spawns
+	// its next occurrence, returned as next (nil otherwise).
+	// actorID is who makes the change; see UpdateUseCase.
+	Execute(ctx context.Context, actorID, id string, req MoveRequest) (updated, next *entity.Todo, err error)
+}
+
+type moveUseCase struct {
+	todoRepo      repository.TodoRepository
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
+}
+
+func NewMoveUseCase(
+	todoRepo repository.TodoRepository,
+	userRepo repository.UserRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) MoveUseCase {
+	return &moveUseCase{todoRepo, userRepo, workspaceRepo, activityRepo, events}
+}
+
+func (uc *moveUseCase) Execute(ctx context.Context, actorID, id string, req MoveRequest) (*entity.Todo, *entity.Todo, error) {
+	if req.Status != "" && !isValidStatus(req.Status) {
+		return nil, nil, ErrInvalidStatus
+	}
+	if req.BeforeID != nil && req.AfterID != nil {
+		return nil, nil, ErrMoveTwoAnchors
+	}
+	t, err := FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, actorID, id, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	status := req.Status
+	if status == "" {
+		status = t.Status
+	}
+
+	rank, err := uc.rankFor(ctx, t, status, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	before := *t
+	wasCompleted := t.Status == entity.StatusCompleted
+	t.Rank = rank
+	t.UpdatedAt = time.Now().UTC()
+	var next *entity.Todo
+	if status != t.Status {
+		t.Status = status
+		if status == entity.StatusCompleted {
+			now := time.Now().UTC()
+			t.CompletedAt = &now
+		} else {
+			t.CompletedAt = nil
+		}
+		if status == entity.StatusCompleted && !wasCompleted {
+			if next, err = nextOccurrence(ctx, uc.userRepo, t); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	updated, err := uc.todoRepo.Update(ctx, t)
+	if err != nil {
+		return nil, nil, err
+	}
+	if next, err = createOccurrence(ctx, uc.todoRepo, next); err != nil {
+		return nil, nil, err
+	}
+	recordSaved(ctx, uc.activityRepo, actorID, &before, updated, next)
+	publishSaved(uc.events, updated, next)
+	return updated, next, nil
+}
+
+// rankFor finds the rank that puts t at the requested spot of the status
+// column. Only t's rank changes; its neighbours keep theirs.
+func (uc *moveUseCase) rankFor(ctx context.Context, t *entity.Todo, status entity.Status, req MoveRequest) (string, error) {
+	all, err := boardOf(ctx, uc.todoRepo, t)
+	if err != nil {
+		return "", err
+	}
+	var column []*entity.Todo
+	for _, other := range all {
+		if other.Status == status && other.ID != t.ID {
+			column = append(column, other)
+		}
+	}
+	sortByRank(column)
+
+	// pos is the index in column that t takes
+	pos := len(column)
+	switch {
+	case req.BeforeID != nil:
+		if pos = indexOfTodo(column, *req.BeforeID); pos < 0 {
+			return "", invalidAnchor("beforeId")
+		}
+	case req.AfterID != nil:
+		if pos = indexOfTodo(column, *req.AfterID); pos < 0 {
+			return "", invalidAnchor("afterId")
+		}
+		pos++
+	}
+
+	lo, hi := "", ""
+	if pos > 0 {
+		lo = column[pos-1].Rank
+	}
+	// todos sharing lo's rank are skipped, so t lands below all of them
+	for _, other := range column[pos:] {
+		if other.Rank > lo {
+			hi = other.Rank
+			break
+		}
+	}
+	return entity.RankBetween(lo, hi)
+}
+
+func indexOfTodo(todos []*entity.Todo, id string) int {
+	for i, t := range todos {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func invalidAnchor(field string) error {
+	return apperror.InvalidField(field, errors.New("must be another todo in the target status"))
+}
@@ -0,0 +1,29 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// DefaultTrashRetention is how long a deleted todo stays restorable.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+type PurgeTrashUseCase interface {
+	// Execute permanently deletes every user's todos that have been in the
+	// trash longer than retention, and reports how many there were.
+	Execute(ctx context.Context, retention time.Duration) (int, error)
+}
+
+type purgeTrashUseCase struct {
+	todoRepo repository.TodoRepository
+}
+
+func NewPurgeTrashUseCase(todoRepo repository.TodoRepository) PurgeTrashUseCase {
+	return &purgeTrashUseCase{todoRepo}
+}
+
+func (uc *purgeTrashUseCase) Execute(ctx context.Context, retention time.Duration) (int, error) {
+	return uc.todoRepo.PurgeTrashed(ctx, time.Now().UTC().Add(-retention))
+}
@@ -0,0 +1,59 @@
+package todo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// rankAtEnd gives each new todo a rank below every todo already in its status
+// column, in slice order, so new todos join the bottom of the board. It costs
+// one query per column the todos go into.
+func rankAtEnd(ctx context.Context, repo repository.TodoRepository, todos ...*entity.Todo) error {
+	last := map[string]string{} // board and status -> lowest rank so far
+	for _, t := range todos {
+		board := "user:" + t.UserID
+		if t.WorkspaceID != nil {
+			board = "workspace:" + *t.WorkspaceID
+		}
+		key := board + "\x00" + string(t.Status)
+		lo, ok := last[key]
+		if !ok {
+			status := t.Status
+			page, err := repo.FindByQuery(ctx, repository.TodoQuery{
+				UserID:      t.UserID,
+				WorkspaceID: t.WorkspaceID,
+				Status:      &status,
+				SortBy:      repository.TodoSortRank,
+				SortDesc:    true,
+				Limit:       1,
+			})
+			if err != nil {
+				return err
+			}
+			if len(page.Todos) > 0 {
+				lo = page.Todos[0].Rank
+			}
+		}
+		rank, err := entity.RankBetween(lo, "")
+		if err != nil {
+			return err
+		}
+		t.Rank = rank
+		last[key] = rank
+	}
+	return nil
+}
+
+// sortByRank orders a status column the way a rank listing does: by rank,
+// then by ID for todos that ended up with the same rank.
+func sortByRank(column []*entity.Todo) {
+	sort.Slice(column, func(i, j int) bool {
+		if column[i].Rank != column[j].Rank {
+			return column[i].Rank < column[j].Rank
+		}
+		return column[i].ID < column[j].ID
+	})
+}
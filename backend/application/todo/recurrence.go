@@ -0,0 +1,78 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// nextOccurrence builds the todo that follows t once t has been completed.
+// The rule moves onto the new todo, so t.Recurrence is cleared. Nothing is
+// stored: the caller saves t first and only then creates the new todo with
+// createOccurrence, so a failed save leaves no stray occurrence behind. It
+// returns nil when t does not recur or the rule is exhausted.
+func nextOccurrence(ctx context.Context, userRepo repository.UserRepository, t *entity.Todo) (*entity.Todo, error) {
+	if t.Recurrence == nil || t.DueDate == nil {
+		return nil, nil
+	}
+	rule := t.Recurrence
+	t.Recurrence = nil
+
+	loc, err := userLocation(ctx, userRepo, t.UserID)
+	if err != nil {
+		return nil, err
+	}
+	due, ok := rule.Next(*t.DueDate, loc)
+	if !ok {
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	next := &entity.Todo{
+		ID:           uuid.NewString(),
+		Title:        t.Title,
+		Body:         t.Body,
+		Status:       entity.StatusTodo,
+		Priority:     t.Priority,
+		DueDate:      &due,
+		UserID:       t.UserID,
+		WorkspaceID:  t.WorkspaceID,
+		CategoryID:   t.CategoryID,
+		TagIDs:       t.TagIDs,
+		Assignees:    t.Assignees,
+		Recurrence:   rule.Advanced(),
+		AutoComplete: t.AutoComplete,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	return next, nil
+}
+
+// createOccurrence stores a todo built by nextOccurrence at the end of its
+// list. A nil next is a no-op.
+func createOccurrence(ctx context.Context, todoRepo repository.TodoRepository, next *entity.Todo) (*entity.Todo, error) {
+	if next == nil {
+		return nil, nil
+	}
+	if err := rankAtEnd(ctx, todoRepo, next); err != nil {
+		return nil, err
+	}
+	return todoRepo.Create(ctx, next)
+}
+
+// userLocation resolves the owner's timezone, falling back to UTC when the
+// stored name is unknown to this host.
+func userLocation(ctx context.Context, userRepo repository.UserRepository, userID string) (*time.Location, error) {
+	u, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return time.UTC, nil
+	}
+	return u.Location(), nil
+}
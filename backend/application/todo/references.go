@@ -0,0 +1,89 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// referenceChecker verifies that the categories and tags attached to a todo
+// come from the same place as the todo: its owner's personal ones, or its
+// workspace's. They are loaded on first use, so checking a whole batch costs
+// at most one query per repository.
+type referenceChecker struct {
+	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+	userID       string
+	workspaceID  *string
+
+	categories map[string]struct{}
+	tags       map[string]struct{}
+}
+
+func newReferenceChecker(
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+	userID string,
+	workspaceID *string,
+) *referenceChecker {
+	return &referenceChecker{categoryRepo: categoryRepo, tagRepo: tagRepo, userID: userID, workspaceID: workspaceID}
+}
+
+// check returns an apperror.InvalidIDs error naming every ID the todo cannot
+// use, or nil when all of them are in its scope. A nil categoryID is not checked.
+func (c *referenceChecker) check(ctx context.Context, categoryID *string, tagIDs []string) error {
+	invalid := map[string][]string{}
+
+	if categoryID != nil {
+		if c.categories == nil {
+			var cats []*entity.Category
+			var err error
+			if c.workspaceID != nil {
+				cats, err = c.categoryRepo.FindAllByWorkspace(ctx, *c.workspaceID)
+			} else {
+				cats, err = c.categoryRepo.FindAllByUser(ctx, c.userID)
+			}
+			if err != nil {
+				return err
+			}
+			c.categories = make(map[string]struct{}, len(cats))
+			for _, cat := range cats {
+				c.categories[cat.ID] = struct{}{}
+			}
+		}
+		if _, ok := c.categories[*categoryID]; !ok {
+			invalid["categoryId"] = []string{*categoryID}
+		}
+	}
+
+	if len(tagIDs) > 0 {
+		if c.tags == nil {
+			var tags []*entity.Tag
+			var err error
+			if c.workspaceID != nil {
+				tags, err = c.tagRepo.FindAllByWorkspace(ctx, *c.workspaceID)
+			} else {
+				tags, err = c.tagRepo.FindAllByUser(ctx, c.userID)
+			}
+			if err != nil {
+				return err
+			}
+			c.tags = make(map[string]struct{}, len(tags))
+			for _, tag := range tags {
+				c.tags[tag.ID] = struct{}{}
+			}
+		}
+		for _, id := range dedupe(tagIDs) {
+			if _, ok := c.tags[id]; !ok {
+				invalid["tagIds"] = append(invalid["tagIds"], id)
+			}
+		}
+	}
+
+	if len(invalid) > 0 {
+		return apperror.InvalidIDs(invalid)
+	}
+	return nil
+}
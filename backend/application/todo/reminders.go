@@ -0,0 +1,26 @@
+package todo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// rescheduleReminders moves the offset reminders of todos whose due date was
+// set to due. Like recording activity it is best effort, since the todos are
+// already saved; a failure leaves the reminders at their old times.
+func rescheduleReminders(ctx context.Context, repo repository.ReminderRepository, todoIDs []string, due *time.Time) {
+	if err := repo.RescheduleByTodos(ctx, todoIDs, due, time.Now().UTC()); err != nil {
+		log.Printf("failed to reschedule reminders of %d todos: %v", len(todoIDs), err)
+	}
+}
+
+// sameTime reports whether two optional times are both unset or equal.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,54 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type RestoreUseCase interface {
+	// Execute takes a todo out of the trash: one of the caller's personal todos
+	// or, for an editor, one of a workspace's. A todo that is not in the trash,
+	// or that the caller cannot change, is reported as not found.
+	Execute(ctx context.Context, userID, id string) (*entity.Todo, error)
+}
+
+type restoreUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
+}
+
+func NewRestoreUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) RestoreUseCase {
+	return &restoreUseCase{todoRepo, workspaceRepo, activityRepo, events}
+}
+
+func (uc *restoreUseCase) Execute(ctx context.Context, userID, id string) (*entity.Todo, error) {
+	trashed, err := uc.todoRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if trashed == nil {
+		return nil, ErrTodoNotFound
+	}
+	if err := checkAccess(ctx, uc.workspaceRepo, userID, trashed, true); err != nil {
+		return nil, err
+	}
+	restored, err := uc.todoRepo.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, uc.activityRepo, activity.TodoEntry(userID, entity.ActivityRestored, trashed, restored))
+	// to the other devices it reappears, as if newly created
+	publishTodo(uc.events, event.ActionCreated, restored)
+	return restored, nil
+}
@@ -0,0 +1,56 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type SearchUseCase interface {
+	// Execute runs a full-text search over the todos of q.WorkspaceID, which
+	// the caller must belong to, or else over their personal todos and those
+	// of every workspace they belong to. q.UserID is overwritten with userID.
+	Execute(ctx context.Context, userID string, q repository.TodoSearch) (*repository.TodoSearchPage, error)
+}
+
+type searchUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewSearchUseCase(todoRepo repository.TodoRepository, workspaceRepo repository.WorkspaceRepository) SearchUseCase {
+	return &searchUseCase{todoRepo, workspaceRepo}
+}
+
+func (uc *searchUseCase) Execute(ctx context.Context, userID string, q repository.TodoSearch) (*repository.TodoSearchPage, error) {
+	q.UserID = userID
+	if err := workspace.CheckScope(ctx, uc.workspaceRepo, userID, q.WorkspaceID, false); err != nil {
+		return nil, err
+	}
+	q.MemberOf = nil
+	if q.WorkspaceID == nil {
+		memberships, err := uc.workspaceRepo.FindMemberships(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range memberships {
+			q.MemberOf = append(q.MemberOf, m.WorkspaceID)
+		}
+	}
+
+	if q.Status != nil && !isValidStatus(*q.Status) {
+		return nil, ErrInvalidStatus
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return nil, ErrInvalidPageSize
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+
+	return uc.todoRepo.Search(ctx, q)
+}
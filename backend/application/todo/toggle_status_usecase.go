@@ -5,29 +5,45 @@ import (
 	"time"
 
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
 
 type ToggleStatusUseCase interface {
-	Execute(ctx context.Context, id string, newStatus entity.Status) (*entity.Todo, error)
+	// Execute sets the todo's status. Completing a recurring todo also creates
+	// its next occurrence, returned as next (nil otherwise).
+	// actorID is who makes the change; see UpdateUseCase.
+	Execute(ctx context.Context, actorID, id string, newStatus entity.Status) (updated, next *entity.Todo, err error)
 }
 
 type toggleStatusUseCase struct {
-	todoRepo repository.TodoRepository
+	todoRepo      repository.TodoRepository
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
 }
 
-func NewToggleStatusUseCase(todoRepo repository.TodoRepository) ToggleStatusUseCase {
-	return &toggleStatusUseCase{todoRepo}
+func NewToggleStatusUseCase(
+	todoRepo repository.TodoRepository,
+	userRepo repository.UserRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) ToggleStatusUseCase {
+	return &toggleStatusUseCase{todoRepo, userRepo, workspaceRepo, activityRepo, events}
 }
 
-func (uc *toggleStatusUseCase) Execute(ctx context.Context, id string, newStatus entity.Status) (*entity.Todo, error) {
-	t, err := uc.todoRepo.FindByID(ctx, id)
-	if err != nil {
-		return nil, err
+func (uc *toggleStatusUseCase) Execute(ctx context.Context, actorID, id string, newStatus entity.Status) (*entity.Todo, *entity.Todo, error) {
+	if !isValidStatus(newStatus) {
+		return nil, nil, ErrInvalidStatus
 	}
-	if t == nil {
-		return nil, ErrTodoNotFound
+	t, err := FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, actorID, id, true)
+	if err != nil {
+		return nil, nil, err
 	}
+	before := *t
+	wasCompleted := t.Status == entity.StatusCompleted
 	t.Status = newStatus
 	t.UpdatedAt = time.Now().UTC()
 	if newStatus == entity.StatusCompleted {
@@ -36,5 +52,21 @@ func (uc *toggleStatusUseCase) Execute(ctx context.Context, id string, newStatus
 	} else {
 		t.CompletedAt = nil
 	}
-	return uc.todoRepo.Update(ctx, t)
+
+	var next *entity.Todo
+	if newStatus == entity.StatusCompleted && !wasCompleted {
+		if next, err = nextOccurrence(ctx, uc.userRepo, t); err != nil {
+			return nil, nil, err
+		}
+	}
+	updated, err := uc.todoRepo.Update(ctx, t)
+	if err != nil {
+		return nil, nil, err
+	}
+	if next, err = createOccurrence(ctx, uc.todoRepo, next); err != nil {
+		return nil, nil, err
+	}
+	recordSaved(ctx, uc.activityRepo, actorID, &before, updated, next)
+	publishSaved(uc.events, updated, next)
+	return updated, next, nil
 }
@@ -0,0 +1,47 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type UnassignUseCase interface {
+	// Execute takes the user off the todo and returns it; a user who is not
+	// assigned is ErrAssigneeNotOnTodo.
+	// actorID is who makes the change; see UpdateUseCase.
+	Execute(ctx context.Context, actorID, id, userID string) (*entity.Todo, error)
+}
+
+type unassignUseCase struct {
+	todoRepo      repository.TodoRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	events        event.Publisher
+}
+
+func NewUnassignUseCase(
+	todoRepo repository.TodoRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) UnassignUseCase {
+	return &unassignUseCase{todoRepo, workspaceRepo, activityRepo, events}
+}
+
+func (uc *unassignUseCase) Execute(ctx context.Context, actorID, id, userID string) (*entity.Todo, error) {
+	t, err := FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, actorID, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if !t.IsAssigned(userID) {
+		return nil, ErrAssigneeNotOnTodo
+	}
+
+	if err := uc.todoRepo.RemoveAssignee(ctx, t.ID, userID); err != nil {
+		return nil, err
+	}
+	return saveAssignees(ctx, uc.todoRepo, uc.activityRepo, uc.events, actorID, t)
+}
@@ -2,35 +2,100 @@ package todo
 
 import (
 	"context"
-	"errors"
+	"slices"
 	"time"
 
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
 
-var ErrTodoNotFound = errors.New("todo not found")
+var ErrTodoNotFound = repository.ErrTodoNotFound
 
 type UpdateUseCase interface {
-	Execute(ctx context.Context, t *entity.Todo) (*entity.Todo, error)
+	// Execute saves t. As with ToggleStatusUseCase, completing a recurring todo
+	// creates its next occurrence, returned as next (nil otherwise).
+	// actorID is who makes the change: the owner of a personal todo or an
+	// editor of its workspace. It is also recorded in the audit log.
+	Execute(ctx context.Context, actorID string, t *entity.Todo) (updated, next *entity.Todo, err error)
 }
 
 type updateUseCase struct {
-	todoRepo repository.TodoRepository
+	todoRepo      repository.TodoRepository
+	categoryRepo  repository.CategoryRepository
+	tagRepo       repository.TagRepository
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	activityRepo  repository.ActivityRepository
+	reminderRepo  repository.ReminderRepository
+	events        event.Publisher
 }
 
-func NewUpdateUseCase(todoRepo repository.TodoRepository) UpdateUseCase {
-	return &updateUseCase{todoRepo}
+func NewUpdateUseCase(
+	todoRepo repository.TodoRepository,
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+	userRepo repository.UserRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	activityRepo repository.ActivityRepository,
+	reminderRepo repository.ReminderRepository,
+	events event.Publisher,
+) UpdateUseCase {
+	return &updateUseCase{todoRepo, categoryRepo, tagRepo, userRepo, workspaceRepo, activityRepo, reminderRepo, events}
 }
 
-func (uc *updateUseCase) Execute(ctx context.Context, t *entity.Todo) (*entity.Todo, error) {
-	existing, err := uc.todoRepo.FindByID(ctx, t.ID)
+func (uc *updateUseCase) Execute(ctx context.Context, actorID string, t *entity.Todo) (*entity.Todo, *entity.Todo, error) {
+	if !isValidStatus(t.Status) {
+		return nil, nil, ErrInvalidStatus
+	}
+	if !isValidPriority(t.Priority) {
+		return nil, nil, ErrInvalidPriority
+	}
+	existing, err := FindAccessible(ctx, uc.todoRepo, uc.workspaceRepo, actorID, t.ID, true)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	if existing == nil {
-		return nil, ErrTodoNotFound
+	if err := t.SetRecurrence(t.Recurrence); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.checkNewReferences(ctx, existing, t); err != nil {
+		return nil, nil, err
 	}
 	t.UpdatedAt = time.Now().UTC()
-	return uc.todoRepo.Update(ctx, t)
+
+	var next *entity.Todo
+	if t.Status == entity.StatusCompleted && existing.Status != entity.StatusCompleted {
+		if next, err = nextOccurrence(ctx, uc.userRepo, t); err != nil {
+			return nil, nil, err
+		}
+	}
+	updated, err := uc.todoRepo.Update(ctx, t)
+	if err != nil {
+		return nil, nil, err
+	}
+	if next, err = createOccurrence(ctx, uc.todoRepo, next); err != nil {
+		return nil, nil, err
+	}
+	if !sameTime(existing.DueDate, updated.DueDate) {
+		rescheduleReminders(ctx, uc.reminderRepo, []string{updated.ID}, updated.DueDate)
+	}
+	recordSaved(ctx, uc.activityRepo, actorID, existing, updated, next)
+	publishSaved(uc.events, updated, next)
+	return updated, next, nil
+}
+
+// checkNewReferences verifies the category and tags t attaches that existing
+// did not have; references already on the todo are left as they are.
+func (uc *updateUseCase) checkNewReferences(ctx context.Context, existing, t *entity.Todo) error {
+	var categoryID *string
+	if t.CategoryID != nil && (existing.CategoryID == nil || *existing.CategoryID != *t.CategoryID) {
+		categoryID = t.CategoryID
+	}
+	var added []string
+	for _, id := range t.TagIDs {
+		if !slices.Contains(existing.TagIDs, id) {
+			added = append(added, id)
+		}
+	}
+	return newReferenceChecker(uc.categoryRepo, uc.tagRepo, existing.UserID, existing.WorkspaceID).check(ctx, categoryID, added)
 }
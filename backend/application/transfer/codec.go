@@ -0,0 +1,63 @@
+package transfer
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// Encode writes doc to w in format f, one todo at a time.
+func Encode(w io.Writer, f Format, doc *Document) error {
+	switch f {
+	case FormatJSON:
+		return encodeJSON(w, doc)
+	case FormatCSV:
+		return encodeCSV(w, doc)
+	case FormatTodoTxt:
+		return encodeTodoTxt(w, doc)
+	}
+	return ErrInvalidFormat
+}
+
+// decode reads a file in format f. Times without an offset are read in loc.
+func decode(r io.Reader, f Format, loc *time.Location) (*Document, error) {
+	switch f {
+	case FormatJSON:
+		return decodeJSON(r)
+	case FormatCSV:
+		return decodeCSV(r, loc)
+	case FormatTodoTxt:
+		return decodeTodoTxt(r, loc)
+	}
+	return nil, ErrInvalidFormat
+}
+
+var errInvalidTime = errors.New(`times must be RFC 3339, "2006-01-02T15:04" or "2006-01-02"`)
+
+// parseTime reads an RFC 3339 time, or a wall-clock time or date in loc, as
+// spreadsheets tend to drop the offset. "" is no time.
+func parseTime(s string, loc *time.Location) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, errInvalidTime
+}
+
+// formatTime writes t as RFC 3339 in UTC; nil is "".
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,195 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// csvColumns is the header row of an export. Import matches columns by
+// name, so they may come in any order and only "title" is required.
+var csvColumns = []string{"title", "body", "status", "dueDate", "completedAt", "createdAt", "category", "tags", "recurrence", "priority"}
+
+// csvTagSeparator joins a todo's tag names within the tags column. A name
+// that contains it is quoted the way CSV quotes a field.
+const csvTagSeparator = ';'
+
+// csvFormulaPrefixes are the leading characters that make a spreadsheet
+// treat a cell as a formula.
+const csvFormulaPrefixes = "=+-@\t\r"
+
+func encodeCSV(w io.Writer, doc *Document) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, r := range doc.Todos {
+		body := ""
+		if r.Body != nil {
+			body = *r.Body
+		}
+		tags, err := joinTags(r.Tags)
+		if err != nil {
+			return err
+		}
+		row := []string{
+			r.Title,
+			body,
+			string(r.Status),
+			formatTime(r.DueDate),
+			formatTime(r.CompletedAt),
+			formatTime(r.CreatedAt),
+			r.Category,
+			tags,
+			r.Recurrence,
+			string(r.Priority),
+		}
+		for i := range row {
+			row[i] = escapeCell(row[i])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func decodeCSV(r io.Reader, loc *time.Location) (*Document, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // short rows leave the remaining columns empty
+	header, err := cr.Read()
+	if err == io.EOF {
+		return &Document{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "\ufeff") // spreadsheet BOM
+		for _, col := range csvColumns {
+			if strings.EqualFold(name, col) {
+				index[col] = i
+			}
+		}
+	}
+	if _, ok := index["title"]; !ok {
+		return nil, errors.New(`the CSV header has no "title" column`)
+	}
+
+	doc := &Document{}
+	for n := 1; ; n++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return doc, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		field := func(col string) string {
+			if i, ok := index[col]; ok && i < len(row) {
+				return strings.TrimSpace(unescapeCell(row[i]))
+			}
+			return ""
+		}
+
+		rec := Record{
+			Title:      field("title"),
+			Status:     entity.Status(field("status")),
+			Priority:   entity.Priority(field("priority")),
+			Category:   field("category"),
+			Recurrence: field("recurrence"),
+		}
+		if body := field("body"); body != "" {
+			rec.Body = &body
+		}
+		tags, err := splitTags(field("tags"))
+		if err != nil {
+			return nil, recordError(n, fmt.Errorf("tags: %w", err))
+		}
+		rec.Tags = tags
+		for _, col := range []struct {
+			name string
+			dst  **time.Time
+		}{
+			{"dueDate", &rec.DueDate},
+			{"completedAt", &rec.CompletedAt},
+			{"createdAt", &rec.CreatedAt},
+		} {
+			t, err := parseTime(field(col.name), loc)
+			if err != nil {
+				return nil, recordError(n, fmt.Errorf("%s: %w", col.name, err))
+			}
+			*col.dst = t
+		}
+		doc.Todos = append(doc.Todos, rec)
+	}
+}
+
+// escapeCell prefixes a cell that a spreadsheet would run as a formula with
+// a quote, which makes it plain text. A cell that merely looks escaped gets
+// one more quote, so unescapeCell always restores the original.
+func escapeCell(v string) string {
+	if isFormulaLike(v) {
+		return "'" + v
+	}
+	return v
+}
+
+func unescapeCell(v string) string {
+	if rest, ok := strings.CutPrefix(v, "'"); ok && isFormulaLike(rest) {
+		return rest
+	}
+	return v
+}
+
+func isFormulaLike(v string) bool {
+	for strings.HasPrefix(v, "'") {
+		v = v[1:]
+	}
+	return v != "" && strings.ContainsRune(csvFormulaPrefixes, rune(v[0]))
+}
+
+func joinTags(tags []string) (string, error) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = csvTagSeparator
+	if err := w.Write(tags); err != nil {
+		return "", err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+func splitTags(v string) ([]string, error) {
+	if v == "" {
+		return nil, nil
+	}
+	r := csv.NewReader(strings.NewReader(v))
+	r.Comma = csvTagSeparator
+	r.LazyQuotes = true
+	names, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			tags = append(tags, name)
+		}
+	}
+	return tags, nil
+}
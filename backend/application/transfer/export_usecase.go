@@ -0,0 +1,108 @@
+package transfer
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type ExportUseCase interface {
+	// Execute collects the user's categories, tags and todos (oldest first)
+	// for Encode. Trashed todos are left out.
+	Execute(ctx context.Context, userID string) (*Document, error)
+}
+
+type exportUseCase struct {
+	todoRepo     repository.TodoRepository
+	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+	userRepo     repository.UserRepository
+}
+
+func NewExportUseCase(
+	todoRepo repository.TodoRepository,
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+	userRepo repository.UserRepository,
+) ExportUseCase {
+	return &exportUseCase{todoRepo, categoryRepo, tagRepo, userRepo}
+}
+
+func (uc *exportUseCase) Execute(ctx context.Context, userID string) (*Document, error) {
+	u, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{Location: time.UTC}
+	if u != nil {
+		doc.Location = u.Location()
+	}
+
+	categories, err := uc.categoryRepo.FindAllByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	categoryNames := make(map[string]string, len(categories))
+	for _, c := range categories {
+		categoryNames[c.ID] = c.Name
+		doc.Categories = append(doc.Categories, CategoryRecord{Name: c.Name, Color: c.Color, Description: c.Description})
+	}
+
+	tags, err := uc.tagRepo.FindAllByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	tagNames := make(map[string]string, len(tags))
+	for _, t := range tags {
+		tagNames[t.ID] = t.Name
+		doc.Tags = append(doc.Tags, t.Name)
+	}
+
+	todos, err := uc.todoRepo.FindAllByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(todos, func(i, j int) bool {
+		if !todos[i].CreatedAt.Equal(todos[j].CreatedAt) {
+			return todos[i].CreatedAt.Before(todos[j].CreatedAt)
+		}
+		return todos[i].ID < todos[j].ID
+	})
+	for _, t := range todos {
+		doc.Todos = append(doc.Todos, toRecord(t, categoryNames, tagNames))
+	}
+	return doc, nil
+}
+
+func toRecord(t *entity.Todo, categoryNames, tagNames map[string]string) Record {
+	createdAt := t.CreatedAt
+	r := Record{
+		Title:       t.Title,
+		Body:        t.Body,
+		Status:      t.Status,
+		DueDate:     t.DueDate,
+		CompletedAt: t.CompletedAt,
+		CreatedAt:   &createdAt,
+	}
+	if t.Priority != entity.PriorityNone {
+		r.Priority = t.Priority
+	}
+	if t.CategoryID != nil {
+		r.Category = categoryNames[*t.CategoryID]
+	}
+	for _, id := range t.TagIDs {
+		if name, ok := tagNames[id]; ok {
+			r.Tags = append(r.Tags, name)
+		}
+	}
+	sort.Strings(r.Tags)
+	if t.Recurrence != nil {
+		r.Recurrence = t.Recurrence.String()
+	}
+	return r
+}
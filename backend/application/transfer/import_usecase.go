@@ -0,0 +1,377 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
+)
+
+// defaultCategoryColor is given to categories an import creates without a
+// color of their own; it is the category panel's default.
+const defaultCategoryColor = "#D8D8F6"
+
+type ImportRequest struct {
+	Format Format
+	// DryRun validates the file and reports what would be created, without writing.
+	DryRun bool
+}
+
+// Duplicate is a todo in the file that was skipped because the user already
+// has a todo with the same title and due date, or the file lists it twice.
+type Duplicate struct {
+	Index int // 1-based position of the todo in the file
+	Title string
+}
+
+// ImportResult lists what an import created or, on a dry run, would create.
+// Categories and Tags are the names that did not exist yet.
+type ImportResult struct {
+	DryRun     bool
+	Todos      []*entity.Todo
+	Categories []string
+	Tags       []string
+	Duplicates []Duplicate
+}
+
+type ImportUseCase interface {
+	// Execute reads a file in req.Format and creates its todos for userID,
+	// together with any category or tag they name that the user lacks. The
+	// file is checked in full before anything is written, so an invalid todo
+	// fails the whole import.
+	Execute(ctx context.Context, userID string, r io.Reader, req ImportRequest) (*ImportResult, error)
+}
+
+type importUseCase struct {
+	todoRepo     repository.TodoRepository
+	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+	userRepo     repository.UserRepository
+	activityRepo repository.ActivityRepository
+	events       event.Publisher
+}
+
+func NewImportUseCase(
+	todoRepo repository.TodoRepository,
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+	userRepo repository.UserRepository,
+	activityRepo repository.ActivityRepository,
+	events event.Publisher,
+) ImportUseCase {
+	return &importUseCase{todoRepo, categoryRepo, tagRepo, userRepo, activityRepo, events}
+}
+
+func (uc *importUseCase) Execute(ctx context.Context, userID string, r io.Reader, req ImportRequest) (*ImportResult, error) {
+	if _, err := ParseFormat(string(req.Format)); err != nil {
+		return nil, err
+	}
+	u, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if u != nil {
+		loc = u.Location()
+	}
+
+	doc, err := decode(r, req.Format, loc)
+	if err != nil {
+		var appErr *apperror.Error
+		if !errors.As(err, &appErr) {
+			appErr = apperror.Validation(err.Error())
+			appErr.Err = err
+		}
+		return nil, appErr
+	}
+	if len(doc.Todos) == 0 && len(doc.Categories) == 0 && len(doc.Tags) == 0 {
+		return nil, ErrImportEmpty
+	}
+	if len(doc.Todos) > MaxImportTodos {
+		return nil, ErrImportTooBig
+	}
+
+	p := &importPlan{userID: userID, result: &ImportResult{DryRun: req.DryRun}}
+	if err := p.loadCategories(ctx, uc.categoryRepo, doc.Categories); err != nil {
+		return nil, err
+	}
+	for _, name := range doc.Tags {
+		if _, err := p.tagID(ctx, uc.tagRepo, name); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.loadExisting(ctx, uc.todoRepo); err != nil {
+		return nil, err
+	}
+	for i, rec := range doc.Todos {
+		if err := p.addTodo(ctx, uc.tagRepo, i+1, rec); err != nil {
+			return nil, err
+		}
+	}
+	if req.DryRun {
+		return p.result, nil
+	}
+	return p.result, uc.apply(ctx, p)
+}
+
+// apply writes the plan: new categories and tags one by one, then every todo
+// in a single CreateMany.
+func (uc *importUseCase) apply(ctx context.Context, p *importPlan) error {
+	var entries []*entity.Activity
+	// repositories assign their own IDs, so planned IDs are swapped for them
+	ids := map[string]string{}
+	for _, c := range p.newCategories {
+		planned := c.ID
+		created, err := uc.categoryRepo.Create(ctx, c)
+		if err != nil {
+			return err
+		}
+		ids[planned] = created.ID
+		entries = append(entries, activity.CategoryEntry(p.userID, entity.ActivityCreated, nil, created))
+		uc.events.Publish(event.New(created.UserID, event.ResourceCategory, event.ActionCreated, created.ID, created))
+	}
+	for _, t := range p.newTags {
+		planned := t.ID
+		created, err := uc.tagRepo.Create(ctx, t)
+		if err != nil {
+			return err
+		}
+		ids[planned] = created.ID
+		entries = append(entries, activity.TagEntry(p.userID, entity.ActivityCreated, nil, created))
+		uc.events.Publish(event.New(created.UserID, event.ResourceTag, event.ActionCreated, created.ID, created))
+	}
+	for _, t := range p.result.Todos {
+		if t.CategoryID != nil {
+			if id, ok := ids[*t.CategoryID]; ok {
+				t.CategoryID = &id
+			}
+		}
+		for i, tagID := range t.TagIDs {
+			if id, ok := ids[tagID]; ok {
+				t.TagIDs[i] = id
+			}
+		}
+	}
+	if len(p.result.Todos) > 0 {
+		created, err := uc.todoRepo.CreateMany(ctx, p.result.Todos)
+		if err != nil {
+			return err
+		}
+		p.result.Todos = created
+		for _, t := range created {
+			entries = append(entries, activity.TodoEntry(p.userID, entity.ActivityCreated, nil, t))
+			uc.events.Publish(event.New(t.UserID, event.ResourceTodo, event.ActionCreated, t.ID, t))
+		}
+	}
+	activity.Record(ctx, uc.activityRepo, entries...)
+	return nil
+}
+
+// importPlan resolves a document against the user's data. Categories and tags
+// it has to create get their IDs up front so todos can refer to them.
+type importPlan struct {
+	userID        string
+	result        *ImportResult
+	categories    map[string]string // name -> ID, existing and planned
+	tags          map[string]string
+	seen          map[string]bool // duplicate keys of existing and planned todos
+	lastRanks     map[entity.Status]string
+	newCategories []*entity.Category
+	newTags       []*entity.Tag
+}
+
+func (p *importPlan) loadCategories(ctx context.Context, repo repository.CategoryRepository, listed []CategoryRecord) error {
+	existing, err := repo.FindAllByUser(ctx, p.userID)
+	if err != nil {
+		return err
+	}
+	p.categories = make(map[string]string, len(existing))
+	for _, c := range existing {
+		p.categories[c.Name] = c.ID
+	}
+	for _, c := range listed {
+		if _, err := p.categoryID(c.Name, c.Color, c.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// categoryID finds the category called name, planning it if it is new.
+func (p *importPlan) categoryID(name, color string, description *string) (string, error) {
+	nameVO, err := valueobject.NewTitleVO(strings.TrimSpace(name))
+	if err != nil {
+		return "", apperror.InvalidField("category", err)
+	}
+	name = nameVO.String()
+	if id, ok := p.categories[name]; ok {
+		return id, nil
+	}
+	if color == "" {
+		color = defaultCategoryColor
+	}
+	c, err := entity.NewCategory(uuid.NewString(), name, color, p.userID, description)
+	if err != nil {
+		return "", err
+	}
+	p.categories[name] = c.ID
+	p.newCategories = append(p.newCategories, c)
+	p.result.Categories = append(p.result.Categories, name)
+	return c.ID, nil
+}
+
+// tagID finds the tag called name, planning it if it is new.
+func (p *importPlan) tagID(ctx context.Context, repo repository.TagRepository, name string) (string, error) {
+	nameVO, err := valueobject.NewTitleVO(strings.TrimSpace(name))
+	if err != nil {
+		return "", apperror.InvalidField("tags", err)
+	}
+	name = nameVO.String()
+	if p.tags == nil {
+		p.tags = map[string]string{}
+	}
+	if id, ok := p.tags[name]; ok {
+		return id, nil
+	}
+	existing, err := repo.FindByName(ctx, p.userID, name)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		p.tags[name] = existing.ID
+		return existing.ID, nil
+	}
+	t, err := entity.NewTag(uuid.NewString(), name, p.userID)
+	if err != nil {
+		return "", err
+	}
+	p.tags[name] = t.ID
+	p.newTags = append(p.newTags, t)
+	p.result.Tags = append(p.result.Tags, name)
+	return t.ID, nil
+}
+
+func (p *importPlan) loadExisting(ctx context.Context, repo repository.TodoRepository) error {
+	existing, err := repo.FindAllByUser(ctx, p.userID)
+	if err != nil {
+		return err
+	}
+	p.seen = make(map[string]bool, len(existing))
+	p.lastRanks = map[entity.Status]string{}
+	for _, t := range existing {
+		p.seen[duplicateKey(t.Title, t.DueDate)] = true
+		if t.Rank > p.lastRanks[t.Status] {
+			p.lastRanks[t.Status] = t.Rank
+		}
+	}
+	return nil
+}
+
+// addTodo validates the n-th record and plans its todo, unless it duplicates one.
+func (p *importPlan) addTodo(ctx context.Context, tagRepo repository.TagRepository, n int, rec Record) error {
+	titleVO, err := valueobject.NewTitleVO(strings.TrimSpace(rec.Title))
+	if err != nil {
+		return recordError(n, apperror.InvalidField("title", err))
+	}
+	key := duplicateKey(titleVO.String(), rec.DueDate)
+	if p.seen[key] {
+		p.result.Duplicates = append(p.result.Duplicates, Duplicate{Index: n, Title: titleVO.String()})
+		return nil
+	}
+	p.seen[key] = true
+
+	var body *string
+	if rec.Body != nil {
+		bodyVO, err := valueobject.NewBodyVO(*rec.Body)
+		if err != nil {
+			return recordError(n, apperror.InvalidField("body", err))
+		}
+		s := bodyVO.String()
+		body = &s
+	}
+
+	status := rec.Status
+	switch status {
+	case "":
+		status = entity.StatusTodo
+	case entity.StatusTodo, entity.StatusInProgress, entity.StatusCompleted:
+	default:
+		return recordError(n, errors.New("status must be TODO, IN_PROGRESS or COMPLETED"))
+	}
+	priority := rec.Priority
+	if priority == "" {
+		priority = entity.PriorityNone
+	}
+	if !slices.Contains(entity.Priorities, priority) {
+		return recordError(n, errors.New("priority must be NONE, LOW, MEDIUM, HIGH or URGENT"))
+	}
+
+	var categoryID *string
+	if rec.Category != "" {
+		id, err := p.categoryID(rec.Category, "", nil)
+		if err != nil {
+			return recordError(n, err)
+		}
+		categoryID = &id
+	}
+	var tagIDs []string
+	for _, name := range rec.Tags {
+		id, err := p.tagID(ctx, tagRepo, name)
+		if err != nil {
+			return recordError(n, err)
+		}
+		if !slices.Contains(tagIDs, id) {
+			tagIDs = append(tagIDs, id)
+		}
+	}
+
+	t, err := entity.NewTodo(uuid.NewString(), titleVO.String(), body, status, rec.DueDate, p.userID, categoryID, tagIDs)
+	if err != nil {
+		return recordError(n, err)
+	}
+	t.Priority = priority
+	// imported todos join the bottom of their status column, in file order
+	if t.Rank, err = entity.RankBetween(p.lastRanks[status], ""); err != nil {
+		return err
+	}
+	p.lastRanks[status] = t.Rank
+	if status == entity.StatusCompleted {
+		completedAt := time.Now().UTC()
+		if rec.CompletedAt != nil {
+			completedAt = rec.CompletedAt.UTC()
+		}
+		t.CompletedAt = &completedAt
+	}
+	if rec.Recurrence != "" {
+		rule, err := entity.ParseRecurrence(rec.Recurrence)
+		if err != nil {
+			return recordError(n, err)
+		}
+		if err := t.SetRecurrence(rule); err != nil {
+			return recordError(n, err)
+		}
+	}
+	p.result.Todos = append(p.result.Todos, t)
+	return nil
+}
+
+// duplicateKey identifies a todo for duplicate detection: its title, ignoring
+// case and surrounding space, and its due date.
+func duplicateKey(title string, due *time.Time) string {
+	key := strings.ToLower(strings.TrimSpace(title)) + "\x00"
+	if due != nil {
+		key += due.UTC().Format(time.RFC3339)
+	}
+	return key
+}
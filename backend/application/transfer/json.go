@@ -0,0 +1,133 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// jsonVersion is written to every JSON export and is the newest one import reads.
+const jsonVersion = 1
+
+type jsonHeader struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exportedAt"`
+	Categories []jsonCategory `json:"categories"`
+	Tags       []jsonTag      `json:"tags"`
+}
+
+type jsonDocument struct {
+	jsonHeader
+	Todos []jsonTodo `json:"todos"`
+}
+
+type jsonCategory struct {
+	Name        string  `json:"name"`
+	Color       string  `json:"color,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type jsonTag struct {
+	Name string `json:"name"`
+}
+
+type jsonTodo struct {
+	Title       string     `json:"title"`
+	Body        *string    `json:"body,omitempty"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority,omitempty"`
+	DueDate     *time.Time `json:"dueDate,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	Category    string     `json:"category,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Recurrence  string     `json:"recurrence,omitempty"` // RRULE
+}
+
+// encodeJSON writes the categories and tags up front, then streams the todos
+// into the "todos" array.
+func encodeJSON(w io.Writer, doc *Document) error {
+	header := jsonHeader{
+		Version:    jsonVersion,
+		ExportedAt: time.Now().UTC(),
+		Categories: []jsonCategory{},
+		Tags:       []jsonTag{},
+	}
+	for _, c := range doc.Categories {
+		header.Categories = append(header.Categories, jsonCategory{Name: c.Name, Color: c.Color, Description: c.Description})
+	}
+	for _, name := range doc.Tags {
+		header.Tags = append(header.Tags, jsonTag{Name: name})
+	}
+	head, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	// reopen the header object to append the todos array
+	head = bytes.TrimSuffix(head, []byte("}"))
+	if _, err := fmt.Fprintf(w, "%s,\"todos\":[", head); err != nil {
+		return err
+	}
+	for i, r := range doc.Todos {
+		item, err := json.Marshal(jsonTodo{
+			Title:       r.Title,
+			Body:        r.Body,
+			Status:      string(r.Status),
+			Priority:    string(r.Priority),
+			DueDate:     r.DueDate,
+			CompletedAt: r.CompletedAt,
+			CreatedAt:   r.CreatedAt,
+			Category:    r.Category,
+			Tags:        r.Tags,
+			Recurrence:  r.Recurrence,
+		})
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			item = append([]byte{','}, item...)
+		}
+		if _, err := w.Write(item); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "]}\n")
+	return err
+}
+
+func decodeJSON(r io.Reader) (*Document, error) {
+	var in jsonDocument
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if in.Version > jsonVersion {
+		return nil, fmt.Errorf("unsupported export version %d", in.Version)
+	}
+
+	doc := &Document{}
+	for _, c := range in.Categories {
+		doc.Categories = append(doc.Categories, CategoryRecord{Name: c.Name, Color: c.Color, Description: c.Description})
+	}
+	for _, t := range in.Tags {
+		doc.Tags = append(doc.Tags, t.Name)
+	}
+	for _, t := range in.Todos {
+		doc.Todos = append(doc.Todos, Record{
+			Title:       t.Title,
+			Body:        t.Body,
+			Status:      entity.Status(t.Status),
+			Priority:    entity.Priority(t.Priority),
+			DueDate:     t.DueDate,
+			CompletedAt: t.CompletedAt,
+			CreatedAt:   t.CreatedAt,
+			Category:    t.Category,
+			Tags:        t.Tags,
+			Recurrence:  t.Recurrence,
+		})
+	}
+	return doc, nil
+}
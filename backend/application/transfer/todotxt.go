@@ -0,0 +1,138 @@
+package transfer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// In todo.txt a todo is one line: "x" and the completion date when done, the
+// creation date, the text, then +project and @context tags and key:value
+// pairs. Categories are written as the +project, tags as @contexts and the due
+// date as due:YYYY-MM-DD in the owner's timezone. Names cannot hold spaces
+// there, so spaces are written as underscores and read back as spaces.
+// Open todos carry their priority as (A) for URGENT down to (D) for LOW; any
+// later letter reads as LOW. Bodies, due times and recurrence have no place in
+// the format and are dropped.
+
+const todoTxtDate = "2006-01-02"
+
+var todoTxtPriority = regexp.MustCompile(`^\([A-Z]\)$`)
+
+// todoTxtPriorities maps priorities to their letters, highest first.
+var todoTxtPriorities = []entity.Priority{entity.PriorityUrgent, entity.PriorityHigh, entity.PriorityMedium, entity.PriorityLow}
+
+func encodeTodoTxt(w io.Writer, doc *Document) error {
+	loc := doc.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	bw := bufio.NewWriter(w)
+	for _, r := range doc.Todos {
+		var parts []string
+		if r.Status == entity.StatusCompleted {
+			parts = append(parts, "x")
+			if r.CompletedAt != nil {
+				parts = append(parts, r.CompletedAt.In(loc).Format(todoTxtDate))
+			}
+		} else if i := slices.Index(todoTxtPriorities, r.Priority); i >= 0 {
+			parts = append(parts, fmt.Sprintf("(%c)", 'A'+i))
+		}
+		if r.CreatedAt != nil {
+			parts = append(parts, r.CreatedAt.In(loc).Format(todoTxtDate))
+		}
+		parts = append(parts, strings.Join(strings.Fields(r.Title), " "))
+		if r.Category != "" {
+			parts = append(parts, "+"+todoTxtName(r.Category))
+		}
+		for _, tag := range r.Tags {
+			parts = append(parts, "@"+todoTxtName(tag))
+		}
+		if r.DueDate != nil {
+			parts = append(parts, "due:"+r.DueDate.In(loc).Format(todoTxtDate))
+		}
+		if _, err := fmt.Fprintln(bw, strings.Join(parts, " ")); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func todoTxtName(name string) string {
+	return strings.Join(strings.Fields(name), "_")
+}
+
+func decodeTodoTxt(r io.Reader, loc *time.Location) (*Document, error) {
+	doc := &Document{}
+	scanner := bufio.NewScanner(r)
+	for n := 0; scanner.Scan(); {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		n++
+		rec := Record{Status: entity.StatusTodo}
+
+		if fields[0] == "x" {
+			rec.Status = entity.StatusCompleted
+			fields = fields[1:]
+			if t, ok := todoTxtDateIn(fields, loc); ok {
+				rec.CompletedAt = &t
+				fields = fields[1:]
+			}
+		} else if len(fields) > 0 && todoTxtPriority.MatchString(fields[0]) {
+			rec.Priority = entity.PriorityLow
+			if i := int(fields[0][1] - 'A'); i < len(todoTxtPriorities) {
+				rec.Priority = todoTxtPriorities[i]
+			}
+			fields = fields[1:]
+		}
+		if t, ok := todoTxtDateIn(fields, loc); ok {
+			rec.CreatedAt = &t
+			fields = fields[1:]
+		}
+
+		var words []string
+		for _, f := range fields {
+			switch {
+			case len(f) > 1 && f[0] == '+' && rec.Category == "":
+				rec.Category = strings.ReplaceAll(f[1:], "_", " ")
+			case len(f) > 1 && f[0] == '@':
+				rec.Tags = append(rec.Tags, strings.ReplaceAll(f[1:], "_", " "))
+			case strings.HasPrefix(f, "due:"):
+				t, err := time.ParseInLocation(todoTxtDate, f[len("due:"):], loc)
+				if err != nil {
+					return nil, recordError(n, fmt.Errorf("due must be YYYY-MM-DD, got %q", f))
+				}
+				t = t.UTC()
+				rec.DueDate = &t
+			default:
+				words = append(words, f)
+			}
+		}
+		rec.Title = strings.Join(words, " ")
+		doc.Todos = append(doc.Todos, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid todo.txt: %w", err)
+	}
+	return doc, nil
+}
+
+// todoTxtDateIn reads fields[0] as a date, if it is one.
+func todoTxtDateIn(fields []string, loc *time.Location) (time.Time, bool) {
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(todoTxtDate, fields[0], loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
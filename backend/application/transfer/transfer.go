@@ -0,0 +1,80 @@
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// Format is a file format todos can be exported to and imported from.
+type Format string
+
+const (
+	// FormatJSON is the full backup: every category and tag, and every todo
+	// field that can be restored.
+	FormatJSON Format = "json"
+	// FormatCSV has one row per todo, for spreadsheets.
+	FormatCSV Format = "csv"
+	// FormatTodoTxt is the todo.txt format (https://github.com/todotxt/todo.txt).
+	FormatTodoTxt Format = "todotxt"
+)
+
+// MaxImportTodos caps how many todos one import may create.
+const MaxImportTodos = 1000
+
+var (
+	ErrInvalidFormat = apperror.InvalidField("format", errors.New(`format must be "json", "csv" or "todotxt"`))
+	ErrImportEmpty   = apperror.Validation("the file has no todos to import")
+	ErrImportTooBig  = apperror.Validation(fmt.Sprintf("an import can hold at most %d todos", MaxImportTodos))
+)
+
+// ParseFormat validates a format name.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatJSON, FormatCSV, FormatTodoTxt:
+		return f, nil
+	}
+	return "", ErrInvalidFormat
+}
+
+// Document is a user's data in portable form: todos refer to their category
+// and tags by name, so a document can be imported into another account.
+type Document struct {
+	Categories []CategoryRecord
+	Tags       []string
+	Todos      []Record
+	// Location is the owner's timezone; todo.txt dates are written in it.
+	Location *time.Location
+}
+
+type CategoryRecord struct {
+	Name        string
+	Color       string
+	Description *string
+}
+
+// Record is one todo. Priority is "" for NONE, and Category and Recurrence
+// (an RRULE otherwise) are "" when the todo has none. CreatedAt is exported for reference
+// only; imported todos are created now.
+type Record struct {
+	Title       string
+	Body        *string
+	Status      entity.Status
+	Priority    entity.Priority
+	DueDate     *time.Time
+	CompletedAt *time.Time
+	CreatedAt   *time.Time
+	Category    string
+	Tags        []string
+	Recurrence  string
+}
+
+// recordError reports a problem with the n-th todo of an import file.
+func recordError(n int, err error) error {
+	e := apperror.Validation(fmt.Sprintf("todo %d: %v", n, err))
+	e.Err = err
+	return e
+}
@@ -0,0 +1,191 @@
+package user
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // registers the WebP decoder with image.Decode
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+const (
+	// MaxAvatarBytes caps an uploaded avatar image.
+	MaxAvatarBytes = 5 << 20
+	// maxAvatarPixels guards against images that are small on disk but
+	// huge once decoded: at 4 bytes a pixel it keeps one upload under 64 MB.
+	maxAvatarPixels = 16_000_000
+)
+
+// AvatarSizes are the edge lengths, in pixels, of the square thumbnails made
+// from each upload. The last one is served by default.
+var AvatarSizes = []int{64, 256}
+
+var (
+	ErrAvatarFormat      = apperror.InvalidField("file", errors.New("avatar must be a PNG, JPEG or WebP image"))
+	ErrAvatarTooLarge    = apperror.InvalidField("file", fmt.Errorf("avatar cannot be larger than %d MB", MaxAvatarBytes>>20))
+	ErrAvatarDimensions  = apperror.InvalidField("file", fmt.Errorf("avatar image cannot exceed %d megapixels", maxAvatarPixels/1_000_000))
+	ErrAvatarNotFound    = apperror.NotFound("avatar not found")
+	ErrInvalidAvatarSize = apperror.InvalidField("size", errors.New("size must be 64 or 256"))
+)
+
+// avatarFormats are the image.Decode format names an avatar may have.
+var avatarFormats = map[string]bool{"png": true, "jpeg": true, "webp": true}
+
+type thumbnail struct {
+	size        int
+	contentType string
+	data        []byte
+}
+
+// makeThumbnails decodes data as a PNG, JPEG or WebP image and renders the
+// centered square of it at each of AvatarSizes. The thumbnails are encoded
+// afresh, so none of the upload's metadata (EXIF, ICC profiles, text chunks)
+// carries over; a JPEG's EXIF orientation is applied first. Opaque
+// thumbnails are JPEGs and the rest PNGs, to keep their transparency.
+func makeThumbnails(data []byte) ([]thumbnail, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || !avatarFormats[format] {
+		return nil, ErrAvatarFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxAvatarPixels {
+		return nil, ErrAvatarDimensions
+	}
+	// decoding the whole image is what proves it is one, not just its header
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrAvatarFormat
+	}
+	orientation := 1
+	if format == "jpeg" {
+		orientation = jpegOrientation(data)
+	}
+
+	// the centered square of the turned image is the turned centered square,
+	// so only the small thumbnails need turning
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	crop := image.Rect(0, 0, side, side).Add(b.Min).Add(image.Pt((b.Dx()-side)/2, (b.Dy()-side)/2))
+
+	thumbs := make([]thumbnail, 0, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		dst := image.NewRGBA(image.Rect(0, 0, size, size))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+		dst = orient(dst, orientation)
+
+		var buf bytes.Buffer
+		t := thumbnail{size: size}
+		if dst.Opaque() {
+			t.contentType = "image/jpeg"
+			err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+		} else {
+			t.contentType = "image/png"
+			err = png.Encode(&buf, dst)
+		}
+		if err != nil {
+			return nil, err
+		}
+		t.data = buf.Bytes()
+		thumbs = append(thumbs, t)
+	}
+	return thumbs, nil
+}
+
+// orient turns a square image upright according to an EXIF orientation (1–8).
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	n := src.Bounds().Dx()
+	dst := image.NewRGBA(src.Bounds())
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // flip horizontally
+				dx, dy = n-1-x, y
+			case 3: // rotate 180°
+				dx, dy = n-1-x, n-1-y
+			case 4: // flip vertically
+				dx, dy = x, n-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // rotate 90° clockwise
+				dx, dy = n-1-y, x
+			case 7: // transverse
+				dx, dy = n-1-y, n-1-x
+			case 8: // rotate 90° counterclockwise
+				dx, dy = y, n-1-x
+			}
+			dst.SetRGBA(dx, dy, src.RGBAAt(x, y))
+		}
+	}
+	return dst
+}
+
+// jpegOrientation reads the EXIF orientation of a JPEG, or 1 (upright) when
+// it has none or the metadata cannot be read.
+func jpegOrientation(data []byte) int {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA { // start of scan: the metadata segments are over
+			return 1
+		}
+		n := int(binary.BigEndian.Uint16(data[i+2:]))
+		if n < 2 || i+2+n > len(data) {
+			return 1
+		}
+		if seg := data[i+4 : i+2+n]; marker == 0xE1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return exifOrientation(seg[6:])
+		}
+		i += 2 + n
+	}
+	return 1
+}
+
+// exifOrientation finds the Orientation tag in the first IFD of a TIFF block.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for k := 0; k < count; k++ {
+		e := ifd + 2 + 12*k
+		if e+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[e:]) == 0x0112 {
+			// a SHORT, stored in the first two bytes of the value field
+			if o := int(order.Uint16(tiff[e+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 1
+		}
+	}
+	return 1
+}
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"context"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/blob"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// AvatarURLTTL is how long the signed URL an avatar redirects to stays valid.
+const AvatarURLTTL = time.Hour
+
+// AvatarUseCase resolves the AvatarURL of an uploaded avatar.
+type AvatarUseCase interface {
+	// Execute returns a signed URL for the thumbnail of the given size. Only
+	// the user's current upload is served: version must be the one in
+	// their AvatarURL, so an old or guessed URL is ErrAvatarNotFound.
+	Execute(ctx context.Context, userID, version string, size int) (string, error)
+}
+
+type avatarUseCase struct {
+	repo  repository.UserRepository
+	blobs blob.BlobStore
+}
+
+func NewAvatarUseCase(repo repository.UserRepository, blobs blob.BlobStore) AvatarUseCase {
+	return &avatarUseCase{repo, blobs}
+}
+
+func (uc *avatarUseCase) Execute(ctx context.Context, userID, version string, size int) (string, error) {
+	if !slices.Contains(AvatarSizes, size) {
+		return "", ErrInvalidAvatarSize
+	}
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if u == nil || u.AvatarKey == nil || path.Base(*u.AvatarKey) != version {
+		return "", ErrAvatarNotFound
+	}
+	return uc.blobs.SignedURL(ctx, thumbnailKey(*u.AvatarKey, size), AvatarURLTTL, "")
+}
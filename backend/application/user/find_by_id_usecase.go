@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 )
@@ -22,7 +23,16 @@ func NewFindByIDUseCase(repo repository.UserRepository) FindByIDUseCase {
 	return &findByIDUseCase{repo: repo}
 }
 
-// Execute calls the repository’s FindByID method.
+var ErrUserNotFound = apperror.NotFound("user not found")
+
+// Execute calls the repository’s FindByID method, returning ErrUserNotFound for a missing user.
 func (uc *findByIDUseCase) Execute(ctx context.Context, id string) (*entity.User, error) {
-	return uc.repo.FindByID(ctx, id)
+	u, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	return u, nil
 }
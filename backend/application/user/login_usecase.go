@@ -2,47 +2,44 @@ package user
 
 import (
 	"context"
-	"errors"
-	"time"
 
+	"github.com/ariangn/todo-fullstack/backend/application/session"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
-	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
 )
 
-var ErrInvalidCredentials = errors.New("invalid email or password")
+var ErrInvalidCredentials = apperror.Unauthorized("invalid email or password")
 
 type LoginUseCase interface {
-	Execute(ctx context.Context, email, password string) (string /* JWT token */, error)
+	// Execute checks the credentials and opens a session for the device
+	// identified by userAgent.
+	Execute(ctx context.Context, email, password, userAgent string) (*session.Tokens, error)
 }
 
 type loginUseCase struct {
-	userRepo   repository.UserRepository
-	authClient auth.AuthClientInterface
+	userRepo repository.UserRepository
+	startUC  session.StartUseCase
 }
 
-func NewLoginUseCase(userRepo repository.UserRepository, authClient auth.AuthClientInterface) LoginUseCase {
-	return &loginUseCase{userRepo, authClient}
+func NewLoginUseCase(userRepo repository.UserRepository, startUC session.StartUseCase) LoginUseCase {
+	return &loginUseCase{userRepo, startUC}
 }
 
-func (uc *loginUseCase) Execute(ctx context.Context, email, password string) (string, error) {
+func (uc *loginUseCase) Execute(ctx context.Context, email, password, userAgent string) (*session.Tokens, error) {
 	// lookup user by email
 	existing, err := uc.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if existing == nil {
-		return "", ErrInvalidCredentials
+		return nil, ErrInvalidCredentials
 	}
 	// verify password
 	pwdVO := valueobject.NewPasswordVOWithHash(existing.Password)
 	if !pwdVO.Verify(password) {
-		return "", ErrInvalidCredentials
+		return nil, ErrInvalidCredentials
 	}
-	// gnerate JWT (24h TTL)
-	token, err := uc.authClient.GenerateToken(existing.ID, 24*time.Hour)
-	if err != nil {
-		return "", err
-	}
-	return token, nil
+	// short-lived access token plus a refresh token for this device
+	return uc.startUC.Execute(ctx, existing.ID, userAgent)
 }
@@ -6,6 +6,7 @@ import (
 
 	"fmt"
 
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
@@ -29,13 +30,13 @@ func (uc *registerUseCase) Execute(ctx context.Context, email, password string,
 	emailVO, err := valueobject.NewEmailVO(email)
 	if err != nil {
 		fmt.Println("Email validation failed:", err)
-		return nil, err
+		return nil, apperror.InvalidField("email", err)
 	}
 	// validate & hash password via PasswordVO
 	pwdVO, err := valueobject.NewPasswordVO(password)
 	if err != nil {
 		fmt.Println("Password validation failed:", err)
-		return nil, err
+		return nil, apperror.InvalidField("password", err)
 	}
 	hashedPwd := pwdVO.Hash()
 
@@ -66,4 +67,4 @@ func (uc *registerUseCase) Execute(ctx context.Context, email, password string,
 	return res, nil
 }
 
-var ErrTimezoneMissing = errors.New("timezone is required")
+var ErrTimezoneMissing = apperror.InvalidField("timezone", errors.New("timezone is required"))
@@ -0,0 +1,97 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/blob"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// SetAvatarUseCase replaces a user's avatar with an uploaded image.
+type SetAvatarUseCase interface {
+	// Execute stores thumbnails of the image and points AvatarURL at them.
+	// The previous upload's thumbnails are removed.
+	Execute(ctx context.Context, userID string, r io.Reader) (*entity.User, error)
+}
+
+type setAvatarUseCase struct {
+	repo  repository.UserRepository
+	blobs blob.BlobStore
+	// apiOrigin is prepended to the avatar path to make AvatarURL absolute,
+	// since it is shown by a frontend on another origin
+	apiOrigin string
+}
+
+func NewSetAvatarUseCase(repo repository.UserRepository, blobs blob.BlobStore, apiOrigin string) SetAvatarUseCase {
+	return &setAvatarUseCase{repo, blobs, apiOrigin}
+}
+
+func (uc *setAvatarUseCase) Execute(ctx context.Context, userID string, r io.Reader) (*entity.User, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxAvatarBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxAvatarBytes {
+		return nil, ErrAvatarTooLarge
+	}
+	thumbs, err := makeThumbnails(data)
+	if err != nil {
+		return nil, err
+	}
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+
+	// every upload gets its own key, so the new AvatarURL is never served
+	// stale from a cache
+	version := uuid.NewString()
+	key := "avatars/" + userID + "/" + version
+	keys := make([]string, 0, len(thumbs))
+	for _, t := range thumbs {
+		k := thumbnailKey(key, t.size)
+		if err := uc.blobs.Put(ctx, k, bytes.NewReader(t.data), t.contentType); err != nil {
+			uc.remove(ctx, keys)
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	url := uc.apiOrigin + "/api/users/" + userID + "/avatar/" + version
+	updated, err := uc.repo.SetAvatar(ctx, userID, &url, &key)
+	if err != nil {
+		uc.remove(ctx, keys)
+		return nil, err
+	}
+	if u.AvatarKey != nil {
+		old := make([]string, 0, len(AvatarSizes))
+		for _, size := range AvatarSizes {
+			old = append(old, thumbnailKey(*u.AvatarKey, size))
+		}
+		uc.remove(ctx, old)
+	}
+	return updated, nil
+}
+
+// remove deletes thumbnails that are no longer referenced. A failure only
+// leaves unreachable files behind, so it is logged rather than returned.
+func (uc *setAvatarUseCase) remove(ctx context.Context, keys []string) {
+	if err := uc.blobs.Delete(ctx, keys...); err != nil {
+		log.Printf("failed to remove %d avatar thumbnails: %v", len(keys), err)
+	}
+}
+
+// thumbnailKey names the thumbnail of the given size under an avatar's key.
+func thumbnailKey(avatarKey string, size int) string {
+	return avatarKey + "/" + strconv.Itoa(size)
+}
@@ -0,0 +1,97 @@
+package workspace
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+)
+
+type AcceptInvitationUseCase interface {
+	// Execute makes userID a member with the invitation's role and discards
+	// the invitation. Only the invitee may accept, with the token the owner
+	// passed on to them and before the invitation expires; to anyone else,
+	// or with the wrong token, the invitation does not exist.
+	Execute(ctx context.Context, userID, invitationID, token string) (*entity.WorkspaceMember, error)
+}
+
+type acceptInvitationUseCase struct {
+	workspaceRepo  repository.WorkspaceRepository
+	invitationRepo repository.WorkspaceInvitationRepository
+	userRepo       repository.UserRepository
+}
+
+func NewAcceptInvitationUseCase(
+	workspaceRepo repository.WorkspaceRepository,
+	invitationRepo repository.WorkspaceInvitationRepository,
+	userRepo repository.UserRepository,
+) AcceptInvitationUseCase {
+	return &acceptInvitationUseCase{workspaceRepo, invitationRepo, userRepo}
+}
+
+func (uc *acceptInvitationUseCase) Execute(ctx context.Context, userID, invitationID, token string) (*entity.WorkspaceMember, error) {
+	inv, err := receivedInvitation(ctx, uc.invitationRepo, uc.userRepo, userID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	// the email alone is not proof: addresses are not verified at sign-up
+	if !auth.InvitationTokenMatches(token, inv.TokenHash) {
+		return nil, ErrInvitationNotFound
+	}
+	if inv.Expired(time.Now()) {
+		return nil, ErrInvitationExpired
+	}
+
+	existing, err := uc.workspaceRepo.FindMember(ctx, inv.WorkspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		// joined some other way meanwhile; the invitation has served its purpose
+		if err := uc.invitationRepo.Delete(ctx, inv.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrAlreadyMember
+	}
+
+	m := &entity.WorkspaceMember{
+		WorkspaceID: inv.WorkspaceID,
+		UserID:      userID,
+		Role:        inv.Role,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := uc.workspaceRepo.SaveMember(ctx, m); err != nil {
+		return nil, err
+	}
+	if err := uc.invitationRepo.Delete(ctx, inv.ID); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// receivedInvitation loads an invitation addressed to userID's email.
+func receivedInvitation(
+	ctx context.Context,
+	invitationRepo repository.WorkspaceInvitationRepository,
+	userRepo repository.UserRepository,
+	userID, invitationID string,
+) (*entity.WorkspaceInvitation, error) {
+	inv, err := invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if inv == nil {
+		return nil, ErrInvitationNotFound
+	}
+	u, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || strings.ToLower(u.Email) != inv.Email {
+		return nil, ErrInvitationNotFound
+	}
+	return inv, nil
+}
@@ -0,0 +1,38 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type CreateUseCase interface {
+	// Execute creates a workspace with userID as its owner.
+	Execute(ctx context.Context, userID, name string) (*entity.Workspace, error)
+}
+
+type createUseCase struct {
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewCreateUseCase(workspaceRepo repository.WorkspaceRepository) CreateUseCase {
+	return &createUseCase{workspaceRepo}
+}
+
+func (uc *createUseCase) Execute(ctx context.Context, userID, name string) (*entity.Workspace, error) {
+	w, err := entity.NewWorkspace(uuid.NewString(), name)
+	if err != nil {
+		return nil, apperror.InvalidField("name", err)
+	}
+	return uc.workspaceRepo.Create(ctx, w, &entity.WorkspaceMember{
+		WorkspaceID: w.ID,
+		UserID:      userID,
+		Role:        entity.RoleOwner,
+		CreatedAt:   time.Now().UTC(),
+	})
+}
@@ -0,0 +1,55 @@
+package workspace
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type DeleteInvitationUseCase interface {
+	// Execute discards an open invitation: the invitee declines it, or an
+	// owner of its workspace revokes it. To anyone else it does not exist.
+	Execute(ctx context.Context, userID, invitationID string) error
+}
+
+type deleteInvitationUseCase struct {
+	workspaceRepo  repository.WorkspaceRepository
+	invitationRepo repository.WorkspaceInvitationRepository
+	userRepo       repository.UserRepository
+}
+
+func NewDeleteInvitationUseCase(
+	workspaceRepo repository.WorkspaceRepository,
+	invitationRepo repository.WorkspaceInvitationRepository,
+	userRepo repository.UserRepository,
+) DeleteInvitationUseCase {
+	return &deleteInvitationUseCase{workspaceRepo, invitationRepo, userRepo}
+}
+
+func (uc *deleteInvitationUseCase) Execute(ctx context.Context, userID, invitationID string) error {
+	inv, err := uc.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if inv == nil {
+		return ErrInvitationNotFound
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	allowed := u != nil && strings.ToLower(u.Email) == inv.Email
+	if !allowed {
+		m, err := uc.workspaceRepo.FindMember(ctx, inv.WorkspaceID, userID)
+		if err != nil {
+			return err
+		}
+		allowed = m != nil && m.Role.CanManage()
+	}
+	if !allowed {
+		return ErrInvitationNotFound
+	}
+	return uc.invitationRepo.Delete(ctx, inv.ID)
+}
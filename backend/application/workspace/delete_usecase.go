@@ -0,0 +1,28 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type DeleteUseCase interface {
+	// Execute deletes the workspace with everything in it: its todos,
+	// categories, tags, members and invitations. Only its owners may.
+	Execute(ctx context.Context, userID, id string) error
+}
+
+type deleteUseCase struct {
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewDeleteUseCase(workspaceRepo repository.WorkspaceRepository) DeleteUseCase {
+	return &deleteUseCase{workspaceRepo}
+}
+
+func (uc *deleteUseCase) Execute(ctx context.Context, userID, id string) error {
+	if err := owner(ctx, uc.workspaceRepo, userID, id); err != nil {
+		return err
+	}
+	return uc.workspaceRepo.Delete(ctx, id)
+}
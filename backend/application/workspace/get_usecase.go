@@ -0,0 +1,64 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// MemberInfo is a member with the account it belongs to; User is nil when
+// the account could not be found.
+type MemberInfo struct {
+	Member *entity.WorkspaceMember
+	User   *entity.User
+}
+
+// Detail is a workspace as one of its members sees it.
+type Detail struct {
+	Workspace *entity.Workspace
+	Role      entity.WorkspaceRole // the caller's
+	Members   []*MemberInfo
+}
+
+type GetUseCase interface {
+	// Execute returns the workspace and its members. Only members may see it.
+	Execute(ctx context.Context, userID, id string) (*Detail, error)
+}
+
+type getUseCase struct {
+	workspaceRepo repository.WorkspaceRepository
+	userRepo      repository.UserRepository
+}
+
+func NewGetUseCase(workspaceRepo repository.WorkspaceRepository, userRepo repository.UserRepository) GetUseCase {
+	return &getUseCase{workspaceRepo, userRepo}
+}
+
+func (uc *getUseCase) Execute(ctx context.Context, userID, id string) (*Detail, error) {
+	me, err := Member(ctx, uc.workspaceRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	w, err := uc.workspaceRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+	members, err := uc.workspaceRepo.FindMembers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Detail{Workspace: w, Role: me.Role, Members: make([]*MemberInfo, 0, len(members))}
+	for _, m := range members {
+		u, err := uc.userRepo.FindByID(ctx, m.UserID)
+		if err != nil {
+			return nil, err
+		}
+		d.Members = append(d.Members, &MemberInfo{Member: m, User: u})
+	}
+	return d, nil
+}
@@ -0,0 +1,107 @@
+package workspace
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+)
+
+type InviteUseCase interface {
+	// Execute invites whoever signs in with email to join the workspace with
+	// role. Only owners may invite, and the email does not need an account yet.
+	// The invitee accepts with the returned token, which the owner passes on
+	// to them; it is only ever returned here. An expired invitation to the
+	// same email is replaced.
+	Execute(ctx context.Context, userID, workspaceID, email string, role entity.WorkspaceRole) (token string, inv *entity.WorkspaceInvitation, err error)
+}
+
+type inviteUseCase struct {
+	workspaceRepo  repository.WorkspaceRepository
+	invitationRepo repository.WorkspaceInvitationRepository
+	userRepo       repository.UserRepository
+}
+
+func NewInviteUseCase(
+	workspaceRepo repository.WorkspaceRepository,
+	invitationRepo repository.WorkspaceInvitationRepository,
+	userRepo repository.UserRepository,
+) InviteUseCase {
+	return &inviteUseCase{workspaceRepo, invitationRepo, userRepo}
+}
+
+func (uc *inviteUseCase) Execute(
+	ctx context.Context,
+	userID, workspaceID, email string,
+	role entity.WorkspaceRole,
+) (string, *entity.WorkspaceInvitation, error) {
+	emailVO, err := valueobject.NewEmailVO(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return "", nil, apperror.InvalidField("email", err)
+	}
+	if !role.Valid() {
+		return "", nil, ErrInvalidRole
+	}
+	if err := owner(ctx, uc.workspaceRepo, userID, workspaceID); err != nil {
+		return "", nil, err
+	}
+
+	invitee, err := uc.userRepo.FindByEmail(ctx, emailVO.String())
+	if err != nil {
+		return "", nil, err
+	}
+	if invitee != nil {
+		m, err := uc.workspaceRepo.FindMember(ctx, workspaceID, invitee.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		if m != nil {
+			return "", nil, ErrAlreadyMember
+		}
+	}
+
+	now := time.Now().UTC()
+	if err := uc.dropExpired(ctx, workspaceID, emailVO.String(), now); err != nil {
+		return "", nil, err
+	}
+	token, hash, err := auth.NewInvitationToken()
+	if err != nil {
+		return "", nil, err
+	}
+	inv, err := uc.invitationRepo.Create(ctx, &entity.WorkspaceInvitation{
+		ID:          uuid.NewString(),
+		WorkspaceID: workspaceID,
+		Email:       emailVO.String(),
+		Role:        role,
+		InvitedBy:   userID,
+		TokenHash:   hash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(entity.InvitationTTL),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return token, inv, nil
+}
+
+// dropExpired deletes an expired invitation of email to the workspace, which
+// would otherwise block a new one.
+func (uc *inviteUseCase) dropExpired(ctx context.Context, workspaceID, email string, now time.Time) error {
+	invitations, err := uc.invitationRepo.FindByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	for _, inv := range invitations {
+		if inv.Email == email && inv.Expired(now) {
+			return uc.invitationRepo.Delete(ctx, inv.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type ListInvitationsUseCase interface {
+	// Execute returns the workspace's open invitations, newest first. Only
+	// owners may see them.
+	Execute(ctx context.Context, userID, workspaceID string) ([]*entity.WorkspaceInvitation, error)
+}
+
+type listInvitationsUseCase struct {
+	workspaceRepo  repository.WorkspaceRepository
+	invitationRepo repository.WorkspaceInvitationRepository
+}
+
+func NewListInvitationsUseCase(
+	workspaceRepo repository.WorkspaceRepository,
+	invitationRepo repository.WorkspaceInvitationRepository,
+) ListInvitationsUseCase {
+	return &listInvitationsUseCase{workspaceRepo, invitationRepo}
+}
+
+func (uc *listInvitationsUseCase) Execute(ctx context.Context, userID, workspaceID string) ([]*entity.WorkspaceInvitation, error) {
+	if err := owner(ctx, uc.workspaceRepo, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return uc.invitationRepo.FindByWorkspace(ctx, workspaceID)
+}
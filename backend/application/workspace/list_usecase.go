@@ -0,0 +1,58 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// Membership is a workspace together with the caller's role in it.
+type Membership struct {
+	Workspace *entity.Workspace
+	Role      entity.WorkspaceRole
+}
+
+type ListUseCase interface {
+	// Execute returns the workspaces userID belongs to, in the order they joined.
+	Execute(ctx context.Context, userID string) ([]*Membership, error)
+}
+
+type listUseCase struct {
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewListUseCase(workspaceRepo repository.WorkspaceRepository) ListUseCase {
+	return &listUseCase{workspaceRepo}
+}
+
+func (uc *listUseCase) Execute(ctx context.Context, userID string) ([]*Membership, error) {
+	members, err := uc.workspaceRepo.FindMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return []*Membership{}, nil
+	}
+	ids := make([]string, len(members))
+	for i, m := range members {
+		ids[i] = m.WorkspaceID
+	}
+	workspaces, err := uc.workspaceRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Workspace, len(workspaces))
+	for _, w := range workspaces {
+		byID[w.ID] = w
+	}
+
+	out := make([]*Membership, 0, len(members))
+	for _, m := range members {
+		// a workspace deleted between the two reads is skipped
+		if w, ok := byID[m.WorkspaceID]; ok {
+			out = append(out, &Membership{Workspace: w, Role: m.Role})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,78 @@
+package workspace
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// ReceivedInvitation is an invitation with the workspace it leads to.
+type ReceivedInvitation struct {
+	Invitation *entity.WorkspaceInvitation
+	Workspace  *entity.Workspace
+}
+
+type ReceivedInvitationsUseCase interface {
+	// Execute returns the unexpired invitations sent to userID's email,
+	// newest first.
+	Execute(ctx context.Context, userID string) ([]*ReceivedInvitation, error)
+}
+
+type receivedInvitationsUseCase struct {
+	workspaceRepo  repository.WorkspaceRepository
+	invitationRepo repository.WorkspaceInvitationRepository
+	userRepo       repository.UserRepository
+}
+
+func NewReceivedInvitationsUseCase(
+	workspaceRepo repository.WorkspaceRepository,
+	invitationRepo repository.WorkspaceInvitationRepository,
+	userRepo repository.UserRepository,
+) ReceivedInvitationsUseCase {
+	return &receivedInvitationsUseCase{workspaceRepo, invitationRepo, userRepo}
+}
+
+func (uc *receivedInvitationsUseCase) Execute(ctx context.Context, userID string) ([]*ReceivedInvitation, error) {
+	u, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return []*ReceivedInvitation{}, nil
+	}
+	invitations, err := uc.invitationRepo.FindByEmail(ctx, strings.ToLower(u.Email))
+	if err != nil {
+		return nil, err
+	}
+	if len(invitations) == 0 {
+		return []*ReceivedInvitation{}, nil
+	}
+
+	ids := make([]string, len(invitations))
+	for i, inv := range invitations {
+		ids[i] = inv.WorkspaceID
+	}
+	workspaces, err := uc.workspaceRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Workspace, len(workspaces))
+	for _, w := range workspaces {
+		byID[w.ID] = w
+	}
+
+	now := time.Now()
+	out := make([]*ReceivedInvitation, 0, len(invitations))
+	for _, inv := range invitations {
+		if inv.Expired(now) {
+			continue
+		}
+		if w, ok := byID[inv.WorkspaceID]; ok {
+			out = append(out, &ReceivedInvitation{Invitation: inv, Workspace: w})
+		}
+	}
+	return out, nil
+}
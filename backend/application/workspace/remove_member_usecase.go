@@ -0,0 +1,47 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type RemoveMemberUseCase interface {
+	// Execute takes memberID out of the workspace. Owners may remove anyone
+	// and every member may leave, except the last owner. The records a
+	// removed member created stay in the workspace.
+	Execute(ctx context.Context, userID, workspaceID, memberID string) error
+}
+
+type removeMemberUseCase struct {
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewRemoveMemberUseCase(workspaceRepo repository.WorkspaceRepository) RemoveMemberUseCase {
+	return &removeMemberUseCase{workspaceRepo}
+}
+
+func (uc *removeMemberUseCase) Execute(ctx context.Context, userID, workspaceID, memberID string) error {
+	me, err := Member(ctx, uc.workspaceRepo, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && !me.Role.CanManage() {
+		return ErrNotOwner
+	}
+	m, err := uc.workspaceRepo.FindMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return ErrMemberNotFound
+	}
+	last, err := lastOwner(ctx, uc.workspaceRepo, m)
+	if err != nil {
+		return err
+	}
+	if last {
+		return ErrLastOwner
+	}
+	return uc.workspaceRepo.RemoveMember(ctx, workspaceID, memberID)
+}
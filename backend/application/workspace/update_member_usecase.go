@@ -0,0 +1,57 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type UpdateMemberUseCase interface {
+	// Execute gives memberID a new role in the workspace. Only owners may,
+	// and the last owner cannot be demoted.
+	Execute(ctx context.Context, userID, workspaceID, memberID string, role entity.WorkspaceRole) (*entity.WorkspaceMember, error)
+}
+
+type updateMemberUseCase struct {
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewUpdateMemberUseCase(workspaceRepo repository.WorkspaceRepository) UpdateMemberUseCase {
+	return &updateMemberUseCase{workspaceRepo}
+}
+
+func (uc *updateMemberUseCase) Execute(
+	ctx context.Context,
+	userID, workspaceID, memberID string,
+	role entity.WorkspaceRole,
+) (*entity.WorkspaceMember, error) {
+	if !role.Valid() {
+		return nil, ErrInvalidRole
+	}
+	if err := owner(ctx, uc.workspaceRepo, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	m, err := uc.workspaceRepo.FindMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, ErrMemberNotFound
+	}
+	if role != entity.RoleOwner {
+		last, err := lastOwner(ctx, uc.workspaceRepo, m)
+		if err != nil {
+			return nil, err
+		}
+		if last {
+			return nil, ErrLastOwner
+		}
+	}
+
+	m.Role = role
+	if err := uc.workspaceRepo.SaveMember(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
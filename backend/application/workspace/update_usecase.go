@@ -0,0 +1,43 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type UpdateUseCase interface {
+	// Execute renames the workspace; only its owners may.
+	Execute(ctx context.Context, userID, id, name string) (*entity.Workspace, error)
+}
+
+type updateUseCase struct {
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewUpdateUseCase(workspaceRepo repository.WorkspaceRepository) UpdateUseCase {
+	return &updateUseCase{workspaceRepo}
+}
+
+func (uc *updateUseCase) Execute(ctx context.Context, userID, id, name string) (*entity.Workspace, error) {
+	name, err := entity.WorkspaceName(name)
+	if err != nil {
+		return nil, apperror.InvalidField("name", err)
+	}
+	if err := owner(ctx, uc.workspaceRepo, userID, id); err != nil {
+		return nil, err
+	}
+	w, err := uc.workspaceRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+	w.Name = name
+	w.UpdatedAt = time.Now().UTC()
+	return uc.workspaceRepo.Update(ctx, w)
+}
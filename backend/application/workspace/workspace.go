@@ -0,0 +1,116 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+var (
+	// ErrWorkspaceNotFound is also returned to users outside the workspace, so
+	// workspace IDs cannot be probed.
+	ErrWorkspaceNotFound  = apperror.NotFound("workspace not found")
+	ErrInvitationNotFound = apperror.NotFound("invitation not found")
+	ErrInvitationExpired  = apperror.NotFound("invitation has expired; ask for a new one")
+	ErrMemberNotFound     = apperror.NotFound("member not found")
+	ErrNotOwner           = apperror.Forbidden("only the workspace's owners can do this")
+	ErrReadOnly           = apperror.Forbidden("viewers cannot change the workspace's records")
+	ErrLastOwner          = apperror.Conflict("a workspace needs at least one owner; promote another member first")
+	ErrAlreadyMember      = apperror.Conflict("that user is already a member of the workspace")
+	ErrInvalidRole        = apperror.InvalidField("role", errors.New(`role must be "owner", "editor" or "viewer"`))
+)
+
+// Member returns userID's membership of the workspace. A workspace they do
+// not belong to is reported as not found.
+func Member(ctx context.Context, repo repository.WorkspaceRepository, userID, workspaceID string) (*entity.WorkspaceMember, error) {
+	m, err := repo.FindMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+	return m, nil
+}
+
+// CheckRecord verifies that userID may read, or with edit also change, a
+// record that ownerID created in workspaceID, or in their personal space when
+// workspaceID is nil. Personal records are their owner's alone; workspace
+// records are open to the workspace's members. Without any access it returns
+// noAccess, so each caller reports foreign records the way it always has; a
+// viewer asking to edit gets ErrReadOnly.
+func CheckRecord(
+	ctx context.Context,
+	repo repository.WorkspaceRepository,
+	userID, ownerID string,
+	workspaceID *string,
+	edit bool,
+	noAccess error,
+) error {
+	if workspaceID == nil {
+		if ownerID != userID {
+			return noAccess
+		}
+		return nil
+	}
+	m, err := repo.FindMember(ctx, *workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return noAccess
+	}
+	if edit && !m.Role.CanEdit() {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// CheckScope verifies that userID may list (edit false) or create (edit
+// true) records in workspaceID. A nil workspaceID is their personal space,
+// which is always theirs.
+func CheckScope(ctx context.Context, repo repository.WorkspaceRepository, userID string, workspaceID *string, edit bool) error {
+	if workspaceID == nil {
+		return nil
+	}
+	m, err := Member(ctx, repo, userID, *workspaceID)
+	if err != nil {
+		return err
+	}
+	if edit && !m.Role.CanEdit() {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// owner checks that userID owns the workspace.
+func owner(ctx context.Context, repo repository.WorkspaceRepository, userID, workspaceID string) error {
+	m, err := Member(ctx, repo, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !m.Role.CanManage() {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+// lastOwner reports whether m is the only owner of its workspace.
+func lastOwner(ctx context.Context, repo repository.WorkspaceRepository, m *entity.WorkspaceMember) (bool, error) {
+	if m.Role != entity.RoleOwner {
+		return false, nil
+	}
+	members, err := repo.FindMembers(ctx, m.WorkspaceID)
+	if err != nil {
+		return false, err
+	}
+	for _, other := range members {
+		if other.Role == entity.RoleOwner && other.UserID != m.UserID {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -3,18 +3,19 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	"github.com/joho/godotenv"
 
+	"github.com/ariangn/todo-fullstack/backend/application/attachment"
+	"github.com/ariangn/todo-fullstack/backend/application/reminder"
+	"github.com/ariangn/todo-fullstack/backend/application/todo"
 	"github.com/ariangn/todo-fullstack/backend/di"
-	custommw "github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
 )
 
 func main() {
@@ -34,68 +35,31 @@ func main() {
 		log.Fatal("CLIENT_ORIGIN must be set in your .env file")
 	}
 
-	// Set up router with common middleware
-	r := chi.NewRouter()
-	r.Use(middleware.Logger)    // logs every request
-	r.Use(middleware.Recoverer) // prevents panics from crashing server
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{clientOrigin},
-		AllowCredentials: true,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-	}))
-
-	// Define /api routes
-	r.Route("/api", func(r chi.Router) {
-		// Public routes
-		r.Post("/users/register", container.UserController.Register)
-		r.Post("/users/login", container.UserController.Login)
-		r.Post("/users/logout", container.UserController.Logout)
-
-		// Protected routes
-		r.Group(func(r chi.Router) {
-			r.Use(custommw.AuthMiddleware(container.AuthClient))
-
-			// Auth info
-			r.Get("/auth/me", container.UserController.Me)
-
-			// Todos
-			r.Route("/todos", func(r chi.Router) {
-				r.Post("/", container.TodoController.Create)
-				r.Get("/", container.TodoController.List)
-				r.Get("/{id}", container.TodoController.GetByID)
-				r.Put("/{id}", container.TodoController.Update)
-				r.Patch("/{id}/status", container.TodoController.ToggleStatus)
-				r.Delete("/{id}", container.TodoController.Delete)
-				r.Post("/{id}/duplicate", container.TodoController.Duplicate)
-			})
-
-			// Categories
-			r.Route("/categories", func(r chi.Router) {
-				r.Post("/", container.CategoryController.Create)
-				r.Get("/", container.CategoryController.List)
-				r.Put("/{id}", container.CategoryController.Update)
-				r.Delete("/{id}", container.CategoryController.Delete)
-			})
-
-			// Tags
-			r.Route("/tags", func(r chi.Router) {
-				r.Post("/", container.TagController.Create)
-				r.Get("/", container.TagController.List)
-				r.Put("/{id}", container.TagController.Update)
-				r.Delete("/{id}", container.TagController.Delete)
-			})
-		})
-	})
-
-	// Start server with graceful shutdown
+	// Deleted todos stay in the trash for TRASH_RETENTION_DAYS (default 30)
+	retention := todo.DefaultTrashRetention
+	if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			log.Fatalf("TRASH_RETENTION_DAYS must be a positive number of days, got %q", v)
+		}
+		retention = time.Duration(days) * 24 * time.Hour
+	}
+
+	// Start server with graceful shutdown. The base context is cancelled on
+	// shutdown so open event streams end instead of holding it up.
+	baseCtx, cancelBase := context.WithCancel(context.Background())
 	srv := &http.Server{
 		Addr:         ":8080",
-		Handler:      r,
+		Handler:      newRouter(container, clientOrigin),
 		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		WriteTimeout: 10 * time.Second, // event streams lift it for themselves
 		IdleTimeout:  120 * time.Second,
+		BaseContext:  func(net.Listener) context.Context { return baseCtx },
 	}
+	srv.RegisterOnShutdown(cancelBase)
+
+	go purgeTrashPeriodically(baseCtx, container.PurgeTrash, container.SweepAttachments, retention)
+	go dispatchRemindersPeriodically(baseCtx, container.DispatchReminders)
 
 	go func() {
 		log.Printf("server listening on %s", srv.Addr)
@@ -119,3 +83,50 @@ func main() {
 
 	log.Println("server exited cleanly")
 }
+
+// purgeTrashPeriodically empties expired trash at startup and then hourly,
+// until ctx ends. Each round then removes the files of every todo deleted for
+// good since the last, whether purged here or with its workspace.
+func purgeTrashPeriodically(ctx context.Context, purge todo.PurgeTrashUseCase, sweep attachment.SweepUseCase, retention time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		if n, err := purge.Execute(ctx, retention); err != nil {
+			log.Printf("trash purge failed: %v", err)
+		} else if n > 0 {
+			log.Printf("purged %d todos from the trash", n)
+		}
+		if n, err := sweep.Execute(ctx); err != nil {
+			log.Printf("attachment sweep failed: %v", err)
+		} else if n > 0 {
+			log.Printf("removed %d attachments of deleted todos", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reminderInterval is how often due reminders are looked for; a reminder goes
+// out at most this long after its time.
+const reminderInterval = 30 * time.Second
+
+// dispatchRemindersPeriodically delivers due reminders every reminderInterval, until ctx ends.
+func dispatchRemindersPeriodically(ctx context.Context, dispatch reminder.DispatchUseCase) {
+	ticker := time.NewTicker(reminderInterval)
+	defer ticker.Stop()
+	for {
+		if n, err := dispatch.Execute(ctx, time.Now()); err != nil {
+			log.Printf("reminder dispatch failed: %v", err)
+		} else if n > 0 {
+			log.Printf("dispatched %d reminders", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
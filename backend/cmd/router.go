@@ -0,0 +1,168 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+
+	"github.com/ariangn/todo-fullstack/backend/di"
+	custommw "github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// newRouter builds the HTTP handler for every /api route on top of the container.
+// It does no I/O of its own, so tests can call it with a container over fake repositories.
+func newRouter(container *di.Container, clientOrigin string) http.Handler {
+	// Set up router with common middleware
+	r := chi.NewRouter()
+	r.Use(middleware.Logger)    // logs every request
+	r.Use(middleware.Recoverer) // prevents panics from crashing server
+	r.Use(cors.Handler(cors.Options{
+		AllowedOrigins:   []string{clientOrigin},
+		AllowCredentials: true,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match", "If-None-Match", "Last-Event-ID"},
+		ExposedHeaders:   []string{"ETag"},
+	}))
+
+	// Define /api routes
+	r.Route("/api", func(r chi.Router) {
+		// Public routes
+		r.Post("/users/register", container.UserController.Register)
+		r.Post("/users/login", container.UserController.Login)
+		r.Post("/users/logout", container.UserController.Logout)
+		// the access token may already have expired when refreshing
+		r.Post("/auth/refresh", container.SessionController.Refresh)
+		// calendar apps cannot sign in; the secret token in the URL authorizes the feed
+		r.Get("/calendar/{token}.ics", container.CalendarController.Feed)
+		// avatars are shown in <img> tags; the version in the path authorizes them
+		r.Get("/users/{id}/avatar/{version}", container.UserController.Avatar)
+		// a local blob store serves its files here; the signature in the URL authorizes them
+		if container.BlobServer != nil {
+			r.Get("/blobs/*", container.BlobServer.ServeHTTP)
+		}
+
+		// Protected routes
+		r.Group(func(r chi.Router) {
+			r.Use(custommw.AuthMiddleware(container.AuthClient, container.Sessions))
+
+			// Auth info
+			r.Get("/auth/me", container.UserController.Me)
+			r.Put("/users/me/avatar", container.UserController.SetAvatar)
+
+			// Sessions (one per signed-in device)
+			r.Get("/auth/sessions", container.SessionController.List)
+			r.Delete("/auth/sessions", container.SessionController.RevokeAll)
+			r.Delete("/auth/sessions/{id}", container.SessionController.Revoke)
+
+			// Live change feed (Server-Sent Events)
+			r.Get("/events", container.EventController.Stream)
+
+			// Todos
+			r.Route("/todos", func(r chi.Router) {
+				r.Post("/", container.TodoController.Create)
+				r.Get("/", container.TodoController.List)
+				r.Get("/search", container.TodoController.Search)
+				r.Get("/trash", container.TodoController.Trash)
+				r.Post("/bulk", container.TodoController.Bulk)
+				r.Get("/{id}", container.TodoController.GetByID)
+				r.Put("/{id}", container.TodoController.Update)
+				r.Patch("/{id}/status", container.TodoController.ToggleStatus)
+				r.Patch("/{id}/move", container.TodoController.Move)
+				r.Delete("/{id}", container.TodoController.Delete)
+				r.Post("/{id}/duplicate", container.TodoController.Duplicate)
+				r.Post("/{id}/restore", container.TodoController.Restore)
+				r.Get("/{id}/history", container.TodoController.History)
+				r.Post("/{id}/assignees", container.TodoController.Assign)
+				r.Delete("/{id}/assignees/{userId}", container.TodoController.Unassign)
+
+				// Comments
+				r.Route("/{id}/comments", func(r chi.Router) {
+					r.Get("/", container.CommentController.List)
+					r.Post("/", container.CommentController.Create)
+					r.Patch("/{commentId}", container.CommentController.Update)
+					r.Delete("/{commentId}", container.CommentController.Delete)
+				})
+
+				// Attachments
+				r.Route("/{id}/attachments", func(r chi.Router) {
+					r.Get("/", container.AttachmentController.List)
+					r.Post("/", container.AttachmentController.Upload)
+					r.Get("/{attachmentId}/download", container.AttachmentController.Download)
+					r.Delete("/{attachmentId}", container.AttachmentController.Delete)
+				})
+
+				// Checklist items
+				r.Route("/{id}/items", func(r chi.Router) {
+					r.Get("/", container.ChecklistController.List)
+					r.Post("/", container.ChecklistController.Create)
+					r.Put("/order", container.ChecklistController.Reorder)
+					r.Patch("/{itemId}", container.ChecklistController.Update)
+					r.Delete("/{itemId}", container.ChecklistController.Delete)
+				})
+
+				// Reminders
+				r.Route("/{id}/reminders", func(r chi.Router) {
+					r.Get("/", container.ReminderController.List)
+					r.Post("/", container.ReminderController.Create)
+					r.Delete("/{reminderId}", container.ReminderController.Delete)
+				})
+			})
+
+			// Audit log of every todo, category and tag change
+			r.Get("/activity", container.ActivityController.List)
+
+			// In-app reminder inbox
+			r.Get("/notifications", container.NotificationController.List)
+			r.Post("/notifications/{id}/read", container.NotificationController.MarkRead)
+
+			// Backup and migration
+			r.Get("/export", container.TransferController.Export)
+			r.Post("/import", container.TransferController.Import)
+
+			// Calendar feed token
+			r.Get("/calendar/feed", container.CalendarController.Get)
+			r.Post("/calendar/feed", container.CalendarController.Rotate)
+			r.Delete("/calendar/feed", container.CalendarController.Revoke)
+
+			// Categories
+			r.Route("/categories", func(r chi.Router) {
+				r.Post("/", container.CategoryController.Create)
+				r.Get("/", container.CategoryController.List)
+				r.Put("/{id}", container.CategoryController.Update)
+				r.Delete("/{id}", container.CategoryController.Delete)
+			})
+
+			// Tags
+			r.Route("/tags", func(r chi.Router) {
+				r.Post("/", container.TagController.Create)
+				r.Get("/", container.TagController.List)
+				r.Put("/{id}", container.TagController.Update)
+				r.Delete("/{id}", container.TagController.Delete)
+			})
+
+			// Workspaces
+			r.Route("/workspaces", func(r chi.Router) {
+				r.Post("/", container.WorkspaceController.Create)
+				r.Get("/", container.WorkspaceController.List)
+				r.Get("/{id}", container.WorkspaceController.GetByID)
+				r.Patch("/{id}", container.WorkspaceController.Update)
+				r.Delete("/{id}", container.WorkspaceController.Delete)
+				r.Post("/{id}/invitations", container.WorkspaceController.Invite)
+				r.Get("/{id}/invitations", container.WorkspaceController.Invitations)
+				r.Patch("/{id}/members/{userId}", container.WorkspaceController.UpdateMember)
+				r.Delete("/{id}/members/{userId}", container.WorkspaceController.RemoveMember)
+			})
+
+			// Invitations the caller received
+			r.Route("/invitations", func(r chi.Router) {
+				r.Get("/", container.WorkspaceController.Received)
+				r.Post("/{id}/accept", container.WorkspaceController.Accept)
+				r.Delete("/{id}", container.WorkspaceController.DeleteInvitation)
+			})
+		})
+	})
+
+	return r
+}
@@ -0,0 +1,2686 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/di"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/notify"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/blobstore"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/memory"
+)
+
+const testOrigin = "http://localhost:5173"
+
+// newTestRouter builds the real router over in-memory repositories.
+func newTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+	return newRouter(newTestContainer(t, nil), testOrigin)
+}
+
+// newTestContainer wires in-memory repositories and a blob store in a
+// temporary directory, with notifiers as the external reminder channels.
+func newTestContainer(t *testing.T, notifiers map[entity.ReminderChannel]notify.Notifier) *di.Container {
+	t.Helper()
+	store := memory.NewStore()
+	blobs, err := blobstore.NewFileSystem(t.TempDir(), di.BlobURLPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return di.NewContainer(
+		auth.NewAuthClientWithSecret("test-secret"),
+		di.Repositories{
+			User:                memory.NewUserRepository(store),
+			Todo:                memory.NewTodoRepository(store),
+			Category:            memory.NewCategoryRepository(store),
+			Tag:                 memory.NewTagRepository(store),
+			ChecklistItem:       memory.NewChecklistItemRepository(store),
+			Session:             memory.NewSessionRepository(store),
+			Activity:            memory.NewActivityRepository(store),
+			Reminder:            memory.NewReminderRepository(store),
+			Notification:        memory.NewNotificationRepository(store),
+			CalendarFeed:        memory.NewCalendarFeedRepository(store),
+			Workspace:           memory.NewWorkspaceRepository(store),
+			WorkspaceInvitation: memory.NewWorkspaceInvitationRepository(store),
+			Comment:             memory.NewCommentRepository(store),
+			Attachment:          memory.NewAttachmentRepository(store),
+			Blobs:               blobs,
+		},
+		notifiers,
+		"",
+	)
+}
+
+// apiClient sends requests straight into the router, replaying the session cookies.
+type apiClient struct {
+	t       *testing.T
+	h       http.Handler
+	cookie  *http.Cookie
+	refresh *http.Cookie
+}
+
+func (c *apiClient) do(method, path string, body any) *httptest.ResponseRecorder {
+	c.t.Helper()
+	return c.doWith(method, path, body, nil)
+}
+
+// doWith is do with extra request headers, such as If-Match.
+func (c *apiClient) doWith(method, path string, body any, header http.Header) *httptest.ResponseRecorder {
+	c.t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			c.t.Fatalf("encode body: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if c.cookie != nil {
+		req.AddCookie(c.cookie)
+	}
+	if c.refresh != nil {
+		req.AddCookie(c.refresh)
+	}
+	rec := httptest.NewRecorder()
+	c.h.ServeHTTP(rec, req)
+	return rec
+}
+
+// expect fails the test unless rec has the wanted status, then decodes the JSON body into out.
+func (c *apiClient) expect(rec *httptest.ResponseRecorder, want int, out any) {
+	c.t.Helper()
+	if rec.Code != want {
+		c.t.Fatalf("status = %d, want %d; body: %s", rec.Code, want, rec.Body.String())
+	}
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			c.t.Fatalf("decode %q: %v", rec.Body.String(), err)
+		}
+	}
+}
+
+// keepCookies replays the token cookies rec sets on later requests, like a browser would.
+func (c *apiClient) keepCookies(rec *httptest.ResponseRecorder) {
+	for _, ck := range rec.Result().Cookies() {
+		switch ck.Name {
+		case "token":
+			c.cookie = ck
+		case "refresh_token":
+			c.refresh = ck
+		}
+	}
+}
+
+// signUp registers and logs in a fresh user, returning a client that carries the token cookies.
+func signUp(t *testing.T, h http.Handler, email string) *apiClient {
+	t.Helper()
+	c := &apiClient{t: t, h: h}
+
+	c.expect(c.do("POST", "/api/users/register", map[string]string{
+		"email":    email,
+		"password": "secret123",
+		"timezone": "Asia/Tokyo",
+	}), http.StatusCreated, nil)
+
+	rec := c.do("POST", "/api/users/login", map[string]string{
+		"email":    email,
+		"password": "secret123",
+	})
+	c.expect(rec, http.StatusOK, nil)
+	c.keepCookies(rec)
+	if c.cookie == nil || c.cookie.Value == "" || c.refresh == nil || c.refresh.Value == "" {
+		t.Fatal("login did not set the token cookies")
+	}
+	return c
+}
+
+type todoResp struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	CompletedAt *string  `json:"completedAt"`
+	CategoryID  *string  `json:"categoryId"`
+	TagIDs      []string `json:"tagIds"`
+}
+
+type todoListResp struct {
+	Items      []todoResp `json:"items"`
+	Total      int        `json:"total"`
+	NextCursor *string    `json:"nextCursor"`
+}
+
+type namedResp struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestAuthFlow(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "alice@example.com")
+
+	var me struct {
+		Email    string `json:"email"`
+		Timezone string `json:"timezone"`
+	}
+	c.expect(c.do("GET", "/api/auth/me", nil), http.StatusOK, &me)
+	if me.Email != "alice@example.com" || me.Timezone != "Asia/Tokyo" {
+		t.Fatalf("unexpected /auth/me payload: %+v", me)
+	}
+
+	// duplicate registration and bad password are rejected
+	c.expect(c.do("POST", "/api/users/register", map[string]string{
+		"email": "alice@example.com", "password": "secret123", "timezone": "UTC",
+	}), http.StatusConflict, nil)
+	c.expect(c.do("POST", "/api/users/login", map[string]string{
+		"email": "alice@example.com", "password": "wrong-password",
+	}), http.StatusUnauthorized, nil)
+
+	oldToken := c.cookie
+	rec := c.do("POST", "/api/users/logout", nil)
+	c.expect(rec, http.StatusOK, nil)
+	var cleared bool
+	for _, ck := range rec.Result().Cookies() {
+		if ck.Name == "token" && ck.MaxAge < 0 {
+			cleared = true
+		}
+	}
+	if !cleared {
+		t.Fatal("logout did not expire the token cookie")
+	}
+
+	// logout revokes the session, so a copied access token stops working
+	stolen := &apiClient{t: t, h: h, cookie: oldToken}
+	stolen.expect(stolen.do("GET", "/api/auth/me", nil), http.StatusUnauthorized, nil)
+
+	anon := &apiClient{t: t, h: h}
+	anon.expect(anon.do("GET", "/api/auth/me", nil), http.StatusUnauthorized, nil)
+	anon.cookie = &http.Cookie{Name: "token", Value: "not-a-jwt"}
+	anon.expect(anon.do("GET", "/api/todos", nil), http.StatusUnauthorized, nil)
+}
+
+func TestCategoryAndTagCRUD(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "bob@example.com")
+
+	var cat namedResp
+	c.expect(c.do("POST", "/api/categories", map[string]string{
+		"name": "Work", "color": "#ff0000",
+	}), http.StatusCreated, &cat)
+
+	var cats []namedResp
+	c.expect(c.do("GET", "/api/categories", nil), http.StatusOK, &cats)
+	if len(cats) != 1 || cats[0].ID != cat.ID {
+		t.Fatalf("categories = %+v, want just %s", cats, cat.ID)
+	}
+
+	var renamed namedResp
+	c.expect(c.do("PUT", "/api/categories/"+cat.ID, map[string]string{
+		"name": "Office", "color": "#00ff00",
+	}), http.StatusOK, &renamed)
+	if renamed.Name != "Office" {
+		t.Fatalf("category name = %q, want Office", renamed.Name)
+	}
+
+	c.expect(c.do("DELETE", "/api/categories/"+cat.ID, nil), http.StatusNoContent, nil)
+	cats = nil
+	c.expect(c.do("GET", "/api/categories", nil), http.StatusOK, &cats)
+	if len(cats) != 0 {
+		t.Fatalf("categories after delete = %+v", cats)
+	}
+
+	var tag namedResp
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "urgent"}), http.StatusCreated, &tag)
+	var tags []namedResp
+	c.expect(c.do("GET", "/api/tags", nil), http.StatusOK, &tags)
+	if len(tags) != 1 || tags[0].Name != "urgent" {
+		t.Fatalf("tags = %+v", tags)
+	}
+
+	var retagged namedResp
+	c.expect(c.do("PUT", "/api/tags/"+tag.ID, map[string]string{"name": "asap"}), http.StatusOK, &retagged)
+	if retagged.Name != "asap" {
+		t.Fatalf("tag name = %q, want asap", retagged.Name)
+	}
+	c.expect(c.do("PUT", "/api/tags/"+tag.ID, map[string]string{"name": ""}), http.StatusBadRequest, nil)
+
+	c.expect(c.do("DELETE", "/api/tags/"+tag.ID, nil), http.StatusNoContent, nil)
+	c.expect(c.do("DELETE", "/api/tags/"+tag.ID, nil), http.StatusNotFound, nil)
+	c.expect(c.do("PUT", "/api/categories/"+cat.ID, map[string]string{"name": "Gone"}), http.StatusNotFound, nil)
+}
+
+func TestCategoryAndTagOwnership(t *testing.T) {
+	h := newTestRouter(t)
+	alice := signUp(t, h, "alice@example.com")
+	mallory := signUp(t, h, "mallory@example.com")
+
+	var cat, tag namedResp
+	alice.expect(alice.do("POST", "/api/categories", map[string]string{"name": "Mine", "color": "#000000"}), http.StatusCreated, &cat)
+	alice.expect(alice.do("POST", "/api/tags", map[string]string{"name": "mine"}), http.StatusCreated, &tag)
+
+	mallory.expect(mallory.do("PUT", "/api/categories/"+cat.ID, map[string]string{"name": "Stolen", "color": "#ffffff"}), http.StatusForbidden, nil)
+	mallory.expect(mallory.do("DELETE", "/api/categories/"+cat.ID, nil), http.StatusForbidden, nil)
+	mallory.expect(mallory.do("PUT", "/api/tags/"+tag.ID, map[string]string{"name": "stolen"}), http.StatusForbidden, nil)
+	mallory.expect(mallory.do("DELETE", "/api/tags/"+tag.ID, nil), http.StatusForbidden, nil)
+
+	var cats []namedResp
+	alice.expect(alice.do("GET", "/api/categories", nil), http.StatusOK, &cats)
+	var tags []namedResp
+	alice.expect(alice.do("GET", "/api/tags", nil), http.StatusOK, &tags)
+	if len(cats) != 1 || cats[0].Name != "Mine" || len(tags) != 1 || tags[0].Name != "mine" {
+		t.Fatalf("alice's data was modified: categories=%+v tags=%+v", cats, tags)
+	}
+}
+
+func TestTodoReferenceOwnership(t *testing.T) {
+	h := newTestRouter(t)
+	alice := signUp(t, h, "alice@example.com")
+	mallory := signUp(t, h, "mallory@example.com")
+
+	var aliceCat, aliceTag, ownTag namedResp
+	alice.expect(alice.do("POST", "/api/categories", map[string]string{"name": "Mine", "color": "#000000"}), http.StatusCreated, &aliceCat)
+	alice.expect(alice.do("POST", "/api/tags", map[string]string{"name": "mine"}), http.StatusCreated, &aliceTag)
+	mallory.expect(mallory.do("POST", "/api/tags", map[string]string{"name": "own"}), http.StatusCreated, &ownTag)
+
+	// foreign and unknown references are both listed, and nothing is created
+	var e errorResp
+	mallory.expect(mallory.do("POST", "/api/todos", map[string]any{
+		"title":      "Borrowed",
+		"categoryId": aliceCat.ID,
+		"tagIds":     []string{ownTag.ID, aliceTag.ID, "no-such-tag"},
+	}), http.StatusBadRequest, &e)
+	if e.Error.Code != "validation" ||
+		len(e.Error.IDs["categoryId"]) != 1 || e.Error.IDs["categoryId"][0] != aliceCat.ID ||
+		len(e.Error.IDs["tagIds"]) != 2 || e.Error.IDs["tagIds"][0] != aliceTag.ID || e.Error.IDs["tagIds"][1] != "no-such-tag" {
+		t.Fatalf("create error = %+v", e.Error)
+	}
+	var list todoListResp
+	mallory.expect(mallory.do("GET", "/api/todos", nil), http.StatusOK, &list)
+	if list.Total != 0 {
+		t.Fatalf("todos after rejected create = %+v", list)
+	}
+
+	var own todoResp
+	mallory.expect(mallory.do("POST", "/api/todos", map[string]any{
+		"title": "Own", "tagIds": []string{ownTag.ID},
+	}), http.StatusCreated, &own)
+
+	e = errorResp{}
+	mallory.expect(mallory.do("PUT", "/api/todos/"+own.ID, map[string]any{
+		"tagIds": []string{ownTag.ID, aliceTag.ID},
+	}), http.StatusBadRequest, &e)
+	if ids := e.Error.IDs["tagIds"]; len(ids) != 1 || ids[0] != aliceTag.ID {
+		t.Fatalf("update error = %+v", e.Error)
+	}
+	mallory.expect(mallory.do("PUT", "/api/todos/"+own.ID, map[string]any{
+		"categoryId": aliceCat.ID,
+	}), http.StatusBadRequest, nil)
+
+	e = errorResp{}
+	mallory.expect(mallory.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": []string{own.ID}, "action": "add_tags", "tagIds": []string{aliceTag.ID},
+	}), http.StatusBadRequest, &e)
+	if ids := e.Error.IDs["tagIds"]; len(ids) != 1 || ids[0] != aliceTag.ID {
+		t.Fatalf("bulk error = %+v", e.Error)
+	}
+	mallory.expect(mallory.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": []string{own.ID}, "action": "set_category", "categoryId": aliceCat.ID,
+	}), http.StatusBadRequest, nil)
+
+	var got todoResp
+	mallory.expect(mallory.do("GET", "/api/todos/"+own.ID, nil), http.StatusOK, &got)
+	if got.CategoryID != nil || len(got.TagIDs) != 1 || got.TagIDs[0] != ownTag.ID {
+		t.Fatalf("todo after rejected updates = %+v", got)
+	}
+	mallory.expect(mallory.do("POST", "/api/todos/"+own.ID+"/duplicate", nil), http.StatusOK, nil)
+}
+
+func TestTodoCRUD(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "carol@example.com")
+
+	var cat, tag namedResp
+	c.expect(c.do("POST", "/api/categories", map[string]string{"name": "Home", "color": "#123456"}), http.StatusCreated, &cat)
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "errand"}), http.StatusCreated, &tag)
+
+	var created todoResp
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":      "Buy milk",
+		"status":     "TODO",
+		"categoryId": cat.ID,
+		"tagIds":     []string{tag.ID},
+	}), http.StatusCreated, &created)
+
+	var got todoResp
+	c.expect(c.do("GET", "/api/todos/"+created.ID, nil), http.StatusOK, &got)
+	if got.Title != "Buy milk" || len(got.TagIDs) != 1 || got.TagIDs[0] != tag.ID {
+		t.Fatalf("fetched todo = %+v", got)
+	}
+
+	var updated todoResp
+	c.expect(c.do("PUT", "/api/todos/"+created.ID, map[string]any{
+		"title":  "Buy oat milk",
+		"tagIds": []string{},
+	}), http.StatusOK, &updated)
+	if updated.Title != "Buy oat milk" || len(updated.TagIDs) != 0 {
+		t.Fatalf("updated todo = %+v", updated)
+	}
+
+	var toggled todoResp
+	c.expect(c.do("PATCH", "/api/todos/"+created.ID+"/status", map[string]string{
+		"status": "COMPLETED",
+	}), http.StatusOK, &toggled)
+	if toggled.Status != "COMPLETED" || toggled.CompletedAt == nil {
+		t.Fatalf("toggled todo = %+v", toggled)
+	}
+
+	var dup todoResp
+	c.expect(c.do("POST", "/api/todos/"+created.ID+"/duplicate", nil), http.StatusOK, &dup)
+	if dup.ID == created.ID || dup.Title != "Buy oat milk (Copy)" || dup.Status != "TODO" {
+		t.Fatalf("duplicate = %+v", dup)
+	}
+
+	var list todoListResp
+	c.expect(c.do("GET", "/api/todos?status=COMPLETED", nil), http.StatusOK, &list)
+	if list.Total != 1 || list.Items[0].ID != created.ID {
+		t.Fatalf("completed todos = %+v", list)
+	}
+
+	c.expect(c.do("DELETE", "/api/todos/"+created.ID, nil), http.StatusNoContent, nil)
+	list = todoListResp{}
+	c.expect(c.do("GET", "/api/todos", nil), http.StatusOK, &list)
+	if list.Total != 1 || list.Items[0].ID != dup.ID {
+		t.Fatalf("todos after delete = %+v", list)
+	}
+}
+
+func TestTodoListPagination(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "dave@example.com")
+
+	for _, title := range []string{"c", "a", "e", "b", "d"} {
+		c.expect(c.do("POST", "/api/todos", map[string]string{"title": title, "status": "TODO"}), http.StatusCreated, nil)
+	}
+
+	var titles []string
+	path := "/api/todos?sortBy=title&limit=2"
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("pagination did not terminate")
+		}
+		var page todoListResp
+		c.expect(c.do("GET", path, nil), http.StatusOK, &page)
+		if page.Total != 5 {
+			t.Fatalf("total = %d, want 5", page.Total)
+		}
+		for _, it := range page.Items {
+			titles = append(titles, it.Title)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		path = "/api/todos?sortBy=title&limit=2&cursor=" + *page.NextCursor
+	}
+	if got := len(titles); got != 5 || titles[0] != "a" || titles[4] != "e" {
+		t.Fatalf("titles = %v", titles)
+	}
+
+	c.expect(c.do("GET", "/api/todos?sortBy=color", nil), http.StatusBadRequest, nil)
+	c.expect(c.do("GET", "/api/todos?limit=1000", nil), http.StatusBadRequest, nil)
+	c.expect(c.do("GET", "/api/todos?cursor=%21%21", nil), http.StatusBadRequest, nil)
+}
+
+func TestTodoPriorityAndMove(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "pia@example.com")
+	mallory := signUp(t, h, "mallory@example.com")
+
+	type rankedTodo struct {
+		ID          string  `json:"id"`
+		Title       string  `json:"title"`
+		Status      string  `json:"status"`
+		Priority    string  `json:"priority"`
+		Rank        string  `json:"rank"`
+		CompletedAt *string `json:"completedAt"`
+	}
+	create := func(title, status, priority string) rankedTodo {
+		t.Helper()
+		var created rankedTodo
+		c.expect(c.do("POST", "/api/todos", map[string]string{"title": title, "status": status, "priority": priority}), http.StatusCreated, &created)
+		return created
+	}
+	titles := func(path string) []string {
+		t.Helper()
+		var page struct {
+			Items []rankedTodo `json:"items"`
+		}
+		c.expect(c.do("GET", path, nil), http.StatusOK, &page)
+		titles := []string{}
+		for _, it := range page.Items {
+			titles = append(titles, it.Title)
+		}
+		return titles
+	}
+	move := func(id string, body map[string]string) rankedTodo {
+		t.Helper()
+		var moved rankedTodo
+		c.expect(c.do("PATCH", "/api/todos/"+id+"/move", body), http.StatusOK, &moved)
+		return moved
+	}
+	column := func(status string) []string {
+		t.Helper()
+		return titles("/api/todos?status=" + status + "&sortBy=rank")
+	}
+
+	a := create("a", "TODO", "HIGH")
+	b := create("b", "TODO", "")
+	cc := create("c", "TODO", "URGENT")
+	if b.Priority != "NONE" || a.Rank == "" || !(a.Rank < b.Rank && b.Rank < cc.Rank) {
+		t.Fatalf("new todos = %+v %+v %+v, want NONE by default and ranks in creation order", a, b, cc)
+	}
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "x", "priority": "CRITICAL"}), http.StatusBadRequest, nil)
+
+	if got := titles("/api/todos?sortBy=priority&order=desc"); !reflect.DeepEqual(got, []string{"c", "a", "b"}) {
+		t.Fatalf("by priority = %v", got)
+	}
+
+	// reordering within a column only rewrites the moved todo's rank
+	move(cc.ID, map[string]string{"beforeId": a.ID})
+	if got := column("TODO"); !reflect.DeepEqual(got, []string{"c", "a", "b"}) {
+		t.Fatalf("after moving c to the top = %v", got)
+	}
+	move(b.ID, map[string]string{"afterId": cc.ID})
+	if got := column("TODO"); !reflect.DeepEqual(got, []string{"c", "b", "a"}) {
+		t.Fatalf("after moving b below c = %v", got)
+	}
+
+	// moving to another column changes the status in the same call
+	d := create("d", "IN_PROGRESS", "LOW")
+	moved := move(a.ID, map[string]string{"status": "IN_PROGRESS", "beforeId": d.ID})
+	if moved.Status != "IN_PROGRESS" || moved.Priority != "HIGH" {
+		t.Fatalf("moved = %+v", moved)
+	}
+	if got, rest := column("IN_PROGRESS"), column("TODO"); !reflect.DeepEqual(got, []string{"a", "d"}) || !reflect.DeepEqual(rest, []string{"c", "b"}) {
+		t.Fatalf("columns after the move = %v and %v", got, rest)
+	}
+	done := move(b.ID, map[string]string{"status": "COMPLETED"})
+	if done.Status != "COMPLETED" || done.CompletedAt == nil {
+		t.Fatalf("completed by moving = %+v", done)
+	}
+
+	// the anchor must be another todo in the target column
+	c.expect(c.do("PATCH", "/api/todos/"+cc.ID+"/move", map[string]string{"beforeId": a.ID, "afterId": d.ID}), http.StatusBadRequest, nil)
+	var bad errorResp
+	c.expect(c.do("PATCH", "/api/todos/"+cc.ID+"/move", map[string]string{"beforeId": a.ID}), http.StatusBadRequest, &bad)
+	if bad.Error.Fields["beforeId"] == "" {
+		t.Fatalf("error = %+v, want it on beforeId", bad.Error)
+	}
+	c.expect(c.do("PATCH", "/api/todos/"+cc.ID+"/move", map[string]string{"afterId": cc.ID}), http.StatusBadRequest, nil)
+	c.expect(c.do("PATCH", "/api/todos/"+cc.ID+"/move", map[string]string{"status": "DONE"}), http.StatusBadRequest, nil)
+	mallory.expect(mallory.do("PATCH", "/api/todos/"+cc.ID+"/move", map[string]string{}), http.StatusNotFound, nil)
+	stale := c.doWith("PATCH", "/api/todos/"+cc.ID+"/move", map[string]string{}, http.Header{"If-Match": {`"1"`}})
+	c.expect(stale, http.StatusPreconditionFailed, nil)
+
+	var updated rankedTodo
+	c.expect(c.do("PUT", "/api/todos/"+cc.ID, map[string]string{"priority": "LOW"}), http.StatusOK, &updated)
+	if updated.Priority != "LOW" || updated.Rank == "" {
+		t.Fatalf("updated = %+v", updated)
+	}
+	c.expect(c.do("PUT", "/api/todos/"+cc.ID, map[string]string{"priority": "low"}), http.StatusBadRequest, nil)
+
+	// a bulk status change appends to the new column, in request order
+	e := create("e", "TODO", "")
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": []string{e.ID, cc.ID}, "action": "set_status", "status": "IN_PROGRESS",
+	}), http.StatusOK, nil)
+	if got := column("IN_PROGRESS"); !reflect.DeepEqual(got, []string{"a", "d", "e", "c"}) {
+		t.Fatalf("after the bulk status change = %v", got)
+	}
+}
+
+func TestTodoOwnership(t *testing.T) {
+	h := newTestRouter(t)
+	alice := signUp(t, h, "alice@example.com")
+	mallory := signUp(t, h, "mallory@example.com")
+
+	var todo todoResp
+	alice.expect(alice.do("POST", "/api/todos", map[string]string{
+		"title": "private", "status": "TODO",
+	}), http.StatusCreated, &todo)
+
+	mallory.expect(mallory.do("GET", "/api/todos/"+todo.ID, nil), http.StatusNotFound, nil)
+	mallory.expect(mallory.do("PUT", "/api/todos/"+todo.ID, map[string]string{"title": "pwned"}), http.StatusNotFound, nil)
+	mallory.expect(mallory.do("PATCH", "/api/todos/"+todo.ID+"/status", map[string]string{"status": "COMPLETED"}), http.StatusNotFound, nil)
+	mallory.expect(mallory.do("POST", "/api/todos/"+todo.ID+"/duplicate", nil), http.StatusNotFound, nil)
+	mallory.expect(mallory.do("DELETE", "/api/todos/"+todo.ID, nil), http.StatusNotFound, nil)
+
+	var list todoListResp
+	mallory.expect(mallory.do("GET", "/api/todos", nil), http.StatusOK, &list)
+	if list.Total != 0 {
+		t.Fatalf("mallory sees %d todos", list.Total)
+	}
+
+	var got todoResp
+	alice.expect(alice.do("GET", "/api/todos/"+todo.ID, nil), http.StatusOK, &got)
+	if got.Title != "private" || got.Status != "TODO" {
+		t.Fatalf("alice's todo was modified: %+v", got)
+	}
+}
+
+type errorResp struct {
+	Error struct {
+		Code    string              `json:"code"`
+		Message string              `json:"message"`
+		Fields  map[string]string   `json:"fields"`
+		IDs     map[string][]string `json:"ids"`
+	} `json:"error"`
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "erin@example.com")
+
+	var e errorResp
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "", "status": "TODO"}), http.StatusBadRequest, &e)
+	if e.Error.Code != "validation" || e.Error.Fields["title"] == "" {
+		t.Fatalf("empty title error = %+v", e)
+	}
+
+	e = errorResp{}
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "x", "status": "DONE"}), http.StatusBadRequest, &e)
+	if e.Error.Fields["status"] == "" {
+		t.Fatalf("bad status error = %+v", e)
+	}
+
+	e = errorResp{}
+	c.expect(c.do("POST", "/api/users/register", map[string]string{
+		"email": "not-an-email", "password": "123", "timezone": "UTC",
+	}), http.StatusBadRequest, &e)
+	if e.Error.Fields["email"] == "" {
+		t.Fatalf("bad email error = %+v", e)
+	}
+
+	for _, path := range []string{
+		"/api/todos/00000000-0000-0000-0000-000000000000",
+		"/api/todos/not-a-uuid",
+	} {
+		e = errorResp{}
+		c.expect(c.do("GET", path, nil), http.StatusNotFound, &e)
+		if e.Error.Code != "not_found" || e.Error.Message != "todo not found" {
+			t.Fatalf("GET %s error = %+v", path, e)
+		}
+	}
+
+	e = errorResp{}
+	c.expect(c.do("POST", "/api/users/register", map[string]string{
+		"email": "erin@example.com", "password": "secret123", "timezone": "UTC",
+	}), http.StatusConflict, &e)
+	if e.Error.Code != "conflict" {
+		t.Fatalf("duplicate email error = %+v", e)
+	}
+
+	anon := &apiClient{t: t, h: h}
+	e = errorResp{}
+	anon.expect(anon.do("GET", "/api/todos", nil), http.StatusUnauthorized, &e)
+	if e.Error.Code != "unauthorized" {
+		t.Fatalf("anonymous error = %+v", e)
+	}
+}
+
+func TestRecurringTodo(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "rita@example.com")
+
+	type recurrence struct {
+		Frequency string   `json:"frequency"`
+		ByWeekday []string `json:"byWeekday"`
+		Count     *int     `json:"count"`
+	}
+	type recurringTodo struct {
+		ID             string      `json:"id"`
+		Status         string      `json:"status"`
+		DueDate        string      `json:"dueDate"`
+		Recurrence     *recurrence `json:"recurrence"`
+		NextOccurrence *struct {
+			ID         string      `json:"id"`
+			Status     string      `json:"status"`
+			DueDate    string      `json:"dueDate"`
+			Recurrence *recurrence `json:"recurrence"`
+		} `json:"nextOccurrence"`
+	}
+
+	var created recurringTodo
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":   "Gym",
+		"dueDate": "2026-11-02T00:00:00Z", // a Monday
+		"recurrence": map[string]any{
+			"frequency": "WEEKLY",
+			"byWeekday": []string{"MO", "TH"},
+			"count":     2,
+		},
+	}), http.StatusCreated, &created)
+	if created.Recurrence == nil || created.Recurrence.Frequency != "WEEKLY" {
+		t.Fatalf("created = %+v", created)
+	}
+
+	var done recurringTodo
+	c.expect(c.do("PATCH", "/api/todos/"+created.ID+"/status", map[string]string{
+		"status": "COMPLETED",
+	}), http.StatusOK, &done)
+	next := done.NextOccurrence
+	if done.Recurrence != nil || next == nil {
+		t.Fatalf("completed = %+v", done)
+	}
+	if next.Status != "TODO" || next.DueDate != "2026-11-05T00:00:00Z" ||
+		next.Recurrence == nil || next.Recurrence.Count == nil || *next.Recurrence.Count != 1 {
+		t.Fatalf("next occurrence = %+v", next)
+	}
+
+	// re-completing an already completed todo must not spawn another occurrence
+	done = recurringTodo{}
+	c.expect(c.do("PATCH", "/api/todos/"+created.ID+"/status", map[string]string{
+		"status": "COMPLETED",
+	}), http.StatusOK, &done)
+	if done.NextOccurrence != nil {
+		t.Fatalf("second completion spawned %+v", done.NextOccurrence)
+	}
+
+	// the last occurrence of a counted rule does not repeat
+	done = recurringTodo{}
+	c.expect(c.do("PUT", "/api/todos/"+next.ID, map[string]string{
+		"status": "COMPLETED",
+	}), http.StatusOK, &done)
+	if done.NextOccurrence != nil || done.Recurrence != nil {
+		t.Fatalf("last occurrence = %+v", done)
+	}
+
+	var list todoListResp
+	c.expect(c.do("GET", "/api/todos", nil), http.StatusOK, &list)
+	if list.Total != 2 {
+		t.Fatalf("todos = %+v", list)
+	}
+
+	var e errorResp
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":      "No date",
+		"recurrence": map[string]string{"frequency": "DAILY"},
+	}), http.StatusBadRequest, &e)
+	if e.Error.Fields["recurrence"] == "" {
+		t.Fatalf("recurrence without due date error = %+v", e)
+	}
+
+	e = errorResp{}
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":      "Bad rule",
+		"dueDate":    "2026-11-02T00:00:00Z",
+		"recurrence": map[string]any{"frequency": "DAILY", "byWeekday": []string{"MO"}},
+	}), http.StatusBadRequest, &e)
+	if e.Error.Fields["recurrence"] == "" {
+		t.Fatalf("byWeekday on DAILY error = %+v", e)
+	}
+
+	// null on update stops the todo from recurring
+	var cleared recurringTodo
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":      "Water plants",
+		"dueDate":    "2026-11-02T00:00:00Z",
+		"recurrence": map[string]string{"frequency": "DAILY"},
+	}), http.StatusCreated, &cleared)
+	var afterNull recurringTodo
+	c.expect(c.do("PUT", "/api/todos/"+cleared.ID, map[string]any{"recurrence": nil}), http.StatusOK, &afterNull)
+	if afterNull.ID != cleared.ID || afterNull.Recurrence != nil {
+		t.Fatalf("after null update = %+v", afterNull)
+	}
+}
+
+func TestChecklist(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "chris@example.com")
+
+	type item struct {
+		ID       string `json:"id"`
+		Title    string `json:"title"`
+		Done     bool   `json:"done"`
+		Position int    `json:"position"`
+	}
+	type progress struct {
+		Done  int `json:"done"`
+		Total int `json:"total"`
+	}
+	type checklistTodo struct {
+		ID       string   `json:"id"`
+		Status   string   `json:"status"`
+		Progress progress `json:"progress"`
+	}
+	type itemUpdate struct {
+		Item item          `json:"item"`
+		Todo checklistTodo `json:"todo"`
+	}
+
+	var parent checklistTodo
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":        "Pack",
+		"autoComplete": true,
+	}), http.StatusCreated, &parent)
+	base := "/api/todos/" + parent.ID + "/items"
+
+	var items []item
+	for _, title := range []string{"Passport", "Charger", "Socks"} {
+		var it item
+		c.expect(c.do("POST", base, map[string]string{"title": title}), http.StatusCreated, &it)
+		items = append(items, it)
+	}
+	if items[2].Position != 2 {
+		t.Fatalf("items = %+v", items)
+	}
+
+	var reordered []item
+	c.expect(c.do("PUT", base+"/order", map[string][]string{
+		"itemIds": {items[2].ID, items[0].ID, items[1].ID},
+	}), http.StatusOK, &reordered)
+	if len(reordered) != 3 || reordered[0].Title != "Socks" || reordered[0].Position != 0 {
+		t.Fatalf("reordered = %+v", reordered)
+	}
+	c.expect(c.do("PUT", base+"/order", map[string][]string{
+		"itemIds": {items[0].ID, items[0].ID, items[1].ID},
+	}), http.StatusBadRequest, nil)
+
+	var upd itemUpdate
+	c.expect(c.do("PATCH", base+"/"+items[0].ID, map[string]bool{"done": true}), http.StatusOK, &upd)
+	if !upd.Item.Done || upd.Todo.Progress != (progress{1, 3}) || upd.Todo.Status != "TODO" {
+		t.Fatalf("after first check = %+v", upd)
+	}
+
+	var got checklistTodo
+	c.expect(c.do("GET", "/api/todos/"+parent.ID, nil), http.StatusOK, &got)
+	if got.Progress != (progress{1, 3}) {
+		t.Fatalf("todo progress = %+v", got.Progress)
+	}
+
+	// deleting an open item and checking the last one completes the parent
+	c.expect(c.do("DELETE", base+"/"+items[1].ID, nil), http.StatusNoContent, nil)
+	upd = itemUpdate{}
+	c.expect(c.do("PATCH", base+"/"+items[2].ID, map[string]bool{"done": true}), http.StatusOK, &upd)
+	if upd.Todo.Status != "COMPLETED" || upd.Todo.Progress != (progress{2, 2}) {
+		t.Fatalf("after last check = %+v", upd)
+	}
+
+	// without autoComplete the parent is left alone
+	var manual checklistTodo
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "Manual"}), http.StatusCreated, &manual)
+	var only item
+	c.expect(c.do("POST", "/api/todos/"+manual.ID+"/items", map[string]string{"title": "Step"}), http.StatusCreated, &only)
+	upd = itemUpdate{}
+	c.expect(c.do("PATCH", "/api/todos/"+manual.ID+"/items/"+only.ID, map[string]bool{"done": true}), http.StatusOK, &upd)
+	if upd.Todo.Status != "TODO" || upd.Todo.Progress != (progress{1, 1}) {
+		t.Fatalf("manual parent = %+v", upd.Todo)
+	}
+
+	// items are scoped to their todo and its owner
+	c.expect(c.do("PATCH", "/api/todos/"+manual.ID+"/items/"+items[0].ID, map[string]bool{"done": false}), http.StatusNotFound, nil)
+	c.expect(c.do("POST", base, map[string]string{"title": ""}), http.StatusBadRequest, nil)
+	other := signUp(t, h, "mallory@example.com")
+	other.expect(other.do("GET", base, nil), http.StatusNotFound, nil)
+	other.expect(other.do("POST", base, map[string]string{"title": "Sneaky"}), http.StatusNotFound, nil)
+
+	var list []item
+	c.expect(c.do("GET", base, nil), http.StatusOK, &list)
+	if len(list) != 2 || list[0].ID != items[2].ID || list[1].ID != items[0].ID {
+		t.Fatalf("final list = %+v", list)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	h := newTestRouter(t)
+	laptop := signUp(t, h, "sam@example.com")
+
+	phone := &apiClient{t: t, h: h}
+	rec := phone.do("POST", "/api/users/login", map[string]string{
+		"email": "sam@example.com", "password": "secret123",
+	})
+	phone.expect(rec, http.StatusOK, nil)
+	phone.keepCookies(rec)
+
+	type sessionResp struct {
+		ID      string `json:"id"`
+		Current bool   `json:"current"`
+	}
+	var sessions []sessionResp
+	laptop.expect(laptop.do("GET", "/api/auth/sessions", nil), http.StatusOK, &sessions)
+	if len(sessions) != 2 || sessions[0].Current == sessions[1].Current {
+		t.Fatalf("sessions = %+v", sessions)
+	}
+	var phoneID string
+	for _, s := range sessions {
+		if !s.Current {
+			phoneID = s.ID
+		}
+	}
+
+	// refresh rotates both cookies; the old refresh token is single-use
+	oldRefresh := phone.refresh
+	rec = phone.do("POST", "/api/auth/refresh", nil)
+	phone.expect(rec, http.StatusOK, nil)
+	phone.keepCookies(rec)
+	if phone.refresh.Value == oldRefresh.Value {
+		t.Fatal("refresh did not rotate the refresh token")
+	}
+	phone.expect(phone.do("GET", "/api/auth/me", nil), http.StatusOK, nil)
+
+	// replaying the rotated token looks like theft and revokes the session
+	thief := &apiClient{t: t, h: h, refresh: oldRefresh}
+	thief.expect(thief.do("POST", "/api/auth/refresh", nil), http.StatusUnauthorized, nil)
+	phone.expect(phone.do("GET", "/api/auth/me", nil), http.StatusUnauthorized, nil)
+	phone.expect(phone.do("POST", "/api/auth/refresh", nil), http.StatusUnauthorized, nil)
+
+	sessions = nil
+	laptop.expect(laptop.do("GET", "/api/auth/sessions", nil), http.StatusOK, &sessions)
+	if len(sessions) != 1 || !sessions[0].Current {
+		t.Fatalf("sessions after reuse = %+v", sessions)
+	}
+	laptop.expect(laptop.do("DELETE", "/api/auth/sessions/"+phoneID, nil), http.StatusNoContent, nil)
+
+	// sessions of other users cannot be revoked
+	other := signUp(t, h, "eve@example.com")
+	other.expect(other.do("DELETE", "/api/auth/sessions/"+sessions[0].ID, nil), http.StatusNotFound, nil)
+
+	// revoking one session leaves the others signed in
+	tablet := signUp(t, h, "sam2@example.com")
+	second := &apiClient{t: t, h: h}
+	rec = second.do("POST", "/api/users/login", map[string]string{
+		"email": "sam2@example.com", "password": "secret123",
+	})
+	second.expect(rec, http.StatusOK, nil)
+	second.keepCookies(rec)
+	var tabletSessions []sessionResp
+	tablet.expect(tablet.do("GET", "/api/auth/sessions", nil), http.StatusOK, &tabletSessions)
+	for _, s := range tabletSessions {
+		if !s.Current {
+			tablet.expect(tablet.do("DELETE", "/api/auth/sessions/"+s.ID, nil), http.StatusNoContent, nil)
+		}
+	}
+	second.expect(second.do("GET", "/api/auth/me", nil), http.StatusUnauthorized, nil)
+	tablet.expect(tablet.do("GET", "/api/auth/me", nil), http.StatusOK, nil)
+
+	// revoking all signs out every device, including the caller
+	laptop.expect(laptop.do("DELETE", "/api/auth/sessions", nil), http.StatusNoContent, nil)
+	laptop.expect(laptop.do("GET", "/api/auth/me", nil), http.StatusUnauthorized, nil)
+	laptop.expect(laptop.do("POST", "/api/auth/refresh", nil), http.StatusUnauthorized, nil)
+	other.expect(other.do("GET", "/api/auth/me", nil), http.StatusOK, nil)
+}
+
+func TestTodoSearch(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "sue@example.com")
+
+	var tag namedResp
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "home"}), http.StatusCreated, &tag)
+
+	todos := []map[string]any{
+		{"title": "Buy milk", "body": "Semi-skimmed milk from the corner shop", "tagIds": []string{tag.ID}},
+		{"title": "Groceries", "body": "Eggs, bread and milk", "status": "COMPLETED"},
+		{"title": "Call the bank", "body": "Ask about the milkshake machine loan"},
+		{"title": "Water plants"},
+	}
+	for _, body := range todos {
+		c.expect(c.do("POST", "/api/todos", body), http.StatusCreated, nil)
+	}
+
+	type segment struct {
+		Text  string `json:"text"`
+		Match bool   `json:"match"`
+	}
+	type searchResp struct {
+		Items []struct {
+			Title        string    `json:"title"`
+			Rank         float64   `json:"rank"`
+			TitleSnippet []segment `json:"titleSnippet"`
+			BodySnippet  []segment `json:"bodySnippet"`
+		} `json:"items"`
+		Total      int     `json:"total"`
+		NextCursor *string `json:"nextCursor"`
+	}
+	titles := func(res searchResp) []string {
+		var out []string
+		for _, it := range res.Items {
+			out = append(out, it.Title)
+		}
+		return out
+	}
+
+	// whole words only; a title match outranks a body match
+	var res searchResp
+	c.expect(c.do("GET", "/api/todos/search?q=milk", nil), http.StatusOK, &res)
+	if got := titles(res); res.Total != 2 || got[0] != "Buy milk" || got[1] != "Groceries" {
+		t.Fatalf("q=milk = %v (total %d)", got, res.Total)
+	}
+	want := []segment{{Text: "Buy "}, {Text: "milk", Match: true}}
+	if s := res.Items[0].TitleSnippet; len(s) != 2 || s[0] != want[0] || s[1] != want[1] {
+		t.Fatalf("title snippet = %+v", s)
+	}
+
+	// prefix matching also finds "milkshake"
+	res = searchResp{}
+	c.expect(c.do("GET", "/api/todos/search?q=MILK*", nil), http.StatusOK, &res)
+	if res.Total != 3 {
+		t.Fatalf("q=MILK* = %v", titles(res))
+	}
+
+	// phrases need the words next to each other
+	res = searchResp{}
+	c.expect(c.do("GET", "/api/todos/search?q=%22corner+shop%22", nil), http.StatusOK, &res)
+	if got := titles(res); len(got) != 1 || got[0] != "Buy milk" {
+		t.Fatalf("phrase = %v", got)
+	}
+	var matched []string
+	for _, seg := range res.Items[0].BodySnippet {
+		if seg.Match {
+			matched = append(matched, seg.Text)
+		}
+	}
+	if len(matched) != 2 || matched[0] != "corner" || matched[1] != "shop" {
+		t.Fatalf("body snippet = %+v", res.Items[0].BodySnippet)
+	}
+	res = searchResp{}
+	c.expect(c.do("GET", "/api/todos/search?q=%22shop+corner%22", nil), http.StatusOK, &res)
+	if res.Total != 0 {
+		t.Fatalf("reversed phrase = %v", titles(res))
+	}
+
+	// filters combine with the query
+	res = searchResp{}
+	c.expect(c.do("GET", "/api/todos/search?q=milk&status=COMPLETED", nil), http.StatusOK, &res)
+	if got := titles(res); len(got) != 1 || got[0] != "Groceries" {
+		t.Fatalf("status filter = %v", got)
+	}
+	res = searchResp{}
+	c.expect(c.do("GET", "/api/todos/search?q=milk*&tagId="+tag.ID, nil), http.StatusOK, &res)
+	if got := titles(res); len(got) != 1 || got[0] != "Buy milk" {
+		t.Fatalf("tag filter = %v", got)
+	}
+
+	res = searchResp{}
+	c.expect(c.do("GET", "/api/todos/search?q=milk*&limit=2", nil), http.StatusOK, &res)
+	if len(res.Items) != 2 || res.NextCursor == nil {
+		t.Fatalf("first page = %+v", res)
+	}
+	next := searchResp{}
+	c.expect(c.do("GET", "/api/todos/search?q=milk*&limit=2&cursor="+*res.NextCursor, nil), http.StatusOK, &next)
+	if len(next.Items) != 1 || next.NextCursor != nil {
+		t.Fatalf("second page = %+v", next)
+	}
+
+	var e errorResp
+	c.expect(c.do("GET", "/api/todos/search?q=+%26%26+", nil), http.StatusBadRequest, &e)
+	if e.Error.Fields["q"] == "" {
+		t.Fatalf("empty query error = %+v", e)
+	}
+
+	// other users' todos never match
+	other := signUp(t, h, "tom@example.com")
+	res = searchResp{}
+	other.expect(other.do("GET", "/api/todos/search?q=milk", nil), http.StatusOK, &res)
+	if res.Total != 0 || res.Items == nil {
+		t.Fatalf("other user = %+v", res)
+	}
+}
+
+func TestTodoBulk(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "bea@example.com")
+	other := signUp(t, h, "oscar@example.com")
+
+	type bulkResult struct {
+		ID             string    `json:"id"`
+		OK             bool      `json:"ok"`
+		Todo           *todoResp `json:"todo"`
+		NextOccurrence *todoResp `json:"nextOccurrence"`
+		Error          *struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	type bulkResp struct {
+		Results   []bulkResult `json:"results"`
+		Succeeded int          `json:"succeeded"`
+		Failed    int          `json:"failed"`
+	}
+
+	var ids []string
+	for _, title := range []string{"One", "Two"} {
+		var td todoResp
+		c.expect(c.do("POST", "/api/todos", map[string]string{"title": title}), http.StatusCreated, &td)
+		ids = append(ids, td.ID)
+	}
+	var recurring todoResp
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":      "Water plants",
+		"dueDate":    "2026-11-02T00:00:00Z",
+		"recurrence": map[string]any{"frequency": "DAILY"},
+	}), http.StatusCreated, &recurring)
+	var foreign todoResp
+	other.expect(other.do("POST", "/api/todos", map[string]string{"title": "Not yours"}), http.StatusCreated, &foreign)
+
+	// other users' todos fail individually without stopping the batch
+	var resp bulkResp
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{
+		"ids":    []string{ids[0], foreign.ID, ids[1], recurring.ID, ids[0]},
+		"action": "set_status",
+		"status": "COMPLETED",
+	}), http.StatusOK, &resp)
+	if len(resp.Results) != 4 || resp.Succeeded != 3 || resp.Failed != 1 {
+		t.Fatalf("set_status = %+v", resp)
+	}
+	if r := resp.Results[1]; r.OK || r.Error == nil || r.Error.Code != "not_found" {
+		t.Fatalf("foreign result = %+v", r)
+	}
+	if r := resp.Results[0]; r.Todo == nil || r.Todo.Status != "COMPLETED" || r.Todo.CompletedAt == nil {
+		t.Fatalf("first result = %+v", r)
+	}
+	if r := resp.Results[3]; r.NextOccurrence == nil || r.NextOccurrence.Status != "TODO" {
+		t.Fatalf("recurring result = %+v", r)
+	}
+	var untouched todoResp
+	other.expect(other.do("GET", "/api/todos/"+foreign.ID, nil), http.StatusOK, &untouched)
+	if untouched.Status != "TODO" {
+		t.Fatalf("foreign todo = %+v", untouched)
+	}
+
+	var cat namedResp
+	c.expect(c.do("POST", "/api/categories", map[string]string{"name": "Chores"}), http.StatusCreated, &cat)
+	resp = bulkResp{}
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": ids, "action": "set_category", "categoryId": cat.ID,
+	}), http.StatusOK, &resp)
+	for _, r := range resp.Results {
+		if r.Todo == nil || r.Todo.CategoryID == nil || *r.Todo.CategoryID != cat.ID {
+			t.Fatalf("set_category = %+v", r)
+		}
+	}
+
+	var tagA, tagB namedResp
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "a"}), http.StatusCreated, &tagA)
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "b"}), http.StatusCreated, &tagB)
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": ids, "action": "add_tags", "tagIds": []string{tagA.ID, tagB.ID},
+	}), http.StatusOK, nil)
+	resp = bulkResp{}
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": ids, "action": "remove_tags", "tagIds": []string{tagA.ID},
+	}), http.StatusOK, &resp)
+	if tags := resp.Results[1].Todo.TagIDs; len(tags) != 1 || tags[0] != tagB.ID {
+		t.Fatalf("tags after remove = %v", tags)
+	}
+
+	resp = bulkResp{}
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": ids, "action": "duplicate",
+	}), http.StatusOK, &resp)
+	copyID := resp.Results[0].Todo.ID
+	if copyID == ids[0] || resp.Results[0].Todo.Title != "One (Copy)" || resp.Results[0].Todo.Status != "TODO" {
+		t.Fatalf("duplicate = %+v", resp.Results[0].Todo)
+	}
+
+	resp = bulkResp{}
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": []string{ids[0], copyID}, "action": "delete",
+	}), http.StatusOK, &resp)
+	if resp.Succeeded != 2 || resp.Results[0].Todo != nil {
+		t.Fatalf("delete = %+v", resp)
+	}
+	c.expect(c.do("GET", "/api/todos/"+ids[0], nil), http.StatusNotFound, nil)
+
+	// a bad action or payload fails the whole request
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{"ids": ids, "action": "explode"}), http.StatusBadRequest, nil)
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{"ids": []string{}, "action": "delete"}), http.StatusBadRequest, nil)
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": ids, "action": "set_status", "status": "DONE",
+	}), http.StatusBadRequest, nil)
+}
+
+func TestConcurrencyETags(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "etag@example.com")
+	ifMatch := func(etag string) http.Header { return http.Header{"If-Match": {etag}} }
+	ifNoneMatch := func(etag string) http.Header { return http.Header{"If-None-Match": {etag}} }
+
+	var created todoResp
+	c.expect(c.do("POST", "/api/todos", map[string]any{"title": "Shared", "status": "TODO"}), http.StatusCreated, &created)
+
+	rec := c.do("GET", "/api/todos/"+created.ID, nil)
+	c.expect(rec, http.StatusOK, nil)
+	etag := rec.Header().Get("ETag")
+	if etag != `"1"` {
+		t.Fatalf("ETag = %q, want \"1\"", etag)
+	}
+	c.expect(c.doWith("GET", "/api/todos/"+created.ID, nil, ifNoneMatch(etag)), http.StatusNotModified, nil)
+
+	listRec := c.do("GET", "/api/todos", nil)
+	c.expect(listRec, http.StatusOK, nil)
+	listETag := listRec.Header().Get("ETag")
+	c.expect(c.doWith("GET", "/api/todos", nil, ifNoneMatch(listETag)), http.StatusNotModified, nil)
+
+	// the first writer wins; the second, still holding version 1, is refused
+	rec = c.doWith("PUT", "/api/todos/"+created.ID, map[string]any{"title": "First"}, ifMatch(etag))
+	c.expect(rec, http.StatusOK, nil)
+	if got := rec.Header().Get("ETag"); got != `"2"` {
+		t.Fatalf("ETag after update = %q, want \"2\"", got)
+	}
+	var stale errorResp
+	c.expect(c.doWith("PUT", "/api/todos/"+created.ID, map[string]any{"title": "Second"}, ifMatch(etag)), http.StatusPreconditionFailed, &stale)
+	if stale.Error.Code != "precondition_failed" {
+		t.Fatalf("stale update code = %q", stale.Error.Code)
+	}
+	c.expect(c.doWith("PATCH", "/api/todos/"+created.ID+"/status", map[string]string{"status": "COMPLETED"}, ifMatch(etag)), http.StatusPreconditionFailed, nil)
+	c.expect(c.doWith("DELETE", "/api/todos/"+created.ID, nil, ifMatch(etag)), http.StatusPreconditionFailed, nil)
+	c.expect(c.doWith("PUT", "/api/todos/"+created.ID, map[string]any{"title": "Weak"}, ifMatch(`W/"2"`)), http.StatusPreconditionFailed, nil)
+
+	// checklist edits change the todo, so they move its version too
+	c.expect(c.do("POST", "/api/todos/"+created.ID+"/items", map[string]string{"title": "step"}), http.StatusCreated, nil)
+	c.expect(c.doWith("PUT", "/api/todos/"+created.ID, map[string]any{"title": "Late"}, ifMatch(`"2"`)), http.StatusPreconditionFailed, nil)
+
+	c.expect(c.doWith("GET", "/api/todos", nil, ifNoneMatch(listETag)), http.StatusOK, nil)
+	c.expect(c.doWith("DELETE", "/api/todos/"+created.ID, nil, ifMatch(`"1", "3"`)), http.StatusNoContent, nil)
+
+	// categories and tags
+	var cat, tag namedResp
+	c.expect(c.do("POST", "/api/categories", map[string]string{"name": "Work", "color": "#ff0000"}), http.StatusCreated, &cat)
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "urgent"}), http.StatusCreated, &tag)
+
+	rec = c.do("GET", "/api/categories", nil)
+	c.expect(rec, http.StatusOK, nil)
+	c.expect(c.doWith("GET", "/api/categories", nil, ifNoneMatch(rec.Header().Get("ETag"))), http.StatusNotModified, nil)
+	rec = c.do("GET", "/api/tags", nil)
+	c.expect(rec, http.StatusOK, nil)
+	c.expect(c.doWith("GET", "/api/tags", nil, ifNoneMatch(rec.Header().Get("ETag"))), http.StatusNotModified, nil)
+
+	c.expect(c.doWith("PUT", "/api/categories/"+cat.ID, map[string]string{"name": "Office", "color": "#00ff00"}, ifMatch(`"1"`)), http.StatusOK, nil)
+	c.expect(c.doWith("PUT", "/api/categories/"+cat.ID, map[string]string{"name": "Lab", "color": "#00ff00"}, ifMatch(`"1"`)), http.StatusPreconditionFailed, nil)
+	c.expect(c.doWith("DELETE", "/api/categories/"+cat.ID, nil, ifMatch(`"1"`)), http.StatusPreconditionFailed, nil)
+	c.expect(c.doWith("DELETE", "/api/categories/"+cat.ID, nil, ifMatch(`"2"`)), http.StatusNoContent, nil)
+
+	c.expect(c.doWith("PUT", "/api/tags/"+tag.ID, map[string]string{"name": "asap"}, ifMatch(`"1"`)), http.StatusOK, nil)
+	c.expect(c.doWith("PUT", "/api/tags/"+tag.ID, map[string]string{"name": "now"}, ifMatch(`"1"`)), http.StatusPreconditionFailed, nil)
+	c.expect(c.doWith("DELETE", "/api/tags/"+tag.ID, nil, ifMatch(`"1"`)), http.StatusPreconditionFailed, nil)
+	c.expect(c.do("DELETE", "/api/tags/"+tag.ID, nil), http.StatusNoContent, nil)
+}
+
+// sseEvent is one message read from GET /api/events.
+type sseEvent struct {
+	ID   string
+	Name string
+	Data struct {
+		Resource string   `json:"resource"`
+		Action   string   `json:"action"`
+		ID       string   `json:"id"`
+		Data     todoResp `json:"data"`
+	}
+}
+
+// openStream connects to the change feed over a real server, since the stream
+// never ends on its own. lastID, when set, is sent as Last-Event-ID.
+func (c *apiClient) openStream(srv *httptest.Server, lastID string) *bufio.Reader {
+	c.t.Helper()
+	req, _ := http.NewRequest("GET", srv.URL+"/api/events", nil)
+	req.AddCookie(c.cookie)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		c.t.Fatalf("open stream: %v", err)
+	}
+	c.t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		c.t.Fatalf("stream status = %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	return bufio.NewReader(resp.Body)
+}
+
+// nextEvent reads the next message, skipping comments. until names a comment
+// to wait for instead, returning nil once it is read.
+func nextEvent(t *testing.T, r *bufio.Reader, until string) *sseEvent {
+	t.Helper()
+	e := &sseEvent{}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && e.Name != "":
+			return e
+		case strings.HasPrefix(line, ":"):
+			if until != "" && strings.TrimSpace(line[1:]) == until {
+				return nil
+			}
+		case strings.HasPrefix(line, "id: "):
+			e.ID = line[len("id: "):]
+		case strings.HasPrefix(line, "event: "):
+			e.Name = line[len("event: "):]
+		case strings.HasPrefix(line, "data: "):
+			if err := json.Unmarshal([]byte(line[len("data: "):]), &e.Data); err != nil {
+				t.Fatalf("decode event data %q: %v", line, err)
+			}
+		}
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	h := newTestRouter(t)
+	srv := httptest.NewServer(h)
+	// registered first so it runs after openStream has closed the streams
+	t.Cleanup(srv.Close)
+	srv.Client().Timeout = 5 * time.Second
+	alice := signUp(t, h, "stream@example.com")
+	bob := signUp(t, h, "other@example.com")
+
+	stream := alice.openStream(srv, "")
+	nextEvent(t, stream, "connected")
+
+	var created todoResp
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{"title": "Live", "status": "TODO"}), http.StatusCreated, &created)
+	e := nextEvent(t, stream, "")
+	if e.Name != "todo.created" || e.Data.ID != created.ID || e.Data.Data.Title != "Live" {
+		t.Fatalf("event = %+v, want todo.created for %s", e, created.ID)
+	}
+
+	// while disconnected: another user's write and two of alice's own
+	bob.expect(bob.do("POST", "/api/todos", map[string]any{"title": "Not yours", "status": "TODO"}), http.StatusCreated, nil)
+	alice.expect(alice.do("PUT", "/api/todos/"+created.ID, map[string]any{"title": "Renamed"}), http.StatusOK, nil)
+	var tag namedResp
+	alice.expect(alice.do("POST", "/api/tags", map[string]string{"name": "live"}), http.StatusCreated, &tag)
+	alice.expect(alice.do("DELETE", "/api/tags/"+tag.ID, nil), http.StatusNoContent, nil)
+
+	resumed := alice.openStream(srv, e.ID)
+	var names []string
+	for _, want := range []string{"todo.updated", "tag.created", "tag.deleted"} {
+		e := nextEvent(t, resumed, "")
+		names = append(names, e.Name)
+		if e.Name != want {
+			t.Fatalf("replayed events = %v, want todo.updated, tag.created, tag.deleted", names)
+		}
+		if want == "todo.updated" && e.Data.Data.Title != "Renamed" {
+			t.Fatalf("replayed update = %+v", e.Data)
+		}
+		if want == "tag.deleted" && (e.Data.ID != tag.ID || e.Data.Data.ID != "") {
+			t.Fatalf("tag.deleted = %+v, want only the ID", e.Data)
+		}
+	}
+	nextEvent(t, resumed, "connected")
+
+	// an ID the server cannot resume from asks the client to reload
+	if e := nextEvent(t, alice.openStream(srv, "not-a-number"), ""); e.Name != "reset" {
+		t.Fatalf("first event after a bad Last-Event-ID = %q, want reset", e.Name)
+	}
+
+	rec := (&apiClient{t: t, h: h}).do("GET", "/api/events", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("anonymous stream status = %d, want 401", rec.Code)
+	}
+}
+
+func TestTodoTrash(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "tess@example.com")
+	other := signUp(t, h, "trudy@example.com")
+
+	type trashedResp struct {
+		todoResp
+		DeletedAt *string `json:"deletedAt"`
+	}
+
+	var keep, oops, bulk todoResp
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "Keep"}), http.StatusCreated, &keep)
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "Oops milk"}), http.StatusCreated, &oops)
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "Bulk"}), http.StatusCreated, &bulk)
+
+	c.expect(c.do("DELETE", "/api/todos/"+oops.ID, nil), http.StatusNoContent, nil)
+	c.expect(c.do("POST", "/api/todos/bulk", map[string]any{"ids": []string{bulk.ID}, "action": "delete"}), http.StatusOK, nil)
+
+	// trashed todos drop out of every regular read
+	c.expect(c.do("GET", "/api/todos/"+oops.ID, nil), http.StatusNotFound, nil)
+	c.expect(c.do("PUT", "/api/todos/"+oops.ID, map[string]any{"title": "Edited"}), http.StatusNotFound, nil)
+	var list todoListResp
+	c.expect(c.do("GET", "/api/todos", nil), http.StatusOK, &list)
+	if list.Total != 1 || list.Items[0].ID != keep.ID {
+		t.Fatalf("todos = %+v, want only Keep", list)
+	}
+	var found todoListResp
+	c.expect(c.do("GET", "/api/todos/search?q=milk", nil), http.StatusOK, &found)
+	if found.Total != 0 {
+		t.Fatalf("search found trashed todos: %+v", found)
+	}
+
+	var trash []trashedResp
+	c.expect(c.do("GET", "/api/todos/trash", nil), http.StatusOK, &trash)
+	if len(trash) != 2 || trash[0].ID != bulk.ID || trash[1].ID != oops.ID || trash[0].DeletedAt == nil {
+		t.Fatalf("trash = %+v, want Bulk then Oops", trash)
+	}
+	var othersTrash []trashedResp
+	other.expect(other.do("GET", "/api/todos/trash", nil), http.StatusOK, &othersTrash)
+	if len(othersTrash) != 0 {
+		t.Fatalf("another user sees %d trashed todos", len(othersTrash))
+	}
+
+	// only the owner can restore, and only from the trash
+	other.expect(other.do("POST", "/api/todos/"+oops.ID+"/restore", nil), http.StatusNotFound, nil)
+	var restored trashedResp
+	c.expect(c.do("POST", "/api/todos/"+oops.ID+"/restore", nil), http.StatusOK, &restored)
+	if restored.ID != oops.ID || restored.DeletedAt != nil {
+		t.Fatalf("restored = %+v", restored)
+	}
+	c.expect(c.do("POST", "/api/todos/"+oops.ID+"/restore", nil), http.StatusNotFound, nil)
+	c.expect(c.do("GET", "/api/todos/"+oops.ID, nil), http.StatusOK, nil)
+	c.expect(c.do("POST", "/api/todos/"+keep.ID+"/restore", nil), http.StatusNotFound, nil)
+}
+
+func TestActivityHistory(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "ada@example.com")
+	other := signUp(t, h, "oscar@example.com")
+
+	type changeResp struct {
+		Field  string `json:"field"`
+		Before any    `json:"before"`
+		After  any    `json:"after"`
+	}
+	type activityResp struct {
+		Resource   string       `json:"resource"`
+		ResourceID string       `json:"resourceId"`
+		Action     string       `json:"action"`
+		ActorID    string       `json:"actorId"`
+		Changes    []changeResp `json:"changes"`
+	}
+	type activityListResp struct {
+		Items      []activityResp `json:"items"`
+		Total      int            `json:"total"`
+		NextCursor *string        `json:"nextCursor"`
+	}
+
+	var created todoResp
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "Draft"}), http.StatusCreated, &created)
+	c.expect(c.do("PUT", "/api/todos/"+created.ID, map[string]any{
+		"title":   "Final",
+		"dueDate": "2030-01-02T09:00:00Z",
+	}), http.StatusOK, nil)
+	// saving the same values again changes nothing and is not logged
+	c.expect(c.do("PUT", "/api/todos/"+created.ID, map[string]any{"title": "Final"}), http.StatusOK, nil)
+	c.expect(c.do("PATCH", "/api/todos/"+created.ID+"/status", map[string]string{"status": "COMPLETED"}), http.StatusOK, nil)
+	c.expect(c.do("DELETE", "/api/todos/"+created.ID, nil), http.StatusNoContent, nil)
+
+	// the history outlives the todo's move to the trash
+	var history activityListResp
+	c.expect(c.do("GET", "/api/todos/"+created.ID+"/history", nil), http.StatusOK, &history)
+	var actions []string
+	for _, a := range history.Items {
+		actions = append(actions, a.Action)
+	}
+	if got := strings.Join(actions, ","); got != "deleted,updated,updated,created" {
+		t.Fatalf("history actions = %s, want deleted,updated,updated,created", got)
+	}
+	edit := history.Items[2]
+	want := []changeResp{
+		{Field: "title", Before: "Draft", After: "Final"},
+		{Field: "dueDate", Before: nil, After: "2030-01-02T09:00:00Z"},
+	}
+	if !reflect.DeepEqual(edit.Changes, want) {
+		t.Fatalf("edit changes = %+v, want %+v", edit.Changes, want)
+	}
+	if edit.ActorID == "" || edit.ResourceID != created.ID || edit.Resource != "todo" {
+		t.Fatalf("edit entry = %+v", edit)
+	}
+	completed := history.Items[1]
+	if len(completed.Changes) != 2 || completed.Changes[0].Field != "status" || completed.Changes[0].After != "COMPLETED" {
+		t.Fatalf("completion changes = %+v, want status and completedAt", completed.Changes)
+	}
+
+	other.expect(other.do("GET", "/api/todos/"+created.ID+"/history", nil), http.StatusNotFound, nil)
+
+	var tag namedResp
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "home"}), http.StatusCreated, &tag)
+	c.expect(c.do("PUT", "/api/tags/"+tag.ID, map[string]string{"name": "house"}), http.StatusOK, nil)
+
+	// the feed covers every resource, newest first, a page at a time
+	var page1, page2 activityListResp
+	c.expect(c.do("GET", "/api/activity?limit=4", nil), http.StatusOK, &page1)
+	if page1.Total != 6 || len(page1.Items) != 4 || page1.NextCursor == nil {
+		t.Fatalf("page1 = %+v, want 4 of 6 with a cursor", page1)
+	}
+	if first := page1.Items[0]; first.Resource != "tag" || first.Action != "updated" ||
+		!reflect.DeepEqual(first.Changes, []changeResp{{Field: "name", Before: "home", After: "house"}}) {
+		t.Fatalf("newest entry = %+v, want the tag rename", first)
+	}
+	c.expect(c.do("GET", "/api/activity?limit=4&cursor="+*page1.NextCursor, nil), http.StatusOK, &page2)
+	if len(page2.Items) != 2 || page2.NextCursor != nil || page2.Items[1].Action != "created" {
+		t.Fatalf("page2 = %+v, want the last 2 entries", page2)
+	}
+
+	var tagsOnly activityListResp
+	c.expect(c.do("GET", "/api/activity?resource=tag", nil), http.StatusOK, &tagsOnly)
+	if tagsOnly.Total != 2 {
+		t.Fatalf("tag activity = %+v, want 2 entries", tagsOnly)
+	}
+	c.expect(c.do("GET", "/api/activity?resource=user", nil), http.StatusBadRequest, nil)
+
+	var othersFeed activityListResp
+	other.expect(other.do("GET", "/api/activity", nil), http.StatusOK, &othersFeed)
+	if othersFeed.Total != 0 {
+		t.Fatalf("another user's feed has %d entries", othersFeed.Total)
+	}
+}
+
+// flakyNotifier fails its first delivery and records every attempt.
+type flakyNotifier struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (n *flakyNotifier) Notify(_ context.Context, m notify.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.keys = append(n.keys, m.Key)
+	if len(n.keys) == 1 {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestReminders(t *testing.T) {
+	hook := &flakyNotifier{}
+	container := newTestContainer(t, map[entity.ReminderChannel]notify.Notifier{entity.ChannelWebhook: hook})
+	h := newRouter(container, testOrigin)
+	c := signUp(t, h, "rita@example.com") // in Asia/Tokyo
+	other := signUp(t, h, "otto@example.com")
+
+	type reminderResp struct {
+		ID        string  `json:"id"`
+		Channel   string  `json:"channel"`
+		FireAt    string  `json:"fireAt"`
+		Status    string  `json:"status"`
+		Attempts  int     `json:"attempts"`
+		LastError *string `json:"lastError"`
+	}
+	type notificationResp struct {
+		ID     string  `json:"id"`
+		TodoID string  `json:"todoId"`
+		Title  string  `json:"title"`
+		Body   string  `json:"body"`
+		ReadAt *string `json:"readAt"`
+	}
+	dispatch := func(at string) int {
+		t.Helper()
+		now, _ := time.Parse(time.RFC3339, at)
+		n, err := container.DispatchReminders.Execute(context.Background(), now)
+		if err != nil {
+			t.Fatalf("dispatch at %s: %v", at, err)
+		}
+		return n
+	}
+
+	var td todoResp
+	c.expect(c.do("POST", "/api/todos", map[string]string{
+		"title":   "File taxes",
+		"dueDate": "2030-01-02T09:00:00Z",
+	}), http.StatusCreated, &td)
+	base := "/api/todos/" + td.ID + "/reminders"
+
+	var before, local reminderResp
+	c.expect(c.do("POST", base, map[string]any{"offsetMinutes": 60}), http.StatusCreated, &before)
+	if before.Channel != "inapp" || before.FireAt != "2030-01-02T08:00:00Z" || before.Status != "pending" {
+		t.Fatalf("offset reminder = %+v, want in-app at 08:00Z", before)
+	}
+	// a time without offset is read in the user's timezone
+	c.expect(c.do("POST", base, map[string]any{"remindAt": "2030-01-02T10:00", "channel": "webhook"}), http.StatusCreated, &local)
+	if local.FireAt != "2030-01-02T01:00:00Z" {
+		t.Fatalf("local reminder fires at %s, want 10:00 in Tokyo", local.FireAt)
+	}
+
+	c.expect(c.do("POST", base, map[string]any{"remindAt": "2030-01-02T10:00", "offsetMinutes": 5}), http.StatusBadRequest, nil)
+	c.expect(c.do("POST", base, map[string]any{"remindAt": "2020-01-01T00:00:00Z"}), http.StatusBadRequest, nil)
+	c.expect(c.do("POST", base, map[string]any{"remindAt": "next tuesday"}), http.StatusBadRequest, nil)
+	c.expect(c.do("POST", base, map[string]any{"offsetMinutes": 10, "channel": "email"}), http.StatusBadRequest, nil)
+	other.expect(other.do("GET", base, nil), http.StatusNotFound, nil)
+	other.expect(other.do("POST", base, map[string]any{"offsetMinutes": 10}), http.StatusNotFound, nil)
+
+	// a failed delivery is retried after a backoff with the same key
+	if n := dispatch("2030-01-02T01:00:00Z"); n != 0 {
+		t.Fatalf("first dispatch sent %d, want the webhook to fail", n)
+	}
+	var list []reminderResp
+	c.expect(c.do("GET", base, nil), http.StatusOK, &list)
+	if len(list) != 2 || list[0].ID != local.ID || list[0].Status != "pending" || list[0].Attempts != 1 || list[0].LastError == nil {
+		t.Fatalf("reminders after failure = %+v", list)
+	}
+	if n := dispatch("2030-01-02T01:00:30Z"); n != 0 {
+		t.Fatalf("retried before the backoff ran out")
+	}
+	if n := dispatch("2030-01-02T01:01:00Z"); n != 1 {
+		t.Fatalf("retry sent %d, want 1", n)
+	}
+	if n := dispatch("2030-01-02T01:05:00Z"); n != 0 {
+		t.Fatalf("a sent reminder went out again")
+	}
+	if len(hook.keys) != 2 || hook.keys[0] != hook.keys[1] {
+		t.Fatalf("webhook keys = %v, want one key delivered twice", hook.keys)
+	}
+
+	// moving the due date moves the offset reminder with it
+	c.expect(c.do("PUT", "/api/todos/"+td.ID, map[string]any{"dueDate": "2030-01-03T09:00:00Z"}), http.StatusOK, nil)
+	c.expect(c.do("GET", base, nil), http.StatusOK, &list)
+	if list[1].ID != before.ID || list[1].FireAt != "2030-01-03T08:00:00Z" || list[0].Status != "sent" {
+		t.Fatalf("reminders after due date change = %+v", list)
+	}
+	if n := dispatch("2030-01-02T08:00:00Z"); n != 0 {
+		t.Fatalf("the moved reminder fired at its old time")
+	}
+	if n := dispatch("2030-01-03T08:00:00Z"); n != 1 {
+		t.Fatalf("the moved reminder did not fire")
+	}
+
+	var inbox []notificationResp
+	c.expect(c.do("GET", "/api/notifications", nil), http.StatusOK, &inbox)
+	if len(inbox) != 1 || inbox[0].TodoID != td.ID || inbox[0].Title != "Reminder: File taxes" || inbox[0].ReadAt != nil {
+		t.Fatalf("inbox = %+v", inbox)
+	}
+	if !strings.Contains(inbox[0].Body, "Due Thu, 3 Jan 2030 18:00 JST") {
+		t.Fatalf("body %q does not show the due date in the user's timezone", inbox[0].Body)
+	}
+	other.expect(other.do("POST", "/api/notifications/"+inbox[0].ID+"/read", nil), http.StatusNotFound, nil)
+	var read notificationResp
+	c.expect(c.do("POST", "/api/notifications/"+inbox[0].ID+"/read", nil), http.StatusOK, &read)
+	if read.ReadAt == nil {
+		t.Fatalf("notification not marked read: %+v", read)
+	}
+	var othersInbox []notificationResp
+	other.expect(other.do("GET", "/api/notifications", nil), http.StatusOK, &othersInbox)
+	if len(othersInbox) != 0 {
+		t.Fatalf("another user sees %d notifications", len(othersInbox))
+	}
+
+	other.expect(other.do("DELETE", base+"/"+before.ID, nil), http.StatusNotFound, nil)
+	c.expect(c.do("DELETE", base+"/"+before.ID, nil), http.StatusNoContent, nil)
+	c.expect(c.do("DELETE", base+"/"+before.ID, nil), http.StatusNotFound, nil)
+}
+
+func TestRemindersOfLeftWorkspaceAreDropped(t *testing.T) {
+	container := newTestContainer(t, nil)
+	h := newRouter(container, testOrigin)
+	alice := signUp(t, h, "rem-alice@example.com")
+	bob := signUp(t, h, "rem-bob@example.com")
+
+	var ws workspaceResp
+	alice.expect(alice.do("POST", "/api/workspaces", map[string]string{"name": "Team"}), http.StatusCreated, &ws)
+	join(alice, bob, ws.ID, "rem-bob@example.com", "viewer")
+	var td todoResp
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{
+		"title": "Launch", "dueDate": "2030-01-02T09:00:00Z", "workspaceId": ws.ID,
+	}), http.StatusCreated, &td)
+	base := "/api/todos/" + td.ID + "/reminders"
+	alice.expect(alice.do("POST", base, map[string]any{"offsetMinutes": 60}), http.StatusCreated, nil)
+	bob.expect(bob.do("POST", base, map[string]any{"offsetMinutes": 60}), http.StatusCreated, nil)
+
+	// bob leaves before the reminder is due, so only alice hears about the todo
+	bob.expect(bob.do("DELETE", "/api/workspaces/"+ws.ID+"/members/"+memberID(t, alice, ws.ID, "rem-bob@example.com"), nil), http.StatusNoContent, nil)
+	now, _ := time.Parse(time.RFC3339, "2030-01-02T08:00:00Z")
+	if n, err := container.DispatchReminders.Execute(context.Background(), now); err != nil || n != 1 {
+		t.Fatalf("dispatch = %d, %v; want alice's reminder only", n, err)
+	}
+	var inbox []struct{}
+	bob.expect(bob.do("GET", "/api/notifications", nil), http.StatusOK, &inbox)
+	if len(inbox) != 0 {
+		t.Fatalf("bob got %d notifications after leaving the workspace", len(inbox))
+	}
+}
+
+func TestCalendarFeed(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "cal@example.com")
+	other := signUp(t, h, "carl@example.com")
+	anon := &apiClient{t: t, h: h}
+
+	type tokenResp struct {
+		Token string `json:"token"`
+		Path  string `json:"path"`
+	}
+	type feedResp struct {
+		Active bool `json:"active"`
+	}
+
+	var status feedResp
+	c.expect(c.do("GET", "/api/calendar/feed", nil), http.StatusOK, &status)
+	if status.Active {
+		t.Fatal("a new user already has a feed")
+	}
+
+	var work, home namedResp
+	c.expect(c.do("POST", "/api/categories", map[string]string{"name": "Work, Inc."}), http.StatusCreated, &work)
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "urgent"}), http.StatusCreated, &home)
+	var report, gym, done todoResp
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":      "Quarterly report; final",
+		"body":       "Numbers\nand charts",
+		"dueDate":    "2030-01-02T09:00:00Z",
+		"categoryId": work.ID,
+		"tagIds":     []string{home.ID},
+	}), http.StatusCreated, &report)
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":      "Gym",
+		"dueDate":    "2030-01-07T00:00:00Z",
+		"recurrence": map[string]any{"frequency": "WEEKLY", "byWeekday": []string{"MO", "TH"}},
+	}), http.StatusCreated, &gym)
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":   "Renew passport",
+		"dueDate": "2030-01-01T00:00:00Z",
+	}), http.StatusCreated, &done)
+	c.expect(c.do("PATCH", "/api/todos/"+done.ID+"/status", map[string]string{"status": "COMPLETED"}), http.StatusOK, &done)
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "Someday"}), http.StatusCreated, nil)
+
+	var issued tokenResp
+	c.expect(c.do("POST", "/api/calendar/feed", nil), http.StatusCreated, &issued)
+	if issued.Token == "" || issued.Path != "/api/calendar/"+issued.Token+".ics" {
+		t.Fatalf("issued = %+v", issued)
+	}
+	c.expect(c.do("GET", "/api/calendar/feed", nil), http.StatusOK, &status)
+	if !status.Active {
+		t.Fatal("feed not active after issuing a token")
+	}
+
+	// calendar apps fetch without cookies
+	rec := anon.do("GET", issued.Path+"?type=todo", nil)
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("GET feed = %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	ics := rec.Body.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-TIMEZONE:Asia/Tokyo\r\n",
+		"UID:" + report.ID + "\r\n",
+		"SUMMARY:Quarterly report\\; final\r\n",
+		"DESCRIPTION:Numbers\\nand charts\r\n",
+		"DUE:20300102T090000Z\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+		"CATEGORIES:Work\\, Inc.,urgent\r\n",
+		"RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH\r\n",
+		"STATUS:COMPLETED\r\n",
+		"PERCENT-COMPLETE:100\r\n",
+		"COMPLETED:",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Fatalf("feed is missing %q:\n%s", want, ics)
+		}
+	}
+	// only todos with a due date, soonest first
+	if got := strings.Count(ics, "BEGIN:VTODO"); got != 3 || strings.Contains(ics, "Someday") {
+		t.Fatalf("feed has %d todos, want the 3 with due dates:\n%s", got, ics)
+	}
+	if strings.Index(ics, done.ID) > strings.Index(ics, report.ID) || strings.Index(ics, report.ID) > strings.Index(ics, gym.ID) {
+		t.Fatalf("todos are not ordered by due date:\n%s", ics)
+	}
+
+	events := anon.do("GET", issued.Path, nil)
+	if events.Code != http.StatusOK || strings.Contains(events.Body.String(), "BEGIN:VTODO") ||
+		!strings.Contains(events.Body.String(), "DTSTART:20300102T090000Z\r\n") ||
+		!strings.Contains(events.Body.String(), "SUMMARY:✓ Renew passport\r\n") {
+		t.Fatalf("event feed:\n%s", events.Body.String())
+	}
+	anon.expect(anon.do("GET", issued.Path+"?type=journal", nil), http.StatusBadRequest, nil)
+
+	// unchanged feeds revalidate cheaply
+	etag := events.Header().Get("ETag")
+	if again := anon.doWith("GET", issued.Path, nil, http.Header{"If-None-Match": {etag}}); etag == "" || again.Code != http.StatusNotModified {
+		t.Fatalf("revalidation = %d with ETag %q, want 304", again.Code, etag)
+	}
+
+	// each user's feed only has their own todos
+	var othersToken tokenResp
+	other.expect(other.do("POST", "/api/calendar/feed", nil), http.StatusCreated, &othersToken)
+	if body := anon.do("GET", othersToken.Path, nil).Body.String(); strings.Contains(body, "BEGIN:VEVENT") {
+		t.Fatalf("another user's feed has events:\n%s", body)
+	}
+
+	// rotating retires the old URL, revoking retires every URL
+	var rotated tokenResp
+	c.expect(c.do("POST", "/api/calendar/feed", nil), http.StatusCreated, &rotated)
+	anon.expect(anon.do("GET", issued.Path, nil), http.StatusNotFound, nil)
+	anon.expect(anon.do("GET", rotated.Path, nil), http.StatusOK, nil)
+	c.expect(c.do("DELETE", "/api/calendar/feed", nil), http.StatusNoContent, nil)
+	anon.expect(anon.do("GET", rotated.Path, nil), http.StatusNotFound, nil)
+	c.expect(c.do("GET", "/api/calendar/feed", nil), http.StatusOK, &status)
+	if status.Active {
+		t.Fatal("feed still active after revoking")
+	}
+	anon.expect(anon.do("POST", "/api/calendar/feed", nil), http.StatusUnauthorized, nil)
+}
+
+// doRaw sends body as is, for uploads that are not JSON.
+func (c *apiClient) doRaw(method, path, contentType, body string) *httptest.ResponseRecorder {
+	c.t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	if c.cookie != nil {
+		req.AddCookie(c.cookie)
+	}
+	rec := httptest.NewRecorder()
+	c.h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestImportExport(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "ivy@example.com")
+	other := signUp(t, h, "ezra@example.com")
+
+	type importResp struct {
+		DryRun     bool     `json:"dryRun"`
+		Todos      int      `json:"todos"`
+		Categories []string `json:"categories"`
+		Tags       []string `json:"tags"`
+		Duplicates []struct {
+			Index int    `json:"index"`
+			Title string `json:"title"`
+		} `json:"duplicates"`
+	}
+	countTodos := func(c *apiClient) int {
+		t.Helper()
+		var list todoListResp
+		c.expect(c.do("GET", "/api/todos", nil), http.StatusOK, &list)
+		return list.Total
+	}
+
+	var work, urgent, office namedResp
+	c.expect(c.do("POST", "/api/categories", map[string]string{"name": "Work, Inc.", "color": "#112233"}), http.StatusCreated, &work)
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "urgent"}), http.StatusCreated, &urgent)
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "home office"}), http.StatusCreated, &office)
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":      "Report",
+		"body":       "Numbers, charts\nand a summary",
+		"dueDate":    "2030-01-02T09:00:00Z",
+		"categoryId": work.ID,
+		"tagIds":     []string{urgent.ID, office.ID},
+	}), http.StatusCreated, nil)
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":      "Gym",
+		"dueDate":    "2030-01-07T00:00:00Z",
+		"recurrence": map[string]any{"frequency": "WEEKLY", "byWeekday": []string{"MO", "TH"}},
+	}), http.StatusCreated, nil)
+	var passport todoResp
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "Passport"}), http.StatusCreated, &passport)
+	c.expect(c.do("PATCH", "/api/todos/"+passport.ID+"/status", map[string]string{"status": "COMPLETED"}), http.StatusOK, nil)
+
+	// JSON is the full backup
+	rec := c.do("GET", "/api/export?format=json", nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("Content-Disposition"), "attachment") {
+		t.Fatalf("JSON export = %d %v", rec.Code, rec.Header())
+	}
+	backup := rec.Body.String()
+	var exported struct {
+		Version    int `json:"version"`
+		Categories []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"categories"`
+		Tags  []namedResp `json:"tags"`
+		Todos []struct {
+			Title      string   `json:"title"`
+			Status     string   `json:"status"`
+			Category   string   `json:"category"`
+			Tags       []string `json:"tags"`
+			Recurrence string   `json:"recurrence"`
+		} `json:"todos"`
+	}
+	if err := json.Unmarshal([]byte(backup), &exported); err != nil {
+		t.Fatalf("export is not JSON: %v\n%s", err, backup)
+	}
+	if exported.Version != 1 || len(exported.Categories) != 1 || exported.Categories[0].Color != "#112233" ||
+		len(exported.Tags) != 2 || len(exported.Todos) != 3 {
+		t.Fatalf("exported = %+v", exported)
+	}
+	if r := exported.Todos[0]; r.Title != "Report" || r.Category != "Work, Inc." || !reflect.DeepEqual(r.Tags, []string{"home office", "urgent"}) {
+		t.Fatalf("first todo = %+v", r)
+	}
+	if r := exported.Todos[1]; r.Recurrence != "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH" {
+		t.Fatalf("recurrence = %q", r.Recurrence)
+	}
+
+	rec = c.do("GET", "/api/export?format=csv", nil)
+	rows, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil || len(rows) != 4 || rows[0][0] != "title" {
+		t.Fatalf("CSV export = %v, %v", rows, err)
+	}
+	if rows[1][1] != "Numbers, charts\nand a summary" || rows[1][7] != "home office;urgent" || rows[3][2] != "COMPLETED" {
+		t.Fatalf("CSV rows = %q", rows)
+	}
+
+	rec = c.do("GET", "/api/export?format=todotxt", nil)
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[0], "Report +Work,_Inc. @home_office @urgent due:2030-01-02") ||
+		!strings.HasPrefix(lines[2], "x ") {
+		t.Fatalf("todo.txt export:\n%s", rec.Body.String())
+	}
+	c.expect(c.do("GET", "/api/export?format=xml", nil), http.StatusBadRequest, nil)
+
+	// a dry run reports what would be created and writes nothing
+	var dry importResp
+	other.expect(other.doRaw("POST", "/api/import?dryRun=true", "application/json", backup), http.StatusOK, &dry)
+	if !dry.DryRun || dry.Todos != 3 || !reflect.DeepEqual(dry.Categories, []string{"Work, Inc."}) ||
+		!reflect.DeepEqual(dry.Tags, []string{"home office", "urgent"}) || len(dry.Duplicates) != 0 {
+		t.Fatalf("dry run = %+v", dry)
+	}
+	if n := countTodos(other); n != 0 {
+		t.Fatalf("dry run created %d todos", n)
+	}
+
+	var restored importResp
+	other.expect(other.doRaw("POST", "/api/import", "application/json", backup), http.StatusCreated, &restored)
+	if restored.DryRun || restored.Todos != 3 {
+		t.Fatalf("import = %+v", restored)
+	}
+	var categories []struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+	other.expect(other.do("GET", "/api/categories", nil), http.StatusOK, &categories)
+	if len(categories) != 1 || categories[0].Color != "#112233" {
+		t.Fatalf("imported categories = %+v", categories)
+	}
+	if again := other.doRaw("GET", "/api/export?format=json", "", ""); !strings.Contains(again.Body.String(), `"recurrence":"FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH"`) {
+		t.Fatalf("round trip lost the recurrence:\n%s", again.Body.String())
+	}
+
+	// importing the same file twice only reports duplicates
+	var twice importResp
+	other.expect(other.doRaw("POST", "/api/import", "application/json", backup), http.StatusCreated, &twice)
+	if twice.Todos != 0 || len(twice.Duplicates) != 3 || twice.Duplicates[0].Title != "Report" || len(twice.Tags) != 0 {
+		t.Fatalf("second import = %+v", twice)
+	}
+
+	// CSV columns are matched by name; existing tags are reused
+	csvFile := "Tags,Title,dueDate\nurgent;errands,Buy milk,2030-02-01\nurgent,report,2030-01-02T09:00:00Z\n"
+	var fromCSV importResp
+	c.expect(c.doRaw("POST", "/api/import?format=csv", "text/plain", csvFile), http.StatusCreated, &fromCSV)
+	if fromCSV.Todos != 1 || !reflect.DeepEqual(fromCSV.Tags, []string{"errands"}) ||
+		len(fromCSV.Duplicates) != 1 || fromCSV.Duplicates[0].Index != 2 {
+		t.Fatalf("CSV import = %+v", fromCSV)
+	}
+
+	todoTxt := "x 2030-01-05 2029-12-01 Buy stamps +Errands @post_office due:2030-01-06\n\n(A) Call mom @phone\n"
+	var fromTxt importResp
+	c.expect(c.doRaw("POST", "/api/import", "text/plain", todoTxt), http.StatusCreated, &fromTxt)
+	if fromTxt.Todos != 2 || !reflect.DeepEqual(fromTxt.Categories, []string{"Errands"}) ||
+		!reflect.DeepEqual(fromTxt.Tags, []string{"post office", "phone"}) {
+		t.Fatalf("todo.txt import = %+v", fromTxt)
+	}
+	var found todoListResp
+	c.expect(c.do("GET", "/api/todos/search?q=stamps", nil), http.StatusOK, &found)
+	if found.Total != 1 || found.Items[0].Status != "COMPLETED" || found.Items[0].CompletedAt == nil {
+		t.Fatalf("imported todo.txt todo = %+v", found)
+	}
+
+	// an invalid todo fails the whole file
+	before := countTodos(c)
+	var bad errorResp
+	c.expect(c.doRaw("POST", "/api/import?format=todotxt", "text/plain", "Fine\nBroken due:tomorrow\n"), http.StatusBadRequest, &bad)
+	if !strings.HasPrefix(bad.Error.Message, "todo 2:") {
+		t.Fatalf("error = %+v, want it to name todo 2", bad.Error)
+	}
+	c.expect(c.doRaw("POST", "/api/import", "application/json", `{"todos":[{"title":"A"},{"title":"B","status":"DONE"}]}`), http.StatusBadRequest, nil)
+	c.expect(c.doRaw("POST", "/api/import?format=csv", "text/csv", "name\nx\n"), http.StatusBadRequest, nil)
+	c.expect(c.doRaw("POST", "/api/import", "application/octet-stream", "x"), http.StatusBadRequest, nil)
+	c.expect(c.doRaw("POST", "/api/import?format=json", "application/json", `{"todos":[]}`), http.StatusBadRequest, nil)
+	if after := countTodos(c); after != before {
+		t.Fatalf("failed imports changed the todo count from %d to %d", before, after)
+	}
+}
+
+func TestCSVExportIsSafeForSpreadsheets(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "formulas@example.com")
+	other := signUp(t, h, "formulas2@example.com")
+
+	var semi namedResp
+	c.expect(c.do("POST", "/api/tags", map[string]string{"name": "a;b"}), http.StatusCreated, &semi)
+	c.expect(c.do("POST", "/api/todos", map[string]any{
+		"title":  `=HYPERLINK("http://evil.example","x")`,
+		"body":   "'@already quoted",
+		"tagIds": []string{semi.ID},
+	}), http.StatusCreated, nil)
+
+	rec := c.do("GET", "/api/export?format=csv", nil)
+	c.expect(rec, http.StatusOK, nil)
+	export := rec.Body.String()
+	rows, err := csv.NewReader(strings.NewReader(export)).ReadAll()
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("CSV export = %v, %v", rows, err)
+	}
+	if rows[1][0] != `'=HYPERLINK("http://evil.example","x")` || rows[1][1] != "''@already quoted" || rows[1][7] != `"a;b"` {
+		t.Fatalf("CSV row = %q", rows[1])
+	}
+
+	// importing the export gives back the original values
+	other.expect(other.doRaw("POST", "/api/import?format=csv", "text/csv", export), http.StatusCreated, nil)
+	var list todoListResp
+	other.expect(other.do("GET", "/api/todos", nil), http.StatusOK, &list)
+	if list.Total != 1 || list.Items[0].Title != `=HYPERLINK("http://evil.example","x")` {
+		t.Fatalf("imported = %+v", list.Items)
+	}
+	var tags []namedResp
+	other.expect(other.do("GET", "/api/tags", nil), http.StatusOK, &tags)
+	if len(tags) != 1 || tags[0].Name != "a;b" {
+		t.Fatalf("imported tags = %+v", tags)
+	}
+}
+
+func TestSearchCoversMemberWorkspaces(t *testing.T) {
+	h := newTestRouter(t)
+	alice := signUp(t, h, "search-alice@example.com")
+	bob := signUp(t, h, "search-bob@example.com")
+	mallory := signUp(t, h, "search-mallory@example.com")
+
+	var ws workspaceResp
+	alice.expect(alice.do("POST", "/api/workspaces", map[string]string{"name": "Team"}), http.StatusCreated, &ws)
+	join(alice, bob, ws.ID, "search-bob@example.com", "viewer")
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{"title": "Shared milk run", "workspaceId": ws.ID}), http.StatusCreated, nil)
+	bob.expect(bob.do("POST", "/api/todos", map[string]string{"title": "Own milk"}), http.StatusCreated, nil)
+	mallory.expect(mallory.do("POST", "/api/todos", map[string]string{"title": "Secret milk"}), http.StatusCreated, nil)
+
+	titles := func(c *apiClient, path string) []string {
+		t.Helper()
+		var res todoListResp
+		c.expect(c.do("GET", path, nil), http.StatusOK, &res)
+		var out []string
+		for _, it := range res.Items {
+			out = append(out, it.Title)
+		}
+		slices.Sort(out)
+		return out
+	}
+
+	// without workspaceId, personal todos and those of every joined workspace
+	if got := titles(bob, "/api/todos/search?q=milk"); !reflect.DeepEqual(got, []string{"Own milk", "Shared milk run"}) {
+		t.Fatalf("bob's search = %q", got)
+	}
+	if got := titles(bob, "/api/todos/search?q=milk&workspaceId="+ws.ID); !reflect.DeepEqual(got, []string{"Shared milk run"}) {
+		t.Fatalf("bob's workspace search = %q", got)
+	}
+	if got := titles(mallory, "/api/todos/search?q=milk"); !reflect.DeepEqual(got, []string{"Secret milk"}) {
+		t.Fatalf("mallory's search = %q", got)
+	}
+	mallory.expect(mallory.do("GET", "/api/todos/search?q=milk&workspaceId="+ws.ID, nil), http.StatusNotFound, nil)
+}
+
+type workspaceResp struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Role    string `json:"role"`
+	Members []struct {
+		UserID string `json:"userId"`
+		Email  string `json:"email"`
+		Role   string `json:"role"`
+	} `json:"members"`
+}
+
+type invitationResp struct {
+	ID            string    `json:"id"`
+	WorkspaceID   string    `json:"workspaceId"`
+	WorkspaceName *string   `json:"workspaceName"`
+	Role          string    `json:"role"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	Token         string    `json:"token"`
+}
+
+// join invites c's email to the workspace with role and has c accept.
+func join(owner, c *apiClient, workspaceID, email, role string) {
+	owner.t.Helper()
+	var inv invitationResp
+	owner.expect(owner.do("POST", "/api/workspaces/"+workspaceID+"/invitations", map[string]string{
+		"email": email, "role": role,
+	}), http.StatusCreated, &inv)
+	var ws workspaceResp
+	c.expect(c.do("POST", "/api/invitations/"+inv.ID+"/accept", map[string]string{"token": inv.Token}), http.StatusOK, &ws)
+	if ws.ID != workspaceID || ws.Role != role {
+		owner.t.Fatalf("accepted workspace = %+v, want %s as %s", ws, workspaceID, role)
+	}
+}
+
+func TestWorkspaces(t *testing.T) {
+	h := newTestRouter(t)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	srv.Client().Timeout = 5 * time.Second
+	alice := signUp(t, h, "alice@example.com")
+	bob := signUp(t, h, "bob@example.com")
+	carol := signUp(t, h, "carol@example.com")
+	mallory := signUp(t, h, "mallory@example.com")
+
+	var ws workspaceResp
+	alice.expect(alice.do("POST", "/api/workspaces", map[string]string{"name": "  Team  "}), http.StatusCreated, &ws)
+	if ws.Name != "Team" || ws.Role != "owner" {
+		t.Fatalf("created workspace = %+v", ws)
+	}
+	alice.expect(alice.do("POST", "/api/workspaces", map[string]string{"name": " "}), http.StatusBadRequest, nil)
+
+	// bob sees the invitation to his address, with the workspace's name
+	var inv invitationResp
+	alice.expect(alice.do("POST", "/api/workspaces/"+ws.ID+"/invitations", map[string]string{
+		"email": "Bob@Example.com", "role": "editor",
+	}), http.StatusCreated, &inv)
+	alice.expect(alice.do("POST", "/api/workspaces/"+ws.ID+"/invitations", map[string]string{
+		"email": "bob@example.com", "role": "viewer",
+	}), http.StatusConflict, nil)
+	alice.expect(alice.do("POST", "/api/workspaces/"+ws.ID+"/invitations", map[string]string{
+		"email": "dave@example.com", "role": "admin",
+	}), http.StatusBadRequest, nil)
+	var received []invitationResp
+	bob.expect(bob.do("GET", "/api/invitations", nil), http.StatusOK, &received)
+	if len(received) != 1 || received[0].ID != inv.ID || received[0].WorkspaceName == nil || *received[0].WorkspaceName != "Team" ||
+		received[0].Token != "" {
+		t.Fatalf("bob's invitations = %+v", received)
+	}
+	if inv.Token == "" || time.Until(inv.ExpiresAt) < 6*24*time.Hour {
+		t.Fatalf("created invitation = %+v, want a token and a week to accept", inv)
+	}
+
+	// accepting takes the token the owner passed on, not just a matching email
+	mallory.expect(mallory.do("POST", "/api/invitations/"+inv.ID+"/accept", map[string]string{"token": inv.Token}), http.StatusNotFound, nil)
+	bob.expect(bob.do("POST", "/api/invitations/"+inv.ID+"/accept", map[string]string{"token": "guess"}), http.StatusNotFound, nil)
+	bob.expect(bob.do("POST", "/api/invitations/"+inv.ID+"/accept", map[string]string{}), http.StatusNotFound, nil)
+	bob.expect(bob.do("POST", "/api/invitations/"+inv.ID+"/accept", map[string]string{"token": inv.Token}), http.StatusOK, nil)
+	join(alice, carol, ws.ID, "carol@example.com", "viewer")
+
+	// bob's stream carries the workspace's events once he is a member
+	stream := bob.openStream(srv, "")
+	nextEvent(t, stream, "connected")
+
+	// an editor's todo is shared with every member, but not with their personal list
+	var cat namedResp
+	bob.expect(bob.do("POST", "/api/categories", map[string]any{
+		"name": "Shared", "color": "#336699", "workspaceId": ws.ID,
+	}), http.StatusCreated, &cat)
+	var shared todoResp
+	bob.expect(bob.do("POST", "/api/todos", map[string]any{
+		"title": "Ship it", "status": "TODO", "workspaceId": ws.ID, "categoryId": cat.ID,
+	}), http.StatusCreated, &shared)
+	if e := nextEvent(t, stream, ""); e.Name != "category.created" {
+		t.Fatalf("first event = %q, want category.created", e.Name)
+	}
+	if e := nextEvent(t, stream, ""); e.Name != "todo.created" || e.Data.ID != shared.ID {
+		t.Fatalf("event = %+v, want todo.created for the shared todo", e)
+	}
+	var list todoListResp
+	carol.expect(carol.do("GET", "/api/todos?workspaceId="+ws.ID, nil), http.StatusOK, &list)
+	if list.Total != 1 || list.Items[0].ID != shared.ID {
+		t.Fatalf("carol's view of the workspace = %+v", list)
+	}
+	bob.expect(bob.do("GET", "/api/todos", nil), http.StatusOK, &list)
+	if list.Total != 0 {
+		t.Fatalf("bob's personal list has %d todos", list.Total)
+	}
+	// a personal todo cannot use the workspace's category
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{
+		"title": "Mine", "status": "TODO", "categoryId": cat.ID,
+	}), http.StatusBadRequest, nil)
+	alice.expect(alice.do("PUT", "/api/todos/"+shared.ID, map[string]any{"title": "Ship it today"}), http.StatusOK, nil)
+	if e := nextEvent(t, stream, ""); e.Name != "todo.updated" || e.Data.Data.Title != "Ship it today" {
+		t.Fatalf("event = %+v, want alice's update", e)
+	}
+
+	// a viewer reads but cannot write
+	carol.expect(carol.do("GET", "/api/todos/"+shared.ID, nil), http.StatusOK, nil)
+	carol.expect(carol.do("PUT", "/api/todos/"+shared.ID, map[string]any{"title": "nope"}), http.StatusForbidden, nil)
+	carol.expect(carol.do("DELETE", "/api/todos/"+shared.ID, nil), http.StatusForbidden, nil)
+	carol.expect(carol.do("POST", "/api/todos", map[string]any{
+		"title": "nope", "status": "TODO", "workspaceId": ws.ID,
+	}), http.StatusForbidden, nil)
+	carol.expect(carol.do("DELETE", "/api/categories/"+cat.ID, nil), http.StatusForbidden, nil)
+	var res struct {
+		Failed int `json:"failed"`
+	}
+	bob.expect(bob.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": []string{shared.ID}, "action": "set_status", "status": "IN_PROGRESS",
+	}), http.StatusOK, &res)
+	if res.Failed != 1 {
+		t.Fatalf("bulk without workspaceId touched the shared todo: %+v", res)
+	}
+	bob.expect(bob.do("POST", "/api/todos/bulk", map[string]any{
+		"ids": []string{shared.ID}, "action": "set_status", "status": "IN_PROGRESS", "workspaceId": ws.ID,
+	}), http.StatusOK, &res)
+	if res.Failed != 0 {
+		t.Fatalf("bulk in the workspace failed: %+v", res)
+	}
+
+	// outsiders cannot tell the workspace or its todos exist
+	mallory.expect(mallory.do("GET", "/api/workspaces/"+ws.ID, nil), http.StatusNotFound, nil)
+	mallory.expect(mallory.do("GET", "/api/todos/"+shared.ID, nil), http.StatusNotFound, nil)
+	mallory.expect(mallory.do("GET", "/api/todos?workspaceId="+ws.ID, nil), http.StatusNotFound, nil)
+	mallory.expect(mallory.do("GET", "/api/categories?workspaceId="+ws.ID, nil), http.StatusNotFound, nil)
+
+	// only owners manage the workspace, and it keeps at least one
+	bob.expect(bob.do("PATCH", "/api/workspaces/"+ws.ID, map[string]string{"name": "Mine"}), http.StatusForbidden, nil)
+	alice.expect(alice.do("DELETE", "/api/workspaces/"+ws.ID+"/members/"+memberID(t, alice, ws.ID, "alice@example.com"), nil), http.StatusConflict, nil)
+	bobID := memberID(t, alice, ws.ID, "bob@example.com")
+	alice.expect(alice.do("PATCH", "/api/workspaces/"+ws.ID+"/members/"+bobID, map[string]string{"role": "viewer"}), http.StatusOK, &ws)
+	bob.expect(bob.do("PUT", "/api/todos/"+shared.ID, map[string]any{"title": "nope"}), http.StatusForbidden, nil)
+
+	// carol leaves and loses access to the workspace's todos
+	carol.expect(carol.do("DELETE", "/api/workspaces/"+ws.ID+"/members/"+memberID(t, alice, ws.ID, "carol@example.com"), nil), http.StatusNoContent, nil)
+	carol.expect(carol.do("GET", "/api/todos/"+shared.ID, nil), http.StatusNotFound, nil)
+	var mine []workspaceResp
+	bob.expect(bob.do("GET", "/api/workspaces", nil), http.StatusOK, &mine)
+	if len(mine) != 1 || mine[0].Role != "viewer" {
+		t.Fatalf("bob's workspaces = %+v", mine)
+	}
+	bob.expect(bob.do("DELETE", "/api/workspaces/"+ws.ID, nil), http.StatusForbidden, nil)
+	alice.expect(alice.do("DELETE", "/api/workspaces/"+ws.ID, nil), http.StatusNoContent, nil)
+	bob.expect(bob.do("GET", "/api/todos/"+shared.ID, nil), http.StatusNotFound, nil)
+	bob.expect(bob.do("GET", "/api/workspaces", nil), http.StatusOK, &mine)
+	if len(mine) != 0 {
+		t.Fatalf("deleted workspace still listed: %+v", mine)
+	}
+}
+
+// memberID looks up a member's user ID by email.
+func memberID(t *testing.T, c *apiClient, workspaceID, email string) string {
+	t.Helper()
+	var ws workspaceResp
+	c.expect(c.do("GET", "/api/workspaces/"+workspaceID, nil), http.StatusOK, &ws)
+	for _, m := range ws.Members {
+		if m.Email == email {
+			return m.UserID
+		}
+	}
+	t.Fatalf("%s is not a member of %+v", email, ws)
+	return ""
+}
+
+func TestTodoAssignees(t *testing.T) {
+	h := newTestRouter(t)
+	alice := signUp(t, h, "alice@example.com")
+	bob := signUp(t, h, "bob@example.com")
+	carol := signUp(t, h, "carol@example.com")
+	mallory := signUp(t, h, "mallory@example.com")
+	var outsider struct {
+		ID string `json:"id"`
+	}
+	mallory.expect(mallory.do("GET", "/api/auth/me", nil), http.StatusOK, &outsider)
+
+	var ws workspaceResp
+	alice.expect(alice.do("POST", "/api/workspaces", map[string]string{"name": "Team"}), http.StatusCreated, &ws)
+	join(alice, bob, ws.ID, "bob@example.com", "editor")
+	join(alice, carol, ws.ID, "carol@example.com", "viewer")
+	aliceID := memberID(t, alice, ws.ID, "alice@example.com")
+	bobID := memberID(t, alice, ws.ID, "bob@example.com")
+
+	type assigned struct {
+		Assignees []struct {
+			UserID string  `json:"userId"`
+			Name   *string `json:"name"`
+		} `json:"assignees"`
+	}
+	var shared struct {
+		todoResp
+		assigned
+	}
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{
+		"title": "Ship it", "status": "TODO", "workspaceId": ws.ID,
+	}), http.StatusCreated, &shared)
+	if shared.Assignees == nil || len(shared.Assignees) != 0 {
+		t.Fatalf("new todo assignees = %+v, want []", shared.Assignees)
+	}
+
+	// assigning twice keeps a single entry
+	var got assigned
+	alice.expect(alice.do("POST", "/api/todos/"+shared.ID+"/assignees", map[string]string{"userId": bobID}), http.StatusOK, &got)
+	alice.expect(alice.do("POST", "/api/todos/"+shared.ID+"/assignees", map[string]string{"userId": bobID}), http.StatusOK, &got)
+	if len(got.Assignees) != 1 || got.Assignees[0].UserID != bobID {
+		t.Fatalf("assignees = %+v, want just bob", got.Assignees)
+	}
+
+	// the assignee must exist and be able to see the todo; viewers cannot assign
+	var e errorResp
+	alice.expect(alice.do("POST", "/api/todos/"+shared.ID+"/assignees", map[string]string{
+		"userId": "00000000-0000-0000-0000-000000000000",
+	}), http.StatusBadRequest, &e)
+	if e.Error.Fields["userId"] == "" {
+		t.Fatalf("error = %+v, want a userId field error", e)
+	}
+	alice.expect(alice.do("POST", "/api/todos/"+shared.ID+"/assignees", map[string]string{"userId": outsider.ID}), http.StatusBadRequest, nil)
+	carol.expect(carol.do("POST", "/api/todos/"+shared.ID+"/assignees", map[string]string{"userId": aliceID}), http.StatusForbidden, nil)
+
+	// a personal todo can only be assigned to its owner
+	var personal todoResp
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{"title": "Dentist", "status": "TODO"}), http.StatusCreated, &personal)
+	alice.expect(alice.do("POST", "/api/todos/"+personal.ID+"/assignees", map[string]string{"userId": bobID}), http.StatusBadRequest, nil)
+	alice.expect(alice.do("POST", "/api/todos/"+personal.ID+"/assignees", map[string]string{"userId": aliceID}), http.StatusOK, nil)
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{"title": "Unassigned", "status": "TODO"}), http.StatusCreated, nil)
+
+	// assignee=me spans personal todos and every workspace the caller is in
+	var list todoListResp
+	bob.expect(bob.do("GET", "/api/todos?assignee=me", nil), http.StatusOK, &list)
+	if list.Total != 1 || list.Items[0].ID != shared.ID {
+		t.Fatalf("bob's plate = %+v, want the shared todo", list)
+	}
+	bob.expect(bob.do("GET", "/api/todos", nil), http.StatusOK, &list)
+	if list.Total != 0 {
+		t.Fatalf("bob's personal list has %d todos", list.Total)
+	}
+	alice.expect(alice.do("GET", "/api/todos?assignee=me", nil), http.StatusOK, &list)
+	if list.Total != 1 || list.Items[0].ID != personal.ID {
+		t.Fatalf("alice's plate = %+v, want her personal todo", list)
+	}
+	alice.expect(alice.do("GET", "/api/todos?assignee="+bobID+"&workspaceId="+ws.ID, nil), http.StatusOK, &list)
+	if list.Total != 1 || list.Items[0].ID != shared.ID {
+		t.Fatalf("bob's todos in the workspace = %+v", list)
+	}
+
+	// unassigning is recorded in the history like any other change
+	bob.expect(bob.do("DELETE", "/api/todos/"+shared.ID+"/assignees/"+bobID, nil), http.StatusOK, &got)
+	if len(got.Assignees) != 0 {
+		t.Fatalf("assignees after unassigning = %+v", got.Assignees)
+	}
+	bob.expect(bob.do("DELETE", "/api/todos/"+shared.ID+"/assignees/"+bobID, nil), http.StatusNotFound, nil)
+	bob.expect(bob.do("GET", "/api/todos?assignee=me", nil), http.StatusOK, &list)
+	if list.Total != 0 {
+		t.Fatalf("bob's plate after unassigning = %+v", list)
+	}
+	var history struct {
+		Items []struct {
+			Changes []struct {
+				Field string `json:"field"`
+			} `json:"changes"`
+		} `json:"items"`
+	}
+	alice.expect(alice.do("GET", "/api/todos/"+shared.ID+"/history", nil), http.StatusOK, &history)
+	if len(history.Items) != 3 || history.Items[0].Changes[0].Field != "assigneeIds" {
+		t.Fatalf("history = %+v, want the creation and two assignee changes", history)
+	}
+}
+
+func TestTodoComments(t *testing.T) {
+	h := newTestRouter(t)
+	alice := signUp(t, h, "alice@example.com")
+	bob := signUp(t, h, "bob@example.com")
+	carol := signUp(t, h, "carol@example.com")
+	mallory := signUp(t, h, "mallory@example.com")
+
+	var ws workspaceResp
+	alice.expect(alice.do("POST", "/api/workspaces", map[string]string{"name": "Team"}), http.StatusCreated, &ws)
+	join(alice, bob, ws.ID, "bob@example.com", "editor")
+	join(alice, carol, ws.ID, "carol@example.com", "viewer")
+	var shared, other todoResp
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{
+		"title": "Ship it", "status": "TODO", "workspaceId": ws.ID,
+	}), http.StatusCreated, &shared)
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{"title": "Mine", "status": "TODO"}), http.StatusCreated, &other)
+
+	type commentResp struct {
+		ID     string `json:"id"`
+		UserID string `json:"userId"`
+		Body   string `json:"body"`
+		Edited bool   `json:"edited"`
+	}
+	type commentListResp struct {
+		Items      []commentResp `json:"items"`
+		Total      int           `json:"total"`
+		NextCursor *string       `json:"nextCursor"`
+	}
+	path := "/api/todos/" + shared.ID + "/comments"
+
+	// anyone who can see the todo may comment, viewers included
+	var first, second commentResp
+	carol.expect(carol.do("POST", path, map[string]string{"body": "  Is this for Friday?  "}), http.StatusCreated, &first)
+	if first.Body != "Is this for Friday?" || first.Edited {
+		t.Fatalf("created comment = %+v", first)
+	}
+	bob.expect(bob.do("POST", path, map[string]string{"body": "Yes"}), http.StatusCreated, &second)
+	bob.expect(bob.do("POST", path, map[string]string{"body": " "}), http.StatusBadRequest, nil)
+	mallory.expect(mallory.do("POST", path, map[string]string{"body": "hi"}), http.StatusNotFound, nil)
+	mallory.expect(mallory.do("GET", path, nil), http.StatusNotFound, nil)
+	bob.expect(bob.do("GET", "/api/todos/"+other.ID+"/comments", nil), http.StatusNotFound, nil)
+
+	// oldest first, one page at a time
+	var page commentListResp
+	alice.expect(alice.do("GET", path+"?limit=1", nil), http.StatusOK, &page)
+	if page.Total != 2 || len(page.Items) != 1 || page.Items[0].ID != first.ID || page.NextCursor == nil {
+		t.Fatalf("first page = %+v", page)
+	}
+	var last commentListResp
+	alice.expect(alice.do("GET", path+"?limit=1&cursor="+*page.NextCursor, nil), http.StatusOK, &last)
+	if len(last.Items) != 1 || last.Items[0].ID != second.ID || last.NextCursor != nil {
+		t.Fatalf("second page = %+v", last)
+	}
+
+	// only the author edits or deletes, and only an actual change marks it edited
+	bob.expect(bob.do("PATCH", path+"/"+first.ID, map[string]string{"body": "nope"}), http.StatusForbidden, nil)
+	bob.expect(bob.do("DELETE", path+"/"+first.ID, nil), http.StatusForbidden, nil)
+	var edited commentResp
+	carol.expect(carol.do("PATCH", path+"/"+first.ID, map[string]string{"body": "Is this for Friday?"}), http.StatusOK, &edited)
+	if edited.Edited {
+		t.Fatalf("saving the same body marked the comment edited: %+v", edited)
+	}
+	carol.expect(carol.do("PATCH", path+"/"+first.ID, map[string]string{"body": "Is this for Monday?"}), http.StatusOK, &edited)
+	if !edited.Edited || edited.Body != "Is this for Monday?" {
+		t.Fatalf("edited comment = %+v", edited)
+	}
+	// a comment is only reachable under its own todo
+	alice.expect(alice.do("DELETE", "/api/todos/"+other.ID+"/comments/"+first.ID, nil), http.StatusNotFound, nil)
+
+	carol.expect(carol.do("DELETE", path+"/"+first.ID, nil), http.StatusNoContent, nil)
+	carol.expect(carol.do("DELETE", path+"/"+first.ID, nil), http.StatusNotFound, nil)
+	alice.expect(alice.do("GET", path, nil), http.StatusOK, &page)
+	if page.Total != 1 || page.Items[0].ID != second.ID {
+		t.Fatalf("comments after delete = %+v", page)
+	}
+
+	// leaving the workspace takes the thread with it
+	carol.expect(carol.do("DELETE", "/api/workspaces/"+ws.ID+"/members/"+memberID(t, alice, ws.ID, "carol@example.com"), nil), http.StatusNoContent, nil)
+	carol.expect(carol.do("GET", path, nil), http.StatusNotFound, nil)
+}
+
+// upload sends data as the "file" field of a multipart form.
+func (c *apiClient) upload(method, path, fileName string, data []byte) *httptest.ResponseRecorder {
+	c.t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile("file", fileName)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	part.Write(data)
+	mw.Close()
+	return c.doRaw(method, path, mw.FormDataContentType(), buf.String())
+}
+
+func TestTodoAttachments(t *testing.T) {
+	container := newTestContainer(t, nil)
+	h := newRouter(container, testOrigin)
+	alice := signUp(t, h, "alice@example.com")
+	bob := signUp(t, h, "bob@example.com")
+	carol := signUp(t, h, "carol@example.com")
+	mallory := signUp(t, h, "mallory@example.com")
+	anon := &apiClient{t: t, h: h}
+
+	var ws workspaceResp
+	alice.expect(alice.do("POST", "/api/workspaces", map[string]string{"name": "Team"}), http.StatusCreated, &ws)
+	join(alice, bob, ws.ID, "bob@example.com", "editor")
+	join(alice, carol, ws.ID, "carol@example.com", "viewer")
+	var shared, mine todoResp
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{
+		"title": "Ship it", "status": "TODO", "workspaceId": ws.ID,
+	}), http.StatusCreated, &shared)
+	alice.expect(alice.do("POST", "/api/todos", map[string]any{"title": "Mine", "status": "TODO"}), http.StatusCreated, &mine)
+
+	type attachmentResp struct {
+		ID          string `json:"id"`
+		UserID      string `json:"userId"`
+		FileName    string `json:"fileName"`
+		ContentType string `json:"contentType"`
+		Size        int64  `json:"size"`
+	}
+	path := "/api/todos/" + shared.ID + "/attachments"
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+
+	// the type is sniffed and any directory part of the name dropped
+	var shot attachmentResp
+	bob.expect(bob.upload("POST", path, `C:\Users\bob\shot.png`, png), http.StatusCreated, &shot)
+	if shot.FileName != "shot.png" || shot.ContentType != "image/png" || shot.Size != int64(len(png)) {
+		t.Fatalf("uploaded attachment = %+v", shot)
+	}
+	var notes attachmentResp
+	alice.expect(alice.upload("POST", path, "notes.txt", []byte("remember the milk")), http.StatusCreated, &notes)
+	if notes.ContentType != "text/plain" {
+		t.Fatalf("notes content type = %q", notes.ContentType)
+	}
+
+	// viewers may only look, outsiders not even that
+	carol.expect(carol.upload("POST", path, "a.png", png), http.StatusForbidden, nil)
+	mallory.expect(mallory.upload("POST", path, "a.png", png), http.StatusNotFound, nil)
+	mallory.expect(mallory.do("GET", path, nil), http.StatusNotFound, nil)
+
+	// size and type limits
+	var bad errorResp
+	bob.expect(bob.upload("POST", path, "setup.exe", append([]byte("MZ\x90\x00"), png...)), http.StatusBadRequest, nil)
+	bob.expect(bob.upload("POST", path, "big.txt", bytes.Repeat([]byte("a"), entity.MaxAttachmentSize+1)), http.StatusBadRequest, nil)
+	bob.expect(bob.upload("POST", path, "empty.txt", nil), http.StatusBadRequest, nil)
+	bob.expect(bob.doRaw("POST", path, "application/json", `{}`), http.StatusBadRequest, &bad)
+	if _, ok := bad.Error.Fields["file"]; !ok {
+		t.Fatalf("missing file error = %+v", bad)
+	}
+
+	var list []attachmentResp
+	carol.expect(carol.do("GET", path, nil), http.StatusOK, &list)
+	if len(list) != 2 || list[0].ID != shot.ID || list[1].ID != notes.ID {
+		t.Fatalf("attachments = %+v", list)
+	}
+
+	// downloads redirect to a signed URL that needs no session
+	rec := carol.do("GET", path+"/"+shot.ID+"/download", nil)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("download status = %d, body %s", rec.Code, rec.Body)
+	}
+	signed := rec.Header().Get("Location")
+	rec = anon.do("GET", signed, nil)
+	if rec.Code != http.StatusOK || !bytes.Equal(rec.Body.Bytes(), png) {
+		t.Fatalf("signed URL status = %d, %d bytes", rec.Code, rec.Body.Len())
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.Contains(cd, "shot.png") {
+		t.Fatalf("Content-Disposition = %q", cd)
+	}
+	anon.expect(anon.do("GET", strings.Replace(signed, "sig=", "sig=0", 1), nil), http.StatusNotFound, nil)
+	anon.expect(anon.do("GET", path+"/"+shot.ID+"/download", nil), http.StatusUnauthorized, nil)
+	// an attachment is only reachable under its own todo
+	alice.expect(alice.do("GET", "/api/todos/"+mine.ID+"/attachments/"+shot.ID+"/download", nil), http.StatusNotFound, nil)
+
+	carol.expect(carol.do("DELETE", path+"/"+shot.ID, nil), http.StatusForbidden, nil)
+	alice.expect(alice.do("DELETE", path+"/"+shot.ID, nil), http.StatusNoContent, nil)
+	alice.expect(alice.do("DELETE", path+"/"+shot.ID, nil), http.StatusNotFound, nil)
+	anon.expect(anon.do("GET", signed, nil), http.StatusNotFound, nil)
+
+	// purging a todo from the trash removes its files on the next sweep
+	var receipt attachmentResp
+	alice.expect(alice.upload("POST", "/api/todos/"+mine.ID+"/attachments", "receipt.pdf", []byte("%PDF-1.4 ...")), http.StatusCreated, &receipt)
+	rec = alice.do("GET", "/api/todos/"+mine.ID+"/attachments/"+receipt.ID+"/download", nil)
+	receiptURL := rec.Header().Get("Location")
+	alice.expect(alice.do("DELETE", "/api/todos/"+mine.ID, nil), http.StatusNoContent, nil)
+	ctx := context.Background()
+	if n, err := container.SweepAttachments.Execute(ctx); err != nil || n != 0 {
+		t.Fatalf("sweep of trashed todo = %d, %v; want nothing removed", n, err)
+	}
+	anon.expect(anon.do("GET", receiptURL, nil), http.StatusOK, nil)
+	if _, err := container.PurgeTrash.Execute(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	// deleting the workspace takes its todos' files too
+	alice.expect(alice.do("DELETE", "/api/workspaces/"+ws.ID, nil), http.StatusNoContent, nil)
+	if n, err := container.SweepAttachments.Execute(ctx); err != nil || n != 2 {
+		t.Fatalf("sweep = %d, %v; want 2 removed", n, err)
+	}
+	anon.expect(anon.do("GET", receiptURL, nil), http.StatusNotFound, nil)
+}
+
+// exifJPEG encodes img as a JPEG carrying EXIF metadata: the given
+// orientation and a description that must not survive processing.
+func exifJPEG(t *testing.T, img image.Image, orientation uint16, description string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	desc := append([]byte(description), 0)
+	be := binary.BigEndian
+	// TIFF header, then one IFD: ImageDescription (ASCII, stored after the
+	// IFD) and Orientation (SHORT, stored inline)
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = be.AppendUint16(tiff, 2)
+	tiff = append(tiff, 0x01, 0x0E, 0, 2)
+	tiff = be.AppendUint32(tiff, uint32(len(desc)))
+	tiff = be.AppendUint32(tiff, 8+2+2*12+4)
+	tiff = append(tiff, 0x01, 0x12, 0, 3, 0, 0, 0, 1)
+	tiff = be.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0) // value padding, no next IFD
+	tiff = append(tiff, desc...)
+
+	app1 := append([]byte("Exif\x00\x00"), tiff...)
+	seg := be.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(app1)+2))
+	data := buf.Bytes()
+	out := append([]byte{}, data[:2]...) // SOI
+	out = append(append(out, seg...), app1...)
+	return append(out, data[2:]...)
+}
+
+// fetchAvatar follows an avatar URL through its redirect and decodes the thumbnail.
+func fetchAvatar(t *testing.T, c *apiClient, url string) (image.Image, string, []byte) {
+	t.Helper()
+	rec := c.do("GET", url, nil)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("GET %s = %d, body %s", url, rec.Code, rec.Body)
+	}
+	rec = c.do("GET", rec.Header().Get("Location"), nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("signed avatar URL = %d", rec.Code)
+	}
+	body := rec.Body.Bytes()
+	img, format, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("thumbnail does not decode: %v", err)
+	}
+	return img, format, body
+}
+
+func TestUserAvatar(t *testing.T) {
+	h := newTestRouter(t)
+	c := signUp(t, h, "ava@example.com")
+	anon := &apiClient{t: t, h: h}
+
+	type userResp struct {
+		ID        string  `json:"id"`
+		AvatarURL *string `json:"avatarUrl"`
+	}
+
+	// a landscape photo, red on the left and blue on the right, taken with
+	// the camera turned: EXIF orientation 6 means "rotate clockwise"
+	photo := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 300; x++ {
+			if x < 150 {
+				photo.Set(x, y, color.RGBA{255, 0, 0, 255})
+			} else {
+				photo.Set(x, y, color.RGBA{0, 0, 255, 255})
+			}
+		}
+	}
+	var me userResp
+	c.expect(c.do("GET", "/api/auth/me", nil), http.StatusOK, &me)
+	var td todoResp
+	c.expect(c.do("POST", "/api/todos", map[string]string{"title": "Mine"}), http.StatusCreated, &td)
+	c.expect(c.do("POST", "/api/todos/"+td.ID+"/assignees", map[string]string{"userId": me.ID}), http.StatusOK, nil)
+	listRec := c.do("GET", "/api/todos", nil)
+	c.expect(listRec, http.StatusOK, nil)
+	todoRec := c.do("GET", "/api/todos/"+td.ID, nil)
+	c.expect(todoRec, http.StatusOK, nil)
+
+	c.expect(c.upload("PUT", "/api/users/me/avatar", "me.jpg", exifJPEG(t, photo, 6, "secret GPS 35.6N")), http.StatusOK, &me)
+	if me.AvatarURL == nil || !strings.HasPrefix(*me.AvatarURL, "/api/users/"+me.ID+"/avatar/") {
+		t.Fatalf("avatarUrl = %v", me.AvatarURL)
+	}
+	// the todo's version stays put, but its assignee now shows the new avatar
+	ifNoneMatch := http.Header{"If-None-Match": {listRec.Header().Get("ETag")}}
+	c.expect(c.doWith("GET", "/api/todos", nil, ifNoneMatch), http.StatusOK, nil)
+	staleTag := todoRec.Header().Get("ETag")
+	todoRec = c.doWith("GET", "/api/todos/"+td.ID, nil, http.Header{"If-None-Match": {staleTag}})
+	c.expect(todoRec, http.StatusOK, nil)
+	if todoRec.Header().Get("ETag") == staleTag {
+		t.Fatalf("todo ETag %s did not change with the assignee's avatar", staleTag)
+	}
+	// the avatar is not a conflicting write: If-Match still goes by the version
+	c.expect(c.doWith("PUT", "/api/todos/"+td.ID, map[string]any{"title": "Still mine"}, http.Header{"If-Match": {staleTag}}), http.StatusOK, nil)
+	first := *me.AvatarURL
+
+	// served without a session, square, upright and stripped of metadata
+	img, format, body := fetchAvatar(t, anon, first)
+	if format != "jpeg" || img.Bounds() != image.Rect(0, 0, 256, 256) {
+		t.Fatalf("thumbnail = %s %v", format, img.Bounds())
+	}
+	if bytes.Contains(body, []byte("secret")) || bytes.Contains(body, []byte("Exif")) {
+		t.Fatal("thumbnail kept the upload's metadata")
+	}
+	if r, _, b, _ := img.At(128, 10).RGBA(); r>>8 < 200 || b>>8 > 60 {
+		t.Fatalf("top of thumbnail = %v, want red after turning", img.At(128, 10))
+	}
+	if r, _, b, _ := img.At(128, 245).RGBA(); b>>8 < 200 || r>>8 > 60 {
+		t.Fatalf("bottom of thumbnail = %v, want blue after turning", img.At(128, 245))
+	}
+	if img, _, _ := fetchAvatar(t, anon, first+"?size=64"); img.Bounds() != image.Rect(0, 0, 64, 64) {
+		t.Fatalf("small thumbnail = %v", img.Bounds())
+	}
+	anon.expect(anon.do("GET", first+"?size=100", nil), http.StatusBadRequest, nil)
+	anon.expect(anon.do("GET", "/api/users/"+me.ID+"/avatar/guessed", nil), http.StatusNotFound, nil)
+
+	var got userResp
+	c.expect(c.do("GET", "/api/auth/me", nil), http.StatusOK, &got)
+	if got.AvatarURL == nil || *got.AvatarURL != first {
+		t.Fatalf("/auth/me avatarUrl = %v, want %s", got.AvatarURL, first)
+	}
+
+	// transparency survives as a PNG
+	clear := image.NewNRGBA(image.Rect(0, 0, 40, 40))
+	var pngBuf bytes.Buffer
+	png.Encode(&pngBuf, clear)
+	c.expect(c.upload("PUT", "/api/users/me/avatar", "clear.png", pngBuf.Bytes()), http.StatusOK, &me)
+	if _, format, _ := fetchAvatar(t, anon, *me.AvatarURL); format != "png" {
+		t.Fatalf("transparent avatar served as %s", format)
+	}
+	// the previous upload is gone
+	anon.expect(anon.do("GET", first, nil), http.StatusNotFound, nil)
+
+	webp, _ := base64.StdEncoding.DecodeString("UklGRiIAAABXRUJQVlA4IBYAAAAwAQCdASoBAAEADsD+JaQAA3AAAAAA")
+	c.expect(c.upload("PUT", "/api/users/me/avatar", "dot.webp", webp), http.StatusOK, &me)
+	if img, _, _ := fetchAvatar(t, anon, *me.AvatarURL); img.Bounds().Dx() != 256 {
+		t.Fatalf("webp thumbnail = %v", img.Bounds())
+	}
+
+	// only real PNG, JPEG or WebP images
+	var bad errorResp
+	c.expect(c.upload("PUT", "/api/users/me/avatar", "a.gif", []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")), http.StatusBadRequest, &bad)
+	if _, ok := bad.Error.Fields["file"]; !ok {
+		t.Fatalf("gif error = %+v", bad)
+	}
+	c.expect(c.upload("PUT", "/api/users/me/avatar", "cut.png", pngBuf.Bytes()[:40]), http.StatusBadRequest, nil)
+	c.expect(c.upload("PUT", "/api/users/me/avatar", "me.png", []byte("not an image")), http.StatusBadRequest, nil)
+	c.expect(c.upload("PUT", "/api/users/me/avatar", "big.png", bytes.Repeat([]byte{0}, 5<<20+1)), http.StatusBadRequest, nil)
+	// a few kilobytes on disk, but too many pixels to decode
+	pngBuf.Reset()
+	if err := png.Encode(&pngBuf, image.NewGray(image.Rect(0, 0, 4200, 4000))); err != nil {
+		t.Fatal(err)
+	}
+	bad = errorResp{}
+	c.expect(c.upload("PUT", "/api/users/me/avatar", "huge.png", pngBuf.Bytes()), http.StatusBadRequest, &bad)
+	if !strings.Contains(bad.Error.Fields["file"], "megapixels") {
+		t.Fatalf("huge image error = %+v", bad)
+	}
+	anon.expect(anon.upload("PUT", "/api/users/me/avatar", "me.png", pngBuf.Bytes()), http.StatusUnauthorized, nil)
+}
@@ -1,72 +1,365 @@
 package di
 
 import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/application/attachment"
+	"github.com/ariangn/todo-fullstack/backend/application/calendar"
 	"github.com/ariangn/todo-fullstack/backend/application/category"
+	"github.com/ariangn/todo-fullstack/backend/application/checklist"
+	"github.com/ariangn/todo-fullstack/backend/application/comment"
+	"github.com/ariangn/todo-fullstack/backend/application/notification"
+	"github.com/ariangn/todo-fullstack/backend/application/reminder"
+	"github.com/ariangn/todo-fullstack/backend/application/session"
 	"github.com/ariangn/todo-fullstack/backend/application/tag"
 	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/application/transfer"
 	"github.com/ariangn/todo-fullstack/backend/application/user"
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/blob"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/notify"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/blobstore"
 	"github.com/ariangn/todo-fullstack/backend/infrastructure/database"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/eventbus"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/memory"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/notifier"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/handler"
 )
 
 type Container struct {
-	AuthClient         auth.AuthClientInterface
-	UserController     *handler.UserController
-	TodoController     *handler.TodoController
-	CategoryController *handler.CategoryController
-	TagController      *handler.TagController
+	AuthClient auth.AuthClientInterface
+	// Sessions lets AuthMiddleware reject tokens of revoked sessions
+	Sessions            repository.SessionRepository
+	UserController      *handler.UserController
+	TodoController      *handler.TodoController
+	CategoryController  *handler.CategoryController
+	TagController       *handler.TagController
+	ChecklistController *handler.ChecklistController
+	SessionController   *handler.SessionController
+	EventController     *handler.EventController
+	ActivityController  *handler.ActivityController
+	ReminderController  *handler.ReminderController
+	// NotificationController serves the in-app reminder inbox
+	NotificationController *handler.NotificationController
+	CalendarController     *handler.CalendarController
+	TransferController     *handler.TransferController
+	WorkspaceController    *handler.WorkspaceController
+	CommentController      *handler.CommentController
+	AttachmentController   *handler.AttachmentController
+	// BlobServer serves the signed URLs of a local blob store at BlobURLPrefix;
+	// nil when blobs live in Supabase Storage, which serves its own
+	BlobServer http.Handler
+	// PurgeTrash is run periodically by main to empty old trash
+	PurgeTrash todo.PurgeTrashUseCase
+	// SweepAttachments is run after each purge to remove the files of deleted todos
+	SweepAttachments attachment.SweepUseCase
+	// DispatchReminders is run periodically by main to deliver due reminders
+	DispatchReminders reminder.DispatchUseCase
 }
 
+// BlobURLPrefix is where a local blob store's signed URLs point.
+const BlobURLPrefix = "/api/blobs"
+
 func InitializeContainer() (*Container, error) {
 	// ─── (1) Auth Client ───────────────────────────────────────────────────────
 	// NewAuthClient no longer takes any arguments
 	authClient := auth.NewAuthClient()
 
-	// ─── (2) Supabase / DB Client ─────────────────────────────────────────────
-	// NewSupabaseClient now returns ( *SupabaseClient, error )
-	supabaseClient, err := database.NewSupabaseClient()
+	// ─── (2) Storage Driver & (3) Repositories ────────────────────────────────
+	// STORAGE_DRIVER=memory keeps everything in-process (no Supabase needed);
+	// anything else, including unset, uses Supabase.
+	var repos Repositories
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", "supabase":
+		// NewSupabaseClient now returns ( *SupabaseClient, error )
+		supabaseClient, err := database.NewSupabaseClient()
+		if err != nil {
+			return nil, err
+		}
+		repos.User = database.NewUserRepository(supabaseClient)
+		repos.Todo = database.NewTodoRepository(supabaseClient)
+		repos.Category = database.NewCategoryRepository(supabaseClient)
+		repos.Tag = database.NewTagRepository(supabaseClient)
+		repos.ChecklistItem = database.NewChecklistItemRepository(supabaseClient)
+		repos.Session = database.NewSessionRepository(supabaseClient)
+		repos.Activity = database.NewActivityRepository(supabaseClient)
+		repos.Reminder = database.NewReminderRepository(supabaseClient)
+		repos.Notification = database.NewNotificationRepository(supabaseClient)
+		repos.CalendarFeed = database.NewCalendarFeedRepository(supabaseClient)
+		repos.Workspace = database.NewWorkspaceRepository(supabaseClient)
+		repos.WorkspaceInvitation = database.NewWorkspaceInvitationRepository(supabaseClient)
+		repos.Comment = database.NewCommentRepository(supabaseClient)
+		repos.Attachment = database.NewAttachmentRepository(supabaseClient)
+		// files go to a private Storage bucket, STORAGE_BUCKET (default "todo-files")
+		bucket := os.Getenv("STORAGE_BUCKET")
+		if bucket == "" {
+			bucket = "todo-files"
+		}
+		repos.Blobs = database.NewBlobStore(supabaseClient, bucket)
+	case "memory":
+		store := memory.NewStore()
+		repos.User = memory.NewUserRepository(store)
+		repos.Todo = memory.NewTodoRepository(store)
+		repos.Category = memory.NewCategoryRepository(store)
+		repos.Tag = memory.NewTagRepository(store)
+		repos.ChecklistItem = memory.NewChecklistItemRepository(store)
+		repos.Session = memory.NewSessionRepository(store)
+		repos.Activity = memory.NewActivityRepository(store)
+		repos.Reminder = memory.NewReminderRepository(store)
+		repos.Notification = memory.NewNotificationRepository(store)
+		repos.CalendarFeed = memory.NewCalendarFeedRepository(store)
+		repos.Workspace = memory.NewWorkspaceRepository(store)
+		repos.WorkspaceInvitation = memory.NewWorkspaceInvitationRepository(store)
+		repos.Comment = memory.NewCommentRepository(store)
+		repos.Attachment = memory.NewAttachmentRepository(store)
+		// files go under BLOB_DIR, or a fresh temporary directory since
+		// the records pointing at them are lost on restart anyway
+		dir := os.Getenv("BLOB_DIR")
+		if dir == "" {
+			var err error
+			if dir, err = os.MkdirTemp("", "todo-blobs-"); err != nil {
+				return nil, err
+			}
+		}
+		blobs, err := blobstore.NewFileSystem(dir, BlobURLPrefix)
+		if err != nil {
+			return nil, err
+		}
+		repos.Blobs = blobs
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q (want \"supabase\" or \"memory\")", driver)
+	}
+
+	notifiers, err := externalNotifiers()
 	if err != nil {
 		return nil, err
 	}
 
-	// ─── (3) Repositories ─────────────────────────────────────────────────────
-	userRepo := database.NewUserRepository(supabaseClient)
-	todoRepo := database.NewTodoRepository(supabaseClient)
-	categoryRepo := database.NewCategoryRepository(supabaseClient)
-	tagRepo := database.NewTagRepository(supabaseClient)
+	// API_ORIGIN is where browsers reach this server (default
+	// http://localhost:8080); uploaded avatars' URLs are built on it
+	apiOrigin := os.Getenv("API_ORIGIN")
+	if apiOrigin == "" {
+		apiOrigin = "http://localhost:8080"
+	}
+
+	return NewContainer(authClient, repos, notifiers, apiOrigin), nil
+}
+
+// externalNotifiers builds the reminder channels that leave the app. Each is
+// enabled by its own settings: REMINDER_WEBHOOK_URL for webhooks, SMTP_HOST
+// for email. In-app reminders need no settings and are always available.
+func externalNotifiers() (map[entity.ReminderChannel]notify.Notifier, error) {
+	notifiers := map[entity.ReminderChannel]notify.Notifier{}
+
+	if url := os.Getenv("REMINDER_WEBHOOK_URL"); url != "" {
+		notifiers[entity.ChannelWebhook] = notifier.NewWebhook(url, os.Getenv("REMINDER_WEBHOOK_SECRET"))
+	}
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port := "587"
+		if v := os.Getenv("SMTP_PORT"); v != "" {
+			if p, err := strconv.Atoi(v); err != nil || p < 1 || p > 65535 {
+				return nil, fmt.Errorf("SMTP_PORT must be a port number, got %q", v)
+			}
+			port = v
+		}
+		from := os.Getenv("SMTP_FROM")
+		if from == "" {
+			return nil, fmt.Errorf("SMTP_FROM must be set when SMTP_HOST is")
+		}
+		notifiers[entity.ChannelEmail] = notifier.NewSMTP(notifier.SMTPConfig{
+			Host:     host,
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     from,
+		})
+	}
+
+	return notifiers, nil
+}
+
+// Repositories is the storage backend a Container is wired over.
+type Repositories struct {
+	User                repository.UserRepository
+	Todo                repository.TodoRepository
+	Category            repository.CategoryRepository
+	Tag                 repository.TagRepository
+	ChecklistItem       repository.ChecklistItemRepository
+	Session             repository.SessionRepository
+	Activity            repository.ActivityRepository
+	Reminder            repository.ReminderRepository
+	Notification        repository.NotificationRepository
+	CalendarFeed        repository.CalendarFeedRepository
+	Workspace           repository.WorkspaceRepository
+	WorkspaceInvitation repository.WorkspaceInvitationRepository
+	Comment             repository.CommentRepository
+	Attachment          repository.AttachmentRepository
+	// Blobs holds attachment contents. A store that is also an http.Handler
+	// serves its own signed URLs and is mounted at BlobURLPrefix.
+	Blobs blob.BlobStore
+}
+
+// NewContainer wires use cases and controllers over already-built dependencies.
+// InitializeContainer uses it after reading the environment; tests call it directly.
+// notifiers holds the external reminder channels (email, webhook); the in-app
+// channel is always added over repos.Notification. apiOrigin is the scheme and
+// host that browsers reach this server at.
+func NewContainer(
+	authClient auth.AuthClientInterface,
+	repos Repositories,
+	notifiers map[entity.ReminderChannel]notify.Notifier,
+	apiOrigin string,
+) *Container {
+	userRepo := repos.User
+	todoRepo := repos.Todo
+	categoryRepo := repos.Category
+	tagRepo := repos.Tag
+	checklistItemRepo := repos.ChecklistItem
+	sessionRepo := repos.Session
+	activityRepo := repos.Activity
+	reminderRepo := repos.Reminder
+	notificationRepo := repos.Notification
+	calendarFeedRepo := repos.CalendarFeed
+	workspaceRepo := repos.Workspace
+	invitationRepo := repos.WorkspaceInvitation
+	commentRepo := repos.Comment
+	attachmentRepo := repos.Attachment
+	blobs := repos.Blobs
+
+	// use cases publish their writes here; EventController streams them to clients
+	bus := eventbus.NewBus()
+	// todo, category and tag use cases also record each write in activityRepo,
+	// and check workspaceRepo before touching a workspace's records
+
+	channels := map[entity.ReminderChannel]notify.Notifier{
+		entity.ChannelInApp: notifier.NewInApp(notificationRepo, bus),
+	}
+	for ch, n := range notifiers {
+		channels[ch] = n
+	}
+
+	// ─── (4) Session & User Use‐Cases ──────────────────────────────────────────
+	startSessionUC := session.NewStartUseCase(sessionRepo, authClient)
+	refreshSessionUC := session.NewRefreshUseCase(sessionRepo, authClient)
+	listSessionUC := session.NewListUseCase(sessionRepo)
+	revokeSessionUC := session.NewRevokeUseCase(sessionRepo)
+	revokeAllSessionUC := session.NewRevokeAllUseCase(sessionRepo)
+	logoutUC := session.NewLogoutUseCase(sessionRepo)
 
-	// ─── (4) User Use‐Cases ────────────────────────────────────────────────────
 	registerUC := user.NewRegisterUseCase(userRepo)
-	// LoginUseCase expects (UserRepository, AuthClientInterface)
-	loginUC := user.NewLoginUseCase(userRepo, authClient)
+	// LoginUseCase expects (UserRepository, session.StartUseCase)
+	loginUC := user.NewLoginUseCase(userRepo, startSessionUC)
 	// FindByIDUseCase expects (UserRepository)
 	findByIDUC := user.NewFindByIDUseCase(userRepo)
+	// avatars are stored as thumbnails alongside attachments in blobs
+	setAvatarUC := user.NewSetAvatarUseCase(userRepo, blobs, apiOrigin)
+	avatarUC := user.NewAvatarUseCase(userRepo, blobs)
 
 	// ─── (5) Todo Use‐Cases ────────────────────────────────────────────────────
-	// Note: NewCreateUseCase requires (TodoRepository, CategoryRepository, TagRepository)
-	createTodoUC := todo.NewCreateUseCase(todoRepo, categoryRepo, tagRepo)
-	listTodoUC := todo.NewListUseCase(todoRepo)
-	findTodoByIDUC := todo.NewFindByIDUseCase(todoRepo)
-	updateTodoUC := todo.NewUpdateUseCase(todoRepo)
-	toggleStatusUC := todo.NewToggleStatusUseCase(todoRepo)
-	deleteTodoUC := todo.NewDeleteUseCase(todoRepo)
-	duplicateTodoUC := todo.NewDuplicateUseCase(todoRepo)
+	// Note: NewCreateUseCase requires (TodoRepository, CategoryRepository, TagRepository, WorkspaceRepository, ActivityRepository, event.Publisher)
+	// Update and Bulk also move pending reminders when a due date changes
+	createTodoUC := todo.NewCreateUseCase(todoRepo, categoryRepo, tagRepo, workspaceRepo, activityRepo, bus)
+	listTodoUC := todo.NewListUseCase(todoRepo, workspaceRepo)
+	findTodoByIDUC := todo.NewFindByIDUseCase(todoRepo, workspaceRepo)
+	updateTodoUC := todo.NewUpdateUseCase(todoRepo, categoryRepo, tagRepo, userRepo, workspaceRepo, activityRepo, reminderRepo, bus)
+	toggleStatusUC := todo.NewToggleStatusUseCase(todoRepo, userRepo, workspaceRepo, activityRepo, bus)
+	moveTodoUC := todo.NewMoveUseCase(todoRepo, userRepo, workspaceRepo, activityRepo, bus)
+	deleteTodoUC := todo.NewDeleteUseCase(todoRepo, workspaceRepo, activityRepo, bus)
+	duplicateTodoUC := todo.NewDuplicateUseCase(todoRepo, categoryRepo, tagRepo, workspaceRepo, activityRepo, bus)
+	searchTodoUC := todo.NewSearchUseCase(todoRepo, workspaceRepo)
+	bulkTodoUC := todo.NewBulkUseCase(todoRepo, categoryRepo, tagRepo, userRepo, workspaceRepo, activityRepo, reminderRepo, bus)
+	listTrashUC := todo.NewListTrashUseCase(todoRepo, workspaceRepo)
+	restoreTodoUC := todo.NewRestoreUseCase(todoRepo, workspaceRepo, activityRepo, bus)
+	purgeTrashUC := todo.NewPurgeTrashUseCase(todoRepo)
+	todoHistoryUC := todo.NewHistoryUseCase(todoRepo, workspaceRepo, activityRepo)
+	assignTodoUC := todo.NewAssignUseCase(todoRepo, userRepo, workspaceRepo, activityRepo, bus)
+	unassignTodoUC := todo.NewUnassignUseCase(todoRepo, workspaceRepo, activityRepo, bus)
 
 	// ─── (6) Category Use‐Cases ────────────────────────────────────────────────
-	createCategoryUC := category.NewCreateUseCase(categoryRepo)
-	listCategoryUC := category.NewListUseCase(categoryRepo)
-	updateCategoryUC := category.NewUpdateUseCase(categoryRepo)
-	deleteCategoryUC := category.NewDeleteUseCase(categoryRepo)
+	createCategoryUC := category.NewCreateUseCase(categoryRepo, workspaceRepo, activityRepo, bus)
+	listCategoryUC := category.NewListUseCase(categoryRepo, workspaceRepo)
+	updateCategoryUC := category.NewUpdateUseCase(categoryRepo, workspaceRepo, activityRepo, bus)
+	deleteCategoryUC := category.NewDeleteUseCase(categoryRepo, workspaceRepo, activityRepo, bus)
 
 	// ─── (7) Tag Use‐Cases ─────────────────────────────────────────────────────
-	createTagUC := tag.NewCreateUseCase(tagRepo)
-	listTagUC := tag.NewListUseCase(tagRepo)
-	updateTagUC := tag.NewUpdateUseCase(tagRepo)
-	deleteTagUC := tag.NewDeleteUseCase(tagRepo)
+	createTagUC := tag.NewCreateUseCase(tagRepo, workspaceRepo, activityRepo, bus)
+	listTagUC := tag.NewListUseCase(tagRepo, workspaceRepo)
+	updateTagUC := tag.NewUpdateUseCase(tagRepo, workspaceRepo, activityRepo, bus)
+	deleteTagUC := tag.NewDeleteUseCase(tagRepo, workspaceRepo, activityRepo, bus)
+
+	// ─── (7b) Checklist Use‐Cases ─────────────────────────────────────────────
+	// UpdateUseCase completes the parent through ToggleStatusUseCase so
+	// recurring todos still spawn their next occurrence
+	createItemUC := checklist.NewCreateUseCase(todoRepo, workspaceRepo, checklistItemRepo, bus)
+	listItemUC := checklist.NewListUseCase(todoRepo, workspaceRepo, checklistItemRepo)
+	updateItemUC := checklist.NewUpdateUseCase(todoRepo, workspaceRepo, checklistItemRepo, toggleStatusUC, bus)
+	reorderItemUC := checklist.NewReorderUseCase(todoRepo, workspaceRepo, checklistItemRepo, bus)
+	deleteItemUC := checklist.NewDeleteUseCase(todoRepo, workspaceRepo, checklistItemRepo, bus)
+
+	// ─── (7c) Activity Use‐Cases ──────────────────────────────────────────────
+	listActivityUC := activity.NewListUseCase(activityRepo)
+
+	// ─── (7d) Reminder & Notification Use‐Cases ───────────────────────────────
+	createReminderUC := reminder.NewCreateUseCase(todoRepo, userRepo, workspaceRepo, reminderRepo, channels)
+	listReminderUC := reminder.NewListUseCase(todoRepo, workspaceRepo, reminderRepo)
+	deleteReminderUC := reminder.NewDeleteUseCase(todoRepo, workspaceRepo, reminderRepo)
+	dispatchReminderUC := reminder.NewDispatchUseCase(todoRepo, userRepo, workspaceRepo, reminderRepo, channels)
+	listNotificationUC := notification.NewListUseCase(notificationRepo)
+	markNotificationReadUC := notification.NewMarkReadUseCase(notificationRepo)
+
+	// ─── (7e) Calendar Feed Use‐Cases ─────────────────────────────────────────
+	getCalendarFeedUC := calendar.NewGetUseCase(calendarFeedRepo)
+	rotateCalendarFeedUC := calendar.NewRotateUseCase(calendarFeedRepo)
+	revokeCalendarFeedUC := calendar.NewRevokeUseCase(calendarFeedRepo)
+	calendarFeedUC := calendar.NewFeedUseCase(calendarFeedRepo, userRepo, todoRepo, categoryRepo, tagRepo)
+
+	// ─── (7f) Import & Export Use‐Cases ───────────────────────────────────────
+	exportUC := transfer.NewExportUseCase(todoRepo, categoryRepo, tagRepo, userRepo)
+	importUC := transfer.NewImportUseCase(todoRepo, categoryRepo, tagRepo, userRepo, activityRepo, bus)
+
+	// ─── (7g) Workspace Use‐Cases ─────────────────────────────────────────────
+	createWorkspaceUC := workspace.NewCreateUseCase(workspaceRepo)
+	listWorkspaceUC := workspace.NewListUseCase(workspaceRepo)
+	getWorkspaceUC := workspace.NewGetUseCase(workspaceRepo, userRepo)
+	updateWorkspaceUC := workspace.NewUpdateUseCase(workspaceRepo)
+	deleteWorkspaceUC := workspace.NewDeleteUseCase(workspaceRepo)
+	inviteMemberUC := workspace.NewInviteUseCase(workspaceRepo, invitationRepo, userRepo)
+	listInvitationsUC := workspace.NewListInvitationsUseCase(workspaceRepo, invitationRepo)
+	receivedInvitationsUC := workspace.NewReceivedInvitationsUseCase(workspaceRepo, invitationRepo, userRepo)
+	acceptInvitationUC := workspace.NewAcceptInvitationUseCase(workspaceRepo, invitationRepo, userRepo)
+	deleteInvitationUC := workspace.NewDeleteInvitationUseCase(workspaceRepo, invitationRepo, userRepo)
+	updateMemberUC := workspace.NewUpdateMemberUseCase(workspaceRepo)
+	removeMemberUC := workspace.NewRemoveMemberUseCase(workspaceRepo)
+
+	// ─── (7h) Comment Use‐Cases ───────────────────────────────────────────────
+	createCommentUC := comment.NewCreateUseCase(todoRepo, workspaceRepo, commentRepo, bus)
+	listCommentUC := comment.NewListUseCase(todoRepo, workspaceRepo, commentRepo)
+	updateCommentUC := comment.NewUpdateUseCase(todoRepo, workspaceRepo, commentRepo, bus)
+	deleteCommentUC := comment.NewDeleteUseCase(todoRepo, workspaceRepo, commentRepo, bus)
+
+	// ─── (7i) Attachment Use‐Cases ────────────────────────────────────────────
+	uploadAttachmentUC := attachment.NewUploadUseCase(todoRepo, workspaceRepo, attachmentRepo, blobs, bus)
+	listAttachmentUC := attachment.NewListUseCase(todoRepo, workspaceRepo, attachmentRepo)
+	downloadAttachmentUC := attachment.NewDownloadUseCase(todoRepo, workspaceRepo, attachmentRepo, blobs)
+	deleteAttachmentUC := attachment.NewDeleteUseCase(todoRepo, workspaceRepo, attachmentRepo, blobs, bus)
+	sweepAttachmentUC := attachment.NewSweepUseCase(attachmentRepo, blobs)
 
 	// ─── (8) Controllers ───────────────────────────────────────────────────────
-	userController := handler.NewUserController(registerUC, loginUC, findByIDUC)
+	userController := handler.NewUserController(registerUC, loginUC, findByIDUC, logoutUC, setAvatarUC, avatarUC)
+	sessionController := handler.NewSessionController(
+		refreshSessionUC,
+		listSessionUC,
+		revokeSessionUC,
+		revokeAllSessionUC,
+	)
 
 	// NewTodoController signature is:
 	//   NewTodoController(
@@ -77,6 +370,14 @@ func InitializeContainer() (*Container, error) {
 	//     ToggleStatusUseCase,
 	//     DeleteUseCase,
 	//     DuplicateUseCase,
+	//     SearchUseCase,
+	//     BulkUseCase,
+	//     ListTrashUseCase,
+	//     RestoreUseCase,
+	//     HistoryUseCase,
+	//     MoveUseCase,
+	//     AssignUseCase,
+	//     UnassignUseCase,
 	//   )
 	todoController := handler.NewTodoController(
 		createTodoUC,
@@ -86,6 +387,14 @@ func InitializeContainer() (*Container, error) {
 		toggleStatusUC,
 		deleteTodoUC,
 		duplicateTodoUC,
+		searchTodoUC,
+		bulkTodoUC,
+		listTrashUC,
+		restoreTodoUC,
+		todoHistoryUC,
+		moveTodoUC,
+		assignTodoUC,
+		unassignTodoUC,
 	)
 
 	categoryController := handler.NewCategoryController(
@@ -102,11 +411,79 @@ func InitializeContainer() (*Container, error) {
 		deleteTagUC,
 	)
 
+	checklistController := handler.NewChecklistController(
+		createItemUC,
+		listItemUC,
+		updateItemUC,
+		reorderItemUC,
+		deleteItemUC,
+	)
+
+	// the stream also carries the events of the caller's workspaces
+	eventController := handler.NewEventController(bus, listWorkspaceUC)
+	activityController := handler.NewActivityController(listActivityUC)
+	reminderController := handler.NewReminderController(createReminderUC, listReminderUC, deleteReminderUC)
+	notificationController := handler.NewNotificationController(listNotificationUC, markNotificationReadUC)
+	calendarController := handler.NewCalendarController(
+		getCalendarFeedUC,
+		rotateCalendarFeedUC,
+		revokeCalendarFeedUC,
+		calendarFeedUC,
+	)
+
+	transferController := handler.NewTransferController(exportUC, importUC)
+
+	workspaceController := handler.NewWorkspaceController(
+		createWorkspaceUC,
+		listWorkspaceUC,
+		getWorkspaceUC,
+		updateWorkspaceUC,
+		deleteWorkspaceUC,
+		inviteMemberUC,
+		listInvitationsUC,
+		receivedInvitationsUC,
+		acceptInvitationUC,
+		deleteInvitationUC,
+		updateMemberUC,
+		removeMemberUC,
+	)
+
+	commentController := handler.NewCommentController(
+		createCommentUC,
+		listCommentUC,
+		updateCommentUC,
+		deleteCommentUC,
+	)
+
+	attachmentController := handler.NewAttachmentController(
+		uploadAttachmentUC,
+		listAttachmentUC,
+		downloadAttachmentUC,
+		deleteAttachmentUC,
+	)
+	blobServer, _ := blobs.(http.Handler)
+
 	return &Container{
-		AuthClient:         authClient,
-		UserController:     userController,
-		TodoController:     todoController,
-		CategoryController: categoryController,
-		TagController:      tagController,
-	}, nil
+		AuthClient:             authClient,
+		Sessions:               sessionRepo,
+		UserController:         userController,
+		TodoController:         todoController,
+		CategoryController:     categoryController,
+		TagController:          tagController,
+		ChecklistController:    checklistController,
+		SessionController:      sessionController,
+		EventController:        eventController,
+		ActivityController:     activityController,
+		ReminderController:     reminderController,
+		NotificationController: notificationController,
+		CalendarController:     calendarController,
+		TransferController:     transferController,
+		WorkspaceController:    workspaceController,
+		CommentController:      commentController,
+		AttachmentController:   attachmentController,
+		BlobServer:             blobServer,
+		PurgeTrash:             purgeTrashUC,
+		SweepAttachments:       sweepAttachmentUC,
+		DispatchReminders:      dispatchReminderUC,
+	}
 }
@@ -0,0 +1,74 @@
+package apperror
+
+import "strings"
+
+// Kind classifies an error so the interface layer can pick a status code
+// without knowing which use case or repository produced it.
+type Kind string
+
+const (
+	KindValidation   Kind = "validation"
+	KindNotFound     Kind = "not_found"
+	KindForbidden    Kind = "forbidden"
+	KindConflict     Kind = "conflict"
+	KindUnauthorized Kind = "unauthorized"
+	// a write was made against a version that is no longer current
+	KindPreconditionFailed Kind = "precondition_failed"
+)
+
+// Error is a domain error with a kind, a user-facing message and, for
+// validation errors, the offending fields keyed by their JSON name. IDs lists
+// the offending identifiers of fields that reference other records.
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  map[string]string
+	IDs     map[string][]string
+	Err     error // optional underlying cause
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+func Validation(message string) *Error         { return New(KindValidation, message) }
+func NotFound(message string) *Error           { return New(KindNotFound, message) }
+func Forbidden(message string) *Error          { return New(KindForbidden, message) }
+func Conflict(message string) *Error           { return New(KindConflict, message) }
+func Unauthorized(message string) *Error       { return New(KindUnauthorized, message) }
+func PreconditionFailed(message string) *Error { return New(KindPreconditionFailed, message) }
+
+// InvalidField reports err as a validation error on field. If err is already an
+// *Error it is wrapped, so errors.Is still matches the original sentinel.
+func InvalidField(field string, err error) *Error {
+	return &Error{
+		Kind:    KindValidation,
+		Message: err.Error(),
+		Fields:  map[string]string{field: err.Error()},
+		Err:     err,
+	}
+}
+
+// InvalidIDs reports references the caller cannot use, keyed by field,
+// e.g. {"tagIds": ["…"]}. Missing records and records owned by someone else
+// are reported alike so IDs cannot be probed.
+func InvalidIDs(ids map[string][]string) *Error {
+	fields := make(map[string]string, len(ids))
+	for field, list := range ids {
+		fields[field] = "not found: " + strings.Join(list, ", ")
+	}
+	return &Error{
+		Kind:    KindValidation,
+		Message: "referenced records do not exist",
+		Fields:  fields,
+		IDs:     ids,
+	}
+}
@@ -0,0 +1,20 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BlobStore keeps file contents outside the database. Keys are chosen by the
+// application and look like relative paths ("attachments/<todo>/<id>").
+type BlobStore interface {
+	// Put stores the contents of r under key, replacing any previous object.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// SignedURL returns a URL that serves the object to anyone holding it
+	// until ttl has passed. With a downloadName the browser saves the file
+	// under that name instead of showing it.
+	SignedURL(ctx context.Context, key string, ttl time.Duration, downloadName string) (string, error)
+	// Delete removes the objects; keys with no object are ignored.
+	Delete(ctx context.Context, keys ...string) error
+}
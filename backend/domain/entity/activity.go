@@ -0,0 +1,176 @@
+package entity
+
+import (
+	"reflect"
+	"slices"
+	"time"
+)
+
+// ActivityResource is the kind of record an activity entry is about.
+type ActivityResource string
+
+const (
+	ActivityTodo     ActivityResource = "todo"
+	ActivityCategory ActivityResource = "category"
+	ActivityTag      ActivityResource = "tag"
+)
+
+type ActivityAction string
+
+const (
+	ActivityCreated  ActivityAction = "created"
+	ActivityUpdated  ActivityAction = "updated"
+	ActivityDeleted  ActivityAction = "deleted"
+	ActivityRestored ActivityAction = "restored"
+)
+
+// FieldChange is one field's value before and after a write, named as in the
+// API. Values are nil, a string, a bool or a []string; times are RFC 3339.
+type FieldChange struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// Activity is one audit log entry: who did what to which record.
+type Activity struct {
+	ID         string
+	UserID     string // the record's owner, whose activity feed lists the entry
+	ActorID    string // who made the change
+	Resource   ActivityResource
+	ResourceID string
+	Action     ActivityAction
+	// Changes lists the fields that differ. A creation lists the new record's
+	// fields, a deletion the old record's; a restore lists none.
+	Changes   []FieldChange
+	CreatedAt time.Time
+}
+
+// DiffTodo compares two states of a todo. Either side may be nil, for a
+// creation or a deletion. Read-only fields (version, checklist progress,
+// timestamps other than completedAt) are not compared, nor is the rank: a
+// move within a status column is not a change worth logging.
+func DiffTodo(before, after *Todo) []FieldChange {
+	var b, a []fieldValue
+	if before != nil {
+		b = todoFields(before)
+	}
+	if after != nil {
+		a = todoFields(after)
+	}
+	return diffFields(b, a)
+}
+
+// DiffCategory compares two states of a category; see DiffTodo.
+func DiffCategory(before, after *Category) []FieldChange {
+	var b, a []fieldValue
+	if before != nil {
+		b = categoryFields(before)
+	}
+	if after != nil {
+		a = categoryFields(after)
+	}
+	return diffFields(b, a)
+}
+
+// DiffTag compares two states of a tag; see DiffTodo.
+func DiffTag(before, after *Tag) []FieldChange {
+	var b, a []fieldValue
+	if before != nil {
+		b = []fieldValue{{"name", before.Name}}
+	}
+	if after != nil {
+		a = []fieldValue{{"name", after.Name}}
+	}
+	return diffFields(b, a)
+}
+
+type fieldValue struct {
+	name  string
+	value any
+}
+
+func todoFields(t *Todo) []fieldValue {
+	var recurrence any
+	if t.Recurrence != nil {
+		recurrence = t.Recurrence.String()
+	}
+	tagIDs := slices.Clone(t.TagIDs)
+	slices.Sort(tagIDs)
+	assigneeIDs := t.AssigneeIDs()
+	slices.Sort(assigneeIDs)
+	return []fieldValue{
+		{"title", t.Title},
+		{"body", stringValue(t.Body)},
+		{"status", string(t.Status)},
+		{"priority", string(t.Priority)},
+		{"dueDate", timeValue(t.DueDate)},
+		{"completedAt", timeValue(t.CompletedAt)},
+		{"categoryId", stringValue(t.CategoryID)},
+		{"tagIds", tagIDs},
+		{"assigneeIds", assigneeIDs},
+		{"recurrence", recurrence},
+		{"autoComplete", t.AutoComplete},
+	}
+}
+
+func categoryFields(c *Category) []fieldValue {
+	return []fieldValue{
+		{"name", c.Name},
+		{"color", c.Color},
+		{"description", stringValue(c.Description)},
+	}
+}
+
+// diffFields pairs up the fields of two states of the same record; a nil side
+// has every field empty.
+func diffFields(before, after []fieldValue) []FieldChange {
+	var changes []FieldChange
+	for i := range max(len(before), len(after)) {
+		var c FieldChange
+		if before != nil {
+			c.Field, c.Before = before[i].name, emptyToNil(before[i].value)
+		}
+		if after != nil {
+			c.Field, c.After = after[i].name, emptyToNil(after[i].value)
+		}
+		if !reflect.DeepEqual(c.Before, c.After) {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// emptyToNil folds the zero values the API treats as unset into nil, so
+// "" → nil or false → nil is not reported as a change.
+func emptyToNil(v any) any {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	case []string:
+		if len(v) == 0 {
+			return nil
+		}
+	}
+	return v
+}
+
+func stringValue(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func timeValue(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
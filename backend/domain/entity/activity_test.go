@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffTodo(t *testing.T) {
+	body := "2 litres"
+	before := &Todo{ID: "t1", Title: "Milk", Status: StatusTodo, TagIDs: []string{"b", "a"}}
+	after := &Todo{
+		ID:         "t1",
+		Title:      "Milk",
+		Body:       &body,
+		Status:     StatusTodo,
+		DueDate:    ptrTime("2026-11-02T09:00:00+09:00"),
+		TagIDs:     []string{"a", "b"}, // same tags in another order
+		Recurrence: &Recurrence{Frequency: FrequencyDaily, Interval: 1},
+	}
+
+	got := DiffTodo(before, after)
+	want := []FieldChange{
+		{Field: "body", Before: nil, After: "2 litres"},
+		{Field: "dueDate", Before: nil, After: "2026-11-02T00:00:00Z"},
+		{Field: "recurrence", Before: nil, After: "FREQ=DAILY;INTERVAL=1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DiffTodo = %+v, want %+v", got, want)
+	}
+
+	if got := DiffTodo(after, after); len(got) != 0 {
+		t.Fatalf("DiffTodo of equal todos = %+v, want none", got)
+	}
+
+	// a creation lists every field that is set; unset ones are left out
+	created := DiffTodo(nil, before)
+	want = []FieldChange{
+		{Field: "title", Before: nil, After: "Milk"},
+		{Field: "status", Before: nil, After: "TODO"},
+		{Field: "tagIds", Before: nil, After: []string{"a", "b"}},
+	}
+	if !reflect.DeepEqual(created, want) {
+		t.Fatalf("DiffTodo(nil, t) = %+v, want %+v", created, want)
+	}
+}
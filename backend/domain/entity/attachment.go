@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+const (
+	// MaxAttachmentSize caps an attached file, in bytes.
+	MaxAttachmentSize = 10 << 20
+	// MaxFileNameLength caps an attachment's file name, in characters.
+	MaxFileNameLength = 255
+)
+
+// attachmentTypes are the media types a file may be attached as.
+var attachmentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"application/zip": true,
+	"text/plain":      true,
+}
+
+// Attachment is a file uploaded to a todo. Its contents live in a blob store
+// under Key; the record only describes them.
+type Attachment struct {
+	ID string
+	// TodoID is empty once the todo has been deleted for good; the file is
+	// then removed by the next sweep.
+	TodoID      string
+	UserID      string // who uploaded it
+	FileName    string
+	ContentType string
+	Size        int64 // in bytes
+	Key         string
+	CreatedAt   time.Time
+}
+
+// NewAttachment enforces: TodoID non-empty, a file name left after dropping
+// any directory part, Size between 1 byte and MaxAttachmentSize, and a
+// ContentType that may be attached.
+func NewAttachment(id, todoID, userID, fileName, contentType string, size int64) (*Attachment, error) {
+	if todoID == "" {
+		return nil, apperror.Validation("todoID cannot be empty")
+	}
+	name, err := attachmentFileName(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		return nil, apperror.Validation("file is empty")
+	}
+	if size > MaxAttachmentSize {
+		return nil, apperror.Validation("file cannot be larger than 10 MB")
+	}
+	if !attachmentTypes[contentType] {
+		return nil, apperror.Validation("files of type " + contentType + " cannot be attached")
+	}
+	return &Attachment{
+		ID:          id,
+		TodoID:      todoID,
+		UserID:      userID,
+		FileName:    name,
+		ContentType: contentType,
+		Size:        size,
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// attachmentFileName keeps the last path element of an uploaded name, as
+// browsers on some systems send the full path, and drops control characters.
+func attachmentFileName(name string) (string, error) {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "" || name == "." || name == ".." {
+		return "", apperror.Validation("fileName cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > MaxFileNameLength {
+		return "", apperror.Validation("fileName cannot exceed 255 characters")
+	}
+	return name, nil
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// CalendarFeed is a user's subscribable iCalendar feed. Calendar apps cannot
+// sign in, so the feed URL carries a secret token; only its hash is stored.
+// A user has at most one feed, and rotating it invalidates the old URL.
+type CalendarFeed struct {
+	UserID    string
+	TokenHash string
+	CreatedAt time.Time
+}
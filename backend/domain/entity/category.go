@@ -1,8 +1,9 @@
 package entity
 
 import (
-    "errors"
     "time"
+
+    "github.com/ariangn/todo-fullstack/backend/domain/apperror"
 )
 
 type Category struct {
@@ -11,6 +12,10 @@ type Category struct {
     Color       string
     Description *string
     UserID      string
+    // WorkspaceID is the workspace the category belongs to; nil for a
+    // personal one. It is fixed at creation.
+    WorkspaceID *string
+    Version     int // increases on every update; see Todo.Version
     CreatedAt   time.Time
     UpdatedAt   time.Time
 }
@@ -21,10 +26,10 @@ func NewCategory(
     description *string,
 ) (*Category, error) {
     if name == "" {
-        return nil, errors.New("category name cannot be empty")
+        return nil, apperror.Validation("category name cannot be empty")
     }
     if userID == "" {
-        return nil, errors.New("userID cannot be empty")
+        return nil, apperror.Validation("userID cannot be empty")
     }
     return &Category{
         ID:          id,
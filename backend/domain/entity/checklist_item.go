@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+// ChecklistItem is one line of a todo's checklist. Items are shown in
+// ascending Position order.
+type ChecklistItem struct {
+	ID        string
+	TodoID    string
+	Title     string
+	Done      bool
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewChecklistItem enforces: Title non-empty, TodoID non-empty
+func NewChecklistItem(id, todoID, title string, position int) (*ChecklistItem, error) {
+	if title == "" {
+		return nil, apperror.Validation("title cannot be empty")
+	}
+	if todoID == "" {
+		return nil, apperror.Validation("todoID cannot be empty")
+	}
+	return &ChecklistItem{
+		ID:        id,
+		TodoID:    todoID,
+		Title:     title,
+		Position:  position,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+// MaxCommentLength caps a comment's body, in characters.
+const MaxCommentLength = 5000
+
+// Comment is one message in the discussion under a todo. UserID is its
+// author, the only one who may change or delete it.
+type Comment struct {
+	ID     string
+	TodoID string
+	UserID string
+	Body   string
+	// Edited is set once the body has been changed after posting.
+	Edited    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewComment enforces: Body non-empty after trimming and at most
+// MaxCommentLength characters, TodoID non-empty.
+func NewComment(id, todoID, userID, body string) (*Comment, error) {
+	body, err := commentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if todoID == "" {
+		return nil, apperror.Validation("todoID cannot be empty")
+	}
+	return &Comment{
+		ID:        id,
+		TodoID:    todoID,
+		UserID:    userID,
+		Body:      body,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Edit replaces the body and marks the comment edited; saving the same text
+// again changes nothing.
+func (c *Comment) Edit(body string) error {
+	body, err := commentBody(body)
+	if err != nil {
+		return err
+	}
+	if body == c.Body {
+		return nil
+	}
+	c.Body = body
+	c.Edited = true
+	c.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func commentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", apperror.Validation("body cannot be empty")
+	}
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return "", apperror.Validation("body cannot exceed 5000 characters")
+	}
+	return body, nil
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// Notification is a reminder delivered to the in-app inbox.
+type Notification struct {
+	ID        string
+	UserID    string
+	TodoID    string
+	Title     string
+	Body      string
+	CreatedAt time.Time
+	ReadAt    *time.Time
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+// rankDigits are the characters a rank is written in, in sort order. Ranks
+// compare as plain byte strings, so they sort the same in Go and in a "C"
+// collated column.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var (
+	errInvalidRank = errors.New("rank must be made of 0-9 and a-z and not end in 0")
+	errRankOrder   = errors.New("lower rank must sort before the upper one")
+)
+
+// RankBetween returns a rank that sorts after lo and before hi. An empty lo
+// stands for the start of a column and an empty hi for its end, so
+// RankBetween("", "") is the first rank of an empty column.
+//
+// Ranks never end in "0", which leaves room before every one of them: the
+// neighbours are never rewritten, however often todos move between them.
+func RankBetween(lo, hi string) (string, error) {
+	if !validRank(lo) || !validRank(hi) {
+		return "", errInvalidRank
+	}
+	if hi != "" && lo >= hi {
+		return "", errRankOrder
+	}
+	return rankMidpoint(lo, hi), nil
+}
+
+func validRank(r string) bool {
+	for i := 0; i < len(r); i++ {
+		if strings.IndexByte(rankDigits, r[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(r, "0")
+}
+
+// rankMidpoint works digit by digit; lo < hi, and an empty hi is unbounded.
+func rankMidpoint(lo, hi string) string {
+	if hi != "" {
+		// keep the prefix they share, reading lo as padded with zeros
+		n := 0
+		for n < len(hi) && rankDigit(lo, n) == hi[n] {
+			n++
+		}
+		if n > 0 {
+			return hi[:n] + rankMidpoint(rankSuffix(lo, n), hi[n:])
+		}
+	}
+
+	a := 0
+	if lo != "" {
+		a = strings.IndexByte(rankDigits, lo[0])
+	}
+	b := len(rankDigits)
+	if hi != "" {
+		b = strings.IndexByte(rankDigits, hi[0])
+	}
+	if b-a > 1 {
+		return string(rankDigits[(a+b)/2])
+	}
+	// the first digits are adjacent: hi's first digit alone still sorts
+	// before hi when hi goes on, otherwise extend lo
+	if len(hi) > 1 {
+		return hi[:1]
+	}
+	return string(rankDigits[a]) + rankMidpoint(rankSuffix(lo, 1), "")
+}
+
+func rankDigit(r string, i int) byte {
+	if i < len(r) {
+		return r[i]
+	}
+	return '0'
+}
+
+func rankSuffix(r string, i int) string {
+	if i < len(r) {
+		return r[i:]
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package entity
+
+import "testing"
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		lo, hi string
+		want   string
+	}{
+		{"", "", "i"},
+		{"i", "", "r"},
+		{"", "i", "9"},
+		{"a", "b", "ai"},
+		{"a", "a5", "a2"},
+		{"a", "a05", "a02"},
+		{"az", "b", "azi"},
+		{"y", "z", "yi"},
+		{"z", "", "zi"},
+		{"", "1", "0i"},
+		{"a1", "a2", "a1i"},
+		{"a1", "a12", "a11"},
+	}
+	for _, tt := range tests {
+		got, err := RankBetween(tt.lo, tt.hi)
+		if err != nil {
+			t.Fatalf("RankBetween(%q, %q): %v", tt.lo, tt.hi, err)
+		}
+		if got != tt.want {
+			t.Errorf("RankBetween(%q, %q) = %q, want %q", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+
+	for _, bad := range [][2]string{{"b", "a"}, {"a", "a"}, {"a0", ""}, {"A", ""}, {"", "a-"}} {
+		if got, err := RankBetween(bad[0], bad[1]); err == nil {
+			t.Errorf("RankBetween(%q, %q) = %q, want an error", bad[0], bad[1], got)
+		}
+	}
+}
+
+// Repeated inserts at the same spot must keep finding room and stay ordered.
+func TestRankBetweenRepeated(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi string // bounds of the first insert
+		// next picks the bounds of the following insert from the last one
+		next func(r, lo, hi string) (string, string)
+	}{
+		{"append", "m", "", func(r, lo, hi string) (string, string) { return r, hi }},
+		{"prepend", "", "m", func(r, lo, hi string) (string, string) { return lo, r }},
+		{"right after a rank", "m", "", func(r, lo, hi string) (string, string) { return "m", r }},
+		{"right before a rank", "", "m", func(r, lo, hi string) (string, string) { return r, "m" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := tt.lo, tt.hi
+			for i := 0; i < 500; i++ {
+				r, err := RankBetween(lo, hi)
+				if err != nil {
+					t.Fatalf("step %d: RankBetween(%q, %q): %v", i, lo, hi, err)
+				}
+				if r <= lo || (hi != "" && r >= hi) {
+					t.Fatalf("step %d: RankBetween(%q, %q) = %q is out of order", i, lo, hi, r)
+				}
+				lo, hi = tt.next(r, lo, hi)
+			}
+		})
+	}
+}
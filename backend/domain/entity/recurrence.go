@@ -0,0 +1,214 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// Recurrence is a subset of an RFC 5545 RRULE. It lives on the most recent
+// occurrence only: completing that todo moves the rule onto the next one.
+type Recurrence struct {
+	Frequency Frequency
+	Interval  int            // every N days/weeks/months/years, >= 1
+	ByWeekday []time.Weekday // WEEKLY only; empty means the due date's weekday
+	Until     *time.Time     // no occurrence is due after this instant
+	Count     *int           // occurrences left including this one; nil means unbounded
+}
+
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayCode returns the two-letter RRULE code for d, e.g. "MO".
+func WeekdayCode(d time.Weekday) string {
+	return weekdayCodes[d]
+}
+
+// ParseWeekday parses a two-letter RRULE weekday code.
+func ParseWeekday(code string) (time.Weekday, bool) {
+	i := slices.Index(weekdayCodes[:], code)
+	if i < 0 {
+		return 0, false
+	}
+	return time.Weekday(i), true
+}
+
+// Validate checks the rule on its own; Todo.SetRecurrence also requires a due date.
+func (r *Recurrence) Validate() error {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	default:
+		return ErrRecurrenceFrequency
+	}
+	if r.Interval < 1 {
+		return ErrRecurrenceInterval
+	}
+	if len(r.ByWeekday) > 0 && r.Frequency != FrequencyWeekly {
+		return ErrRecurrenceByWeekday
+	}
+	if r.Count != nil && *r.Count < 1 {
+		return ErrRecurrenceCount
+	}
+	return nil
+}
+
+// Next returns the occurrence after due, computed on the wall clock of loc so
+// that "every Monday 9:00" stays at 9:00 across DST changes. Monthly and yearly
+// rules clamp to the end of shorter months (Jan 31 → Feb 28). ok is false when
+// the rule is exhausted by Count or Until.
+func (r *Recurrence) Next(due time.Time, loc *time.Location) (next time.Time, ok bool) {
+	if r.Count != nil && *r.Count <= 1 {
+		return time.Time{}, false
+	}
+
+	local := due.In(loc)
+	switch r.Frequency {
+	case FrequencyDaily:
+		next = local.AddDate(0, 0, r.Interval)
+	case FrequencyWeekly:
+		next = r.nextWeekly(local)
+	case FrequencyMonthly:
+		next = addMonthsClamped(local, r.Interval)
+	case FrequencyYearly:
+		next = addMonthsClamped(local, 12*r.Interval)
+	default:
+		return time.Time{}, false
+	}
+
+	if r.Until != nil && next.After(*r.Until) {
+		return time.Time{}, false
+	}
+	return next.UTC(), true
+}
+
+// Advanced returns the rule carried by the next occurrence: the same schedule
+// with one fewer occurrence left.
+func (r *Recurrence) Advanced() *Recurrence {
+	next := *r
+	next.ByWeekday = slices.Clone(r.ByWeekday)
+	if r.Count != nil {
+		c := *r.Count - 1
+		next.Count = &c
+	}
+	return &next
+}
+
+// String renders the rule as an RRULE value, e.g.
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=3".
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency), "INTERVAL=" + strconv.Itoa(r.Interval)}
+	if len(r.ByWeekday) > 0 {
+		codes := make([]string, len(r.ByWeekday))
+		for i, d := range r.ByWeekday {
+			codes[i] = WeekdayCode(d)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.Count != nil {
+		parts = append(parts, "COUNT="+strconv.Itoa(*r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseRecurrence reads an RRULE value in the subset String writes. Parts may
+// come in any order and INTERVAL defaults to 1; the rule is not validated.
+func ParseRecurrence(rrule string) (*Recurrence, error) {
+	r := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(strings.TrimPrefix(rrule, "RRULE:"), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, ErrRecurrenceRule
+		}
+		switch key {
+		case "FREQ":
+			r.Frequency = Frequency(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, ErrRecurrenceRule
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				d, ok := ParseWeekday(code)
+				if !ok {
+					return nil, ErrRecurrenceRule
+				}
+				r.ByWeekday = append(r.ByWeekday, d)
+			}
+		case "UNTIL":
+			until, err := time.Parse("20060102T150405Z", value)
+			if err != nil {
+				return nil, ErrRecurrenceRule
+			}
+			r.Until = &until
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, ErrRecurrenceRule
+			}
+			r.Count = &n
+		default:
+			return nil, ErrRecurrenceRule
+		}
+	}
+	return r, nil
+}
+
+// nextWeekly walks forward day by day to the next listed weekday that falls in
+// an active week (weeks start on Monday, as with RRULE's default WKST).
+func (r *Recurrence) nextWeekly(local time.Time) time.Time {
+	if len(r.ByWeekday) == 0 {
+		return local.AddDate(0, 0, 7*r.Interval)
+	}
+	start := mondayOf(local)
+	for i := 1; i <= 7*r.Interval+7; i++ {
+		d := local.AddDate(0, 0, i)
+		if !slices.Contains(r.ByWeekday, d.Weekday()) {
+			continue
+		}
+		weeks := int(mondayOf(d).Sub(start).Hours()+12) / (24 * 7)
+		if weeks%r.Interval == 0 {
+			return d
+		}
+	}
+	// unreachable for a valid rule: some listed weekday always falls in the next active week
+	return local.AddDate(0, 0, 7*r.Interval)
+}
+
+func mondayOf(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func addMonthsClamped(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	firstOfTarget := time.Date(y, m+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	return firstOfTarget.AddDate(0, 0, min(d, lastDay)-1)
+}
+
+var (
+	ErrRecurrenceFrequency = apperror.InvalidField("recurrence", errors.New("recurrence frequency must be DAILY, WEEKLY, MONTHLY or YEARLY"))
+	ErrRecurrenceInterval  = apperror.InvalidField("recurrence", errors.New("recurrence interval must be at least 1"))
+	ErrRecurrenceByWeekday = apperror.InvalidField("recurrence", errors.New("byWeekday is only allowed for WEEKLY recurrence"))
+	ErrRecurrenceCount     = apperror.InvalidField("recurrence", errors.New("recurrence count must be at least 1"))
+	ErrRecurrenceNoDueDate = apperror.InvalidField("recurrence", errors.New("a recurring todo needs a due date"))
+	ErrRecurrenceRule      = apperror.InvalidField("recurrence", errors.New("recurrence must be an RRULE like FREQ=WEEKLY;INTERVAL=1;BYDAY=MO"))
+)
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurrenceNext(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	two := 2
+
+	tests := []struct {
+		name string
+		rule Recurrence
+		due  string
+		loc  *time.Location
+		want string // empty means exhausted
+	}{
+		{"daily", Recurrence{Frequency: FrequencyDaily, Interval: 3}, "2026-01-30T09:00:00Z", time.UTC, "2026-02-02T09:00:00Z"},
+		{"daily keeps wall clock across DST", Recurrence{Frequency: FrequencyDaily, Interval: 1}, "2026-03-07T14:00:00Z", ny, "2026-03-08T13:00:00Z"},
+		{"weekly same weekday", Recurrence{Frequency: FrequencyWeekly, Interval: 2}, "2026-11-02T09:00:00Z", time.UTC, "2026-11-16T09:00:00Z"},
+		{"weekly by weekday within week", Recurrence{Frequency: FrequencyWeekly, Interval: 2, ByWeekday: []time.Weekday{time.Monday, time.Thursday}}, "2026-11-02T09:00:00Z", time.UTC, "2026-11-05T09:00:00Z"},
+		{"weekly by weekday skips inactive week", Recurrence{Frequency: FrequencyWeekly, Interval: 2, ByWeekday: []time.Weekday{time.Monday, time.Thursday}}, "2026-11-05T09:00:00Z", time.UTC, "2026-11-16T09:00:00Z"},
+		{"monthly clamps to month end", Recurrence{Frequency: FrequencyMonthly, Interval: 1}, "2026-01-31T09:00:00Z", time.UTC, "2026-02-28T09:00:00Z"},
+		{"yearly from leap day", Recurrence{Frequency: FrequencyYearly, Interval: 1}, "2028-02-29T09:00:00Z", time.UTC, "2029-02-28T09:00:00Z"},
+		{"count exhausted", Recurrence{Frequency: FrequencyDaily, Interval: 1, Count: new(int)}, "2026-01-01T09:00:00Z", time.UTC, ""},
+		{"count left", Recurrence{Frequency: FrequencyDaily, Interval: 1, Count: &two}, "2026-01-01T09:00:00Z", time.UTC, "2026-01-02T09:00:00Z"},
+		{"until passed", Recurrence{Frequency: FrequencyDaily, Interval: 1, Until: ptrTime("2026-01-01T12:00:00Z")}, "2026-01-01T09:00:00Z", time.UTC, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.rule.Next(*ptrTime(tt.due), tt.loc)
+			if tt.want == "" {
+				if ok {
+					t.Fatalf("Next = %v, want exhausted", got)
+				}
+				return
+			}
+			if !ok || !got.Equal(*ptrTime(tt.want)) {
+				t.Fatalf("Next = %v, %v; want %s", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceString(t *testing.T) {
+	three := 3
+	rule := Recurrence{
+		Frequency: FrequencyWeekly,
+		Interval:  2,
+		ByWeekday: []time.Weekday{time.Monday, time.Thursday},
+		Until:     ptrTime("2027-01-01T09:00:00+09:00"),
+		Count:     &three,
+	}
+	want := "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20270101T000000Z;COUNT=3"
+	if got := rule.String(); got != want {
+		t.Fatalf("String = %q, want %q", got, want)
+	}
+
+	parsed, err := ParseRecurrence(want)
+	if err != nil {
+		t.Fatalf("ParseRecurrence(%q): %v", want, err)
+	}
+	if got := parsed.String(); got != want {
+		t.Fatalf("ParseRecurrence round trip = %q, want %q", got, want)
+	}
+	if daily, err := ParseRecurrence("FREQ=DAILY"); err != nil || daily.Interval != 1 {
+		t.Fatalf("ParseRecurrence(FREQ=DAILY) = %+v, %v; want interval 1", daily, err)
+	}
+	for _, bad := range []string{"", "FREQ=WEEKLY;BYDAY=XX", "FREQ=DAILY;WKST=MO", "FREQ=DAILY;COUNT=two"} {
+		if _, err := ParseRecurrence(bad); err == nil {
+			t.Errorf("ParseRecurrence(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func ptrTime(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
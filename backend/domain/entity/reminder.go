@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+// ReminderChannel is how a reminder reaches its owner.
+type ReminderChannel string
+
+const (
+	ChannelInApp   ReminderChannel = "inapp"
+	ChannelEmail   ReminderChannel = "email"
+	ChannelWebhook ReminderChannel = "webhook"
+)
+
+type ReminderStatus string
+
+const (
+	ReminderPending ReminderStatus = "pending"
+	ReminderSent    ReminderStatus = "sent"
+	// ReminderFailed: every delivery attempt failed
+	ReminderFailed ReminderStatus = "failed"
+	// ReminderCancelled: the todo was completed, trashed or lost its due date first
+	ReminderCancelled ReminderStatus = "cancelled"
+)
+
+// MaxReminderAttempts is how many times a delivery is tried before the reminder fails.
+const MaxReminderAttempts = 5
+
+// Reminder notifies a todo's owner either at a fixed time (RemindAt) or a
+// number of minutes before the todo's due date (OffsetMinutes); exactly one is set.
+type Reminder struct {
+	ID            string
+	TodoID        string
+	UserID        string
+	RemindAt      *time.Time
+	OffsetMinutes *int
+	Channel       ReminderChannel
+	// FireAt is when the reminder is due: RemindAt, or the due date minus the
+	// offset. Offset reminders follow the due date when it changes.
+	FireAt time.Time
+	Status ReminderStatus
+	// delivery state, written by the scheduler
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	SentAt        *time.Time
+	CreatedAt     time.Time
+}
+
+// Schedule computes FireAt from the reminder's rule and the todo's due date,
+// and arms it for delivery.
+func (r *Reminder) Schedule(due *time.Time) error {
+	switch {
+	case r.RemindAt != nil && r.OffsetMinutes == nil:
+		r.FireAt = r.RemindAt.UTC()
+	case r.OffsetMinutes != nil && r.RemindAt == nil:
+		if *r.OffsetMinutes < 0 || *r.OffsetMinutes > MaxReminderOffsetMinutes {
+			return ErrReminderOffset
+		}
+		if due == nil {
+			return ErrReminderNoDueDate
+		}
+		r.FireAt = due.Add(-time.Duration(*r.OffsetMinutes) * time.Minute).UTC()
+	default:
+		return ErrReminderRule
+	}
+	r.Status = ReminderPending
+	r.Attempts = 0
+	r.NextAttemptAt = r.FireAt
+	r.LastError = nil
+	r.SentAt = nil
+	return nil
+}
+
+// RetryDelay is the wait after the given number of failed attempts: one
+// minute, doubling each time, at most an hour.
+func RetryDelay(attempts int) time.Duration {
+	d := time.Minute
+	for i := 1; i < attempts && d < time.Hour; i++ {
+		d *= 2
+	}
+	return min(d, time.Hour)
+}
+
+// MaxReminderOffsetMinutes caps an offset reminder at 30 days before the due date.
+const MaxReminderOffsetMinutes = 30 * 24 * 60
+
+var (
+	ErrReminderRule      = apperror.InvalidField("remindAt", errors.New("a reminder needs exactly one of remindAt and offsetMinutes"))
+	ErrReminderOffset    = apperror.InvalidField("offsetMinutes", errors.New("offsetMinutes must be between 0 and 43200 (30 days)"))
+	ErrReminderNoDueDate = apperror.InvalidField("offsetMinutes", errors.New("an offset reminder needs a todo with a due date"))
+)
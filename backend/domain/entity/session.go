@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+// Session is one signed-in device. Access tokens carry its ID, and the
+// refresh token that extends it is stored only as a hash.
+type Session struct {
+	ID               string
+	UserID           string
+	RefreshTokenHash string
+	UserAgent        string
+	CreatedAt        time.Time
+	LastUsedAt       time.Time
+	ExpiresAt        time.Time
+	RevokedAt        *time.Time
+}
+
+// NewSession enforces: UserID non-empty, RefreshTokenHash non-empty
+func NewSession(id, userID, refreshTokenHash, userAgent string, ttl time.Duration) (*Session, error) {
+	if userID == "" {
+		return nil, apperror.Validation("userID cannot be empty")
+	}
+	if refreshTokenHash == "" {
+		return nil, apperror.Validation("refresh token hash cannot be empty")
+	}
+	now := time.Now().UTC()
+	return &Session{
+		ID:               id,
+		UserID:           userID,
+		RefreshTokenHash: refreshTokenHash,
+		UserAgent:        userAgent,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(ttl),
+	}, nil
+}
+
+// Active reports whether the session can still authenticate requests at now.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Revoke marks the session as signed out; revoking twice keeps the first time.
+func (s *Session) Revoke(now time.Time) {
+	if s.RevokedAt == nil {
+		s.RevokedAt = &now
+	}
+}
@@ -1,25 +1,30 @@
 package entity
 
 import (
-    "errors"
     "time"
+
+    "github.com/ariangn/todo-fullstack/backend/domain/apperror"
 )
 
 type Tag struct {
-    ID        string
-    Name      string
-    UserID    string
-    CreatedAt time.Time
-    UpdatedAt time.Time
+    ID          string
+    Name        string
+    UserID      string
+    // WorkspaceID is the workspace the tag belongs to; nil for a personal
+    // one. It is fixed at creation.
+    WorkspaceID *string
+    Version     int // increases on every update; see Todo.Version
+    CreatedAt   time.Time
+    UpdatedAt   time.Time
 }
 
 // NewTag enforces: Name non-empty, UserID non-empty
 func NewTag(id, name, userID string) (*Tag, error) {
     if name == "" {
-        return nil, errors.New("tag name cannot be empty")
+        return nil, apperror.Validation("tag name cannot be empty")
     }
     if userID == "" {
-        return nil, errors.New("userID cannot be empty")
+        return nil, apperror.Validation("userID cannot be empty")
     }
     return &Tag{
         ID:        id,
@@ -1,8 +1,9 @@
 package entity
 
 import (
-	"errors"
 	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 )
 
 type Status string
@@ -13,18 +14,61 @@ const (
 	StatusCompleted  Status = "COMPLETED"
 )
 
+type Priority string
+
+const (
+	PriorityNone   Priority = "NONE"
+	PriorityLow    Priority = "LOW"
+	PriorityMedium Priority = "MEDIUM"
+	PriorityHigh   Priority = "HIGH"
+	PriorityUrgent Priority = "URGENT"
+)
+
+// Priorities lists every priority from lowest to highest, the order todos
+// sort in by priority.
+var Priorities = []Priority{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+// Assignee is a user a todo is assigned to, with what clients show for them.
+type Assignee struct {
+	UserID    string
+	Name      *string
+	AvatarURL *string
+}
+
 type Todo struct {
 	ID          string
 	Title       string
 	Body        *string
 	Status      Status
+	Priority    Priority
+	Rank        string // orders the todo within its status column; see RankBetween
 	DueDate     *time.Time
 	CompletedAt *time.Time
 	UserID      string
 	CategoryID  *string
 	TagIDs      []string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Recurrence  *Recurrence
+	// WorkspaceID is the workspace the todo belongs to; nil for a personal
+	// todo. It is fixed at creation, and the todo's category and tags come
+	// from the same workspace. UserID stays the todo's creator.
+	WorkspaceID *string
+	// Assignees are the users doing the todo, in the order they were
+	// assigned. The repository fills in their display info on read; Create
+	// writes their UserIDs, later changes go through AddAssignee and
+	// RemoveAssignee.
+	Assignees []Assignee
+	// AutoComplete completes the todo once every checklist item is done.
+	AutoComplete bool
+	// checklist progress; read-only, filled in by the repository
+	ItemsDone  int
+	ItemsTotal int
+	// Version increases on every change to the todo, its tags or its
+	// checklist; Update only succeeds against the version it was read at.
+	Version int
+	// DeletedAt is set while the todo is in the trash.
+	DeletedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // NewTodo enforces: Title non-empty & dueDate (if set) not in past.
@@ -38,13 +82,14 @@ func NewTodo(
 	tagIDs []string,
 ) (*Todo, error) {
 	if title == "" {
-		return nil, errors.New("title cannot be empty")
+		return nil, apperror.Validation("title cannot be empty")
 	}
 	return &Todo{
 		ID:          id,
 		Title:       title,
 		Body:        body,
 		Status:      status,
+		Priority:    PriorityNone,
 		DueDate:     dueDate,
 		CompletedAt: nil,
 		UserID:      userID,
@@ -55,4 +100,39 @@ func NewTodo(
 	}, nil
 }
 
-var ErrDueDateInPast = errors.New("due date cannot be in the past")
+// SetRecurrence validates and attaches r; nil stops the todo from recurring.
+func (t *Todo) SetRecurrence(r *Recurrence) error {
+	if r == nil {
+		t.Recurrence = nil
+		return nil
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if t.DueDate == nil {
+		return ErrRecurrenceNoDueDate
+	}
+	t.Recurrence = r
+	return nil
+}
+
+var ErrDueDateInPast = apperror.Validation("due date cannot be in the past")
+
+// AssigneeIDs returns the user IDs of t's assignees.
+func (t *Todo) AssigneeIDs() []string {
+	ids := make([]string, len(t.Assignees))
+	for i, a := range t.Assignees {
+		ids[i] = a.UserID
+	}
+	return ids
+}
+
+// IsAssigned reports whether userID is one of t's assignees.
+func (t *Todo) IsAssigned(userID string) bool {
+	for _, a := range t.Assignees {
+		if a.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
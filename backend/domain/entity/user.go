@@ -1,8 +1,9 @@
 package entity
 
 import (
-	"errors"
 	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 )
 
 type User struct {
@@ -11,11 +12,27 @@ type User struct {
 	Password  string
 	Name      *string
 	AvatarURL *string
+	// AvatarKey is where the thumbnails of an uploaded avatar live in the
+	// blob store; nil when AvatarURL was given at registration, or is unset.
+	AvatarKey *string
 	Timezone  string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// Location resolves Timezone, falling back to UTC when the name is empty or
+// unknown to this host.
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 func NewUser(
 	id, email, password string,
 	name, avatarURL *string,
@@ -40,6 +57,6 @@ func NewUser(
 }
 
 var (
-	ErrEmailEmpty    = errors.New("email cannot be empty")
-	ErrTimezoneEmpty = errors.New("timezone cannot be empty")
+	ErrEmailEmpty    = apperror.Validation("email cannot be empty")
+	ErrTimezoneEmpty = apperror.Validation("timezone cannot be empty")
 )
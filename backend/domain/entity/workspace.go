@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+// MaxWorkspaceNameLength caps a workspace name, in characters.
+const MaxWorkspaceNameLength = 100
+
+// Workspace is a shared backlog. Todos, categories and tags either belong to
+// one workspace, where every member sees them, or to no workspace, where only
+// their owner does.
+type Workspace struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WorkspaceRole is what a member may do in a workspace.
+type WorkspaceRole string
+
+const (
+	// RoleOwner manages the workspace: renames or deletes it and invites,
+	// promotes and removes members. A workspace always keeps one owner.
+	RoleOwner WorkspaceRole = "owner"
+	// RoleEditor creates and changes the workspace's todos, categories and tags.
+	RoleEditor WorkspaceRole = "editor"
+	// RoleViewer only reads them.
+	RoleViewer WorkspaceRole = "viewer"
+)
+
+func (r WorkspaceRole) Valid() bool {
+	return r == RoleOwner || r == RoleEditor || r == RoleViewer
+}
+
+// CanEdit reports whether the role may write the workspace's records.
+func (r WorkspaceRole) CanEdit() bool {
+	return r == RoleOwner || r == RoleEditor
+}
+
+// CanManage reports whether the role may change the workspace and its members.
+func (r WorkspaceRole) CanManage() bool {
+	return r == RoleOwner
+}
+
+// WorkspaceMember gives one user a role in one workspace.
+type WorkspaceMember struct {
+	WorkspaceID string
+	UserID      string
+	Role        WorkspaceRole
+	CreatedAt   time.Time
+}
+
+// InvitationTTL is how long an invitation can be accepted.
+const InvitationTTL = 7 * 24 * time.Hour
+
+// WorkspaceInvitation offers a role to whoever signs in with Email and holds
+// the invitation's token. Accepting it turns it into a membership; until then
+// it can be declined or revoked.
+type WorkspaceInvitation struct {
+	ID          string
+	WorkspaceID string
+	Email       string // lower-cased
+	Role        WorkspaceRole
+	InvitedBy   string
+	// TokenHash is the hash of the token sent to the invitee; the token
+	// itself is only known to them.
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the invitation can no longer be accepted at now.
+func (inv *WorkspaceInvitation) Expired(now time.Time) bool {
+	return !now.Before(inv.ExpiresAt)
+}
+
+// NewWorkspace enforces: Name non-empty after trimming and at most
+// MaxWorkspaceNameLength characters.
+func NewWorkspace(id, name string) (*Workspace, error) {
+	name, err := WorkspaceName(name)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &Workspace{ID: id, Name: name, CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// WorkspaceName trims and checks a workspace name.
+func WorkspaceName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", apperror.Validation("workspace name cannot be empty")
+	}
+	if len([]rune(name)) > MaxWorkspaceNameLength {
+		return "", apperror.Validation("workspace name must be at most 100 characters")
+	}
+	return name, nil
+}
@@ -0,0 +1,86 @@
+package event
+
+import "time"
+
+// Resource is the kind of record an event is about.
+type Resource string
+
+const (
+	ResourceTodo     Resource = "todo"
+	ResourceCategory Resource = "category"
+	ResourceTag      Resource = "tag"
+	// notifications are only ever created, when a reminder reaches the in-app inbox
+	ResourceNotification Resource = "notification"
+	ResourceComment      Resource = "comment"
+	ResourceAttachment   Resource = "attachment"
+)
+
+// Action is what happened to the record.
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+	ActionDeleted Action = "deleted"
+)
+
+// Event records one change to a user's data, or to a workspace's when
+// WorkspaceID is set.
+type Event struct {
+	ID       int64 // assigned by the bus on Publish; grows with every event
+	UserID   string
+	Resource Resource
+	Action   Action
+	EntityID string
+	// Entity is the record as saved (*entity.Todo, *entity.Category,
+	// *entity.Tag, *entity.Notification, *entity.Comment or *entity.Attachment);
+	// nil for deletions.
+	Entity any
+	At     time.Time // set by the bus on Publish
+	// WorkspaceID sends the event to every member of the workspace instead
+	// of to UserID alone.
+	WorkspaceID string
+}
+
+// New builds an event about one record of userID.
+func New(userID string, resource Resource, action Action, entityID string, entity any) Event {
+	return Event{UserID: userID, Resource: resource, Action: action, EntityID: entityID, Entity: entity}
+}
+
+// In moves the event into workspaceID; nil keeps it personal.
+func (e Event) In(workspaceID *string) Event {
+	if workspaceID != nil {
+		e.WorkspaceID = *workspaceID
+	}
+	return e
+}
+
+// Name is the event's type on the wire, e.g. "todo.updated".
+func (e Event) Name() string {
+	return string(e.Resource) + "." + string(e.Action)
+}
+
+// Publisher is what use cases announce their writes to.
+type Publisher interface {
+	Publish(e Event)
+}
+
+// Subscription follows one user's events and those of their workspaces.
+type Subscription interface {
+	// Events delivers events in ID order. It is closed when the subscriber
+	// falls too far behind; it should reconnect with the last ID it saw.
+	Events() <-chan Event
+	Close()
+}
+
+// Bus fans published events out to the owner's subscribers, or to the
+// workspace's members.
+type Bus interface {
+	Publisher
+	// Subscribe follows userID's personal events and the events of
+	// workspaceIDs, the workspaces they belong to when subscribing. backlog
+	// holds the retained events after lastID (none when lastID is 0);
+	// complete is false when some of them were already discarded, so the
+	// client has to reload instead.
+	Subscribe(userID string, workspaceIDs []string, lastID int64) (sub Subscription, backlog []Event, complete bool)
+}
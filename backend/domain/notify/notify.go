@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"context"
+	"time"
+)
+
+// Message is one reminder delivery.
+type Message struct {
+	// Key identifies the delivery: a reminder firing for the same time always
+	// has the same key, so a receiver can drop the duplicates that retries produce.
+	Key     string
+	UserID  string
+	Email   string
+	TodoID  string
+	Title   string // the todo's title
+	DueDate *time.Time
+	// Subject and Text are rendered for the user, with times in their timezone.
+	Subject string
+	Text    string
+}
+
+// Notifier delivers reminders over one channel. An error means the message
+// may not have arrived; it is sent again later with the same Key.
+type Notifier interface {
+	Notify(ctx context.Context, m Message) error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// ActivityQuery pages through one user's activity feed, newest first. An
+// empty Resource or ResourceID matches every record. An empty UserID matches
+// every user's records; a workspace todo's history uses it, as any member may
+// have changed the todo.
+type ActivityQuery struct {
+	UserID     string
+	Resource   entity.ActivityResource
+	ResourceID string
+	Limit      int
+	Offset     int
+}
+
+// ActivityPage is one page of an ActivityQuery result.
+type ActivityPage struct {
+	Entries    []*entity.Activity
+	Total      int
+	NextOffset *int
+}
+
+// ActivityRepository stores the audit log. Entries are never changed once written.
+type ActivityRepository interface {
+	CreateMany(ctx context.Context, entries []*entity.Activity) error
+	FindByQuery(ctx context.Context, q ActivityQuery) (*ActivityPage, error)
+}
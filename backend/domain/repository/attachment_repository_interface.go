@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type AttachmentRepository interface {
+	// Create stores the attachment under the ID it already has, since its
+	// blob key is derived from it.
+	Create(ctx context.Context, a *entity.Attachment) (*entity.Attachment, error)
+	// FindByID returns (nil, nil) when no attachment has the given ID.
+	FindByID(ctx context.Context, id string) (*entity.Attachment, error)
+	// FindAllByTodo returns the todo's attachments, oldest first.
+	FindAllByTodo(ctx context.Context, todoID string) ([]*entity.Attachment, error)
+	// FindOrphaned returns up to limit attachments whose todo has been
+	// deleted for good.
+	FindOrphaned(ctx context.Context, limit int) ([]*entity.Attachment, error)
+	Delete(ctx context.Context, id string) error
+}
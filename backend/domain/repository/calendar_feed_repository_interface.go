@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type CalendarFeedRepository interface {
+	// Save creates the user's feed or replaces its token.
+	Save(ctx context.Context, f *entity.CalendarFeed) (*entity.CalendarFeed, error)
+	// FindByUser returns (nil, nil) when the user has no feed.
+	FindByUser(ctx context.Context, userID string) (*entity.CalendarFeed, error)
+	// FindByTokenHash returns (nil, nil) when no feed has the given token.
+	FindByTokenHash(ctx context.Context, hash string) (*entity.CalendarFeed, error)
+	// Delete removes the user's feed; deleting a missing feed is not an error.
+	Delete(ctx context.Context, userID string) error
+}
@@ -9,7 +9,10 @@ import (
 type CategoryRepository interface {
 	Create(ctx context.Context, c *entity.Category) (*entity.Category, error)
 	FindByID(ctx context.Context, id string) (*entity.Category, error)
+	// FindAllByUser returns the user's personal categories.
 	FindAllByUser(ctx context.Context, userID string) ([]*entity.Category, error)
+	// FindAllByWorkspace returns the workspace's categories.
+	FindAllByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Category, error)
 	Update(ctx context.Context, c *entity.Category) (*entity.Category, error)
 	Delete(ctx context.Context, id string) error
 }
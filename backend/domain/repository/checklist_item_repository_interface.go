@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type ChecklistItemRepository interface {
+	Create(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error)
+	// FindByID returns (nil, nil) when no item has the given ID.
+	FindByID(ctx context.Context, id string) (*entity.ChecklistItem, error)
+	// FindAllByTodo returns the todo's items ordered by position.
+	FindAllByTodo(ctx context.Context, todoID string) ([]*entity.ChecklistItem, error)
+	Update(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error)
+	// Reorder sets each item's position to its index in itemIDs.
+	Reorder(ctx context.Context, todoID string, itemIDs []string) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// CommentQuery pages through the comments on one todo, oldest first.
+type CommentQuery struct {
+	TodoID string
+	Limit  int
+	Offset int
+}
+
+// CommentPage is one page of a CommentQuery result.
+// Total counts every comment on the todo, not just this page.
+type CommentPage struct {
+	Comments   []*entity.Comment
+	Total      int
+	NextOffset *int
+}
+
+type CommentRepository interface {
+	Create(ctx context.Context, c *entity.Comment) (*entity.Comment, error)
+	// FindByID returns (nil, nil) when no comment has the given ID.
+	FindByID(ctx context.Context, id string) (*entity.Comment, error)
+	FindByQuery(ctx context.Context, q CommentQuery) (*CommentPage, error)
+	// Update writes the comment's body, edited flag and updated_at.
+	Update(ctx context.Context, c *entity.Comment) (*entity.Comment, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type NotificationRepository interface {
+	// Create stores n unless a notification with its ID exists already, so a
+	// retried delivery lands in the inbox once.
+	Create(ctx context.Context, n *entity.Notification) error
+	// FindByUser lists the user's most recent notifications, newest first.
+	FindByUser(ctx context.Context, userID string, limit int) ([]*entity.Notification, error)
+	// FindByID returns (nil, nil) when no notification has the given ID.
+	FindByID(ctx context.Context, id string) (*entity.Notification, error)
+	MarkRead(ctx context.Context, id string, at time.Time) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type ReminderRepository interface {
+	Create(ctx context.Context, r *entity.Reminder) (*entity.Reminder, error)
+	// FindByID returns (nil, nil) when no reminder has the given ID.
+	FindByID(ctx context.Context, id string) (*entity.Reminder, error)
+	// FindByTodo lists a todo's reminders, soonest first.
+	FindByTodo(ctx context.Context, todoID string) ([]*entity.Reminder, error)
+	// Update writes the schedule and delivery state.
+	Update(ctx context.Context, r *entity.Reminder) error
+	Delete(ctx context.Context, id string) error
+
+	// ClaimDue returns up to limit pending reminders whose next attempt is due
+	// at now, and pushes that attempt back by lease so that other schedulers
+	// skip them while this one delivers. A claim that is never settled with
+	// Update is retried once the lease runs out.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.Reminder, error)
+	// RescheduleByTodos moves the offset reminders of todos that now share the
+	// due date due. Pending ones follow it, and sent or cancelled ones are
+	// re-armed when their new time is still ahead of now. A nil due date
+	// cancels the pending ones.
+	RescheduleByTodos(ctx context.Context, todoIDs []string, due *time.Time, now time.Time) error
+}
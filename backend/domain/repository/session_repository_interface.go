@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, s *entity.Session) (*entity.Session, error)
+	// FindByID returns (nil, nil) when no session has the given ID.
+	FindByID(ctx context.Context, id string) (*entity.Session, error)
+	// FindAllByUser returns the user's sessions, newest first, revoked ones included.
+	FindAllByUser(ctx context.Context, userID string) ([]*entity.Session, error)
+	// Update writes the refresh token hash, last-used time and revocation.
+	Update(ctx context.Context, s *entity.Session) (*entity.Session, error)
+	// Rotate writes s's refresh token hash, last-used time and expiry, but
+	// only while the stored hash is still previousHash and the session is not
+	// revoked. It reports false when another refresh rotated it first.
+	Rotate(ctx context.Context, s *entity.Session, previousHash string) (bool, error)
+	// RevokeAllByUser revokes every session of the user that is not yet revoked.
+	RevokeAllByUser(ctx context.Context, userID string, at time.Time) error
+}
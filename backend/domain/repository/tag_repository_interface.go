@@ -9,7 +9,11 @@ import (
 type TagRepository interface {
 	Create(ctx context.Context, t *entity.Tag) (*entity.Tag, error)
 	FindByID(ctx context.Context, id string) (*entity.Tag, error)
+	// FindAllByUser returns the user's personal tags.
 	FindAllByUser(ctx context.Context, userID string) ([]*entity.Tag, error)
+	// FindAllByWorkspace returns the workspace's tags.
+	FindAllByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Tag, error)
+	// FindByName looks among the user's personal tags.
 	FindByName(ctx context.Context, userID string, name string) (*entity.Tag, error)
 	Update(ctx context.Context, t *entity.Tag) (*entity.Tag, error)
 	Delete(ctx context.Context, id string) error
@@ -2,14 +2,118 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 )
 
+// ErrTodoNotFound is returned by Update when the todo is in the trash or gone.
+var ErrTodoNotFound = apperror.NotFound("todo not found")
+
+type TodoSortField string
+
+const (
+	TodoSortCreatedAt TodoSortField = "created_at"
+	TodoSortUpdatedAt TodoSortField = "updated_at"
+	TodoSortDueDate   TodoSortField = "due_date"
+	TodoSortTitle     TodoSortField = "title"
+	TodoSortStatus    TodoSortField = "status"
+	// priorities sort from NONE up to URGENT, not alphabetically
+	TodoSortPriority TodoSortField = "priority"
+	// rank is only meaningful among todos of one status; filter by it too
+	TodoSortRank TodoSortField = "rank"
+)
+
+// TodoQuery describes a filtered, sorted and paginated listing of one user's
+// personal todos or, when WorkspaceID is set, of one workspace's todos.
+// Nil filters are ignored.
+type TodoQuery struct {
+	UserID      string
+	WorkspaceID *string // when set, UserID and MemberOf are ignored
+	// MemberOf widens a listing without WorkspaceID to these workspaces'
+	// todos as well as the user's personal ones.
+	MemberOf    []string
+	AssigneeID  *string
+	Status      *entity.Status
+	CategoryID  *string
+	TagID       *string
+	DueDateFrom *time.Time
+	DueDateTo   *time.Time
+	SortBy      TodoSortField
+	SortDesc    bool
+	Limit       int
+	Offset      int
+}
+
+// TodoPage is one page of a TodoQuery result.
+// Total counts every row matching the filters, not just this page.
+type TodoPage struct {
+	Todos      []*entity.Todo
+	Total      int
+	NextOffset *int
+}
+
+// TodoPatch is a set of column writes applied to many todos at once. Category
+// and due date are only written when their Set flag is on, so nil can clear them.
+type TodoPatch struct {
+	Status          *entity.Status
+	CompletedAt     *time.Time // written together with Status; nil clears it
+	SetCategory     bool
+	CategoryID      *string
+	SetDueDate      bool
+	DueDate         *time.Time
+	ClearRecurrence bool
+	Ranks           map[string]string // by todo ID; todos not in it keep their rank
+}
+
+// TodoRepository reads and writes todos. Todos in the trash are skipped by
+// every read except the trash methods below.
 type TodoRepository interface {
 	Create(ctx context.Context, t *entity.Todo) (*entity.Todo, error)
 	FindByID(ctx context.Context, id string) (*entity.Todo, error)
+	// FindAllByUser returns the user's personal todos; todos they created in a
+	// workspace are not included.
 	FindAllByUser(ctx context.Context, userID string) ([]*entity.Todo, error)
+	// FindAllByWorkspace returns the workspace's todos.
+	FindAllByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Todo, error)
+	FindByQuery(ctx context.Context, q TodoQuery) (*TodoPage, error)
+	Search(ctx context.Context, q TodoSearch) (*TodoSearchPage, error)
+	// Update fails with ErrStaleVersion when the todo changed since t was
+	// read, and with ErrTodoNotFound when it was trashed or deleted meanwhile.
 	Update(ctx context.Context, t *entity.Todo) (*entity.Todo, error)
+	// Delete moves the todo to the trash.
 	Delete(ctx context.Context, id string) error
+
+	// AddAssignee assigns the user to the todo; assigning them again is a no-op.
+	AddAssignee(ctx context.Context, todoID, userID string) error
+	RemoveAssignee(ctx context.Context, todoID, userID string) error
+
+	// Trash
+
+	// FindTrashByUser lists the user's trashed personal todos, most recently
+	// trashed first.
+	FindTrashByUser(ctx context.Context, userID string) ([]*entity.Todo, error)
+	// FindTrashByWorkspace is FindTrashByUser for a workspace's todos.
+	FindTrashByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Todo, error)
+	// FindTrashedByID returns (nil, nil) unless the todo exists and is in the trash.
+	FindTrashedByID(ctx context.Context, id string) (*entity.Todo, error)
+	// Restore takes the todo out of the trash.
+	Restore(ctx context.Context, id string) (*entity.Todo, error)
+	// PurgeTrashed permanently deletes every todo trashed before cutoff,
+	// with its tag links and checklist, and reports how many there were.
+	PurgeTrashed(ctx context.Context, cutoff time.Time) (int, error)
+
+	// Batch variants for bulk operations; each issues a fixed number of writes
+	// regardless of how many todos it touches.
+
+	// FindByIDs returns the todos that exist among ids, in no particular order.
+	FindByIDs(ctx context.Context, ids []string) ([]*entity.Todo, error)
+	CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error)
+	UpdateMany(ctx context.Context, ids []string, patch TodoPatch) error
+	// AddTags links every tag to every todo; existing links are kept.
+	AddTags(ctx context.Context, ids []string, tagIDs []string) error
+	RemoveTags(ctx context.Context, ids []string, tagIDs []string) error
+	// DeleteMany moves the todos to the trash.
+	DeleteMany(ctx context.Context, ids []string) error
 }
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
+)
+
+// TodoSearch is a ranked full-text search over todo titles and bodies, in
+// the same scope as TodoQuery and optionally narrowed by the same filters.
+type TodoSearch struct {
+	UserID      string
+	WorkspaceID *string // when set, UserID and MemberOf are ignored
+	// MemberOf widens a search without WorkspaceID to these workspaces'
+	// todos as well as the user's personal ones.
+	MemberOf   []string
+	Query      valueobject.SearchQueryVO
+	Status     *entity.Status
+	CategoryID *string
+	TagID      *string
+	Limit      int
+	Offset     int
+}
+
+// TextSegment is a run of snippet text; Match marks the runs that matched the query.
+type TextSegment struct {
+	Text  string
+	Match bool
+}
+
+// TodoSearchHit is one matching todo. TitleSnippet covers the whole title;
+// BodySnippet is an excerpt around the first match, or nil without a body.
+type TodoSearchHit struct {
+	Todo         *entity.Todo
+	Rank         float64
+	TitleSnippet []TextSegment
+	BodySnippet  []TextSegment
+}
+
+// TodoSearchPage is one page of hits, best match first.
+// Total counts every matching todo, not just this page.
+type TodoSearchPage struct {
+	Hits       []*TodoSearchHit
+	Total      int
+	NextOffset *int
+}
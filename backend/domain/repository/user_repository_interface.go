@@ -10,4 +10,6 @@ type UserRepository interface {
 	Create(ctx context.Context, u *entity.User) (*entity.User, error)
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	FindByID(ctx context.Context, id string) (*entity.User, error)
+	// SetAvatar writes the user's avatar_url and avatar_key.
+	SetAvatar(ctx context.Context, id string, avatarURL, avatarKey *string) (*entity.User, error)
 }
@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/ariangn/todo-fullstack/backend/domain/apperror"
+
+// ErrStaleVersion is returned by Update when the record changed since it was
+// read, i.e. its version no longer equals the one on the entity passed in.
+var ErrStaleVersion = apperror.PreconditionFailed("the record was changed by another request")
+
+// VersionMatches reports whether version is one of ifMatch. An empty ifMatch
+// places no condition on the version.
+func VersionMatches(ifMatch []int, version int) bool {
+	if len(ifMatch) == 0 {
+		return true
+	}
+	for _, v := range ifMatch {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type WorkspaceRepository interface {
+	// Create stores the workspace together with its first member, its owner.
+	Create(ctx context.Context, w *entity.Workspace, owner *entity.WorkspaceMember) (*entity.Workspace, error)
+	// FindByID returns (nil, nil) when no workspace has the given ID.
+	FindByID(ctx context.Context, id string) (*entity.Workspace, error)
+	// FindByIDs returns the workspaces that exist among ids, in no particular order.
+	FindByIDs(ctx context.Context, ids []string) ([]*entity.Workspace, error)
+	Update(ctx context.Context, w *entity.Workspace) (*entity.Workspace, error)
+	// Delete removes the workspace with its members, invitations, todos,
+	// categories and tags.
+	Delete(ctx context.Context, id string) error
+
+	// Members
+	// FindMember returns (nil, nil) when the user is not a member.
+	FindMember(ctx context.Context, workspaceID, userID string) (*entity.WorkspaceMember, error)
+	// FindMembers lists the workspace's members, oldest first.
+	FindMembers(ctx context.Context, workspaceID string) ([]*entity.WorkspaceMember, error)
+	// FindMemberships lists the workspaces the user belongs to, oldest first.
+	FindMemberships(ctx context.Context, userID string) ([]*entity.WorkspaceMember, error)
+	// SaveMember adds the member, or changes their role if they already are one.
+	SaveMember(ctx context.Context, m *entity.WorkspaceMember) error
+	RemoveMember(ctx context.Context, workspaceID, userID string) error
+}
+
+type WorkspaceInvitationRepository interface {
+	// Create fails with a conflict when the email already has an invitation
+	// to the workspace.
+	Create(ctx context.Context, inv *entity.WorkspaceInvitation) (*entity.WorkspaceInvitation, error)
+	// FindByID returns (nil, nil) when no invitation has the given ID.
+	FindByID(ctx context.Context, id string) (*entity.WorkspaceInvitation, error)
+	// FindByEmail lists the invitations sent to email, newest first.
+	FindByEmail(ctx context.Context, email string) ([]*entity.WorkspaceInvitation, error)
+	// FindByWorkspace lists the workspace's open invitations, newest first.
+	FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.WorkspaceInvitation, error)
+	Delete(ctx context.Context, id string) error
+}
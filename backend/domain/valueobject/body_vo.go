@@ -1,12 +1,12 @@
 package valueobject
 
-import "errors"
+import "github.com/ariangn/todo-fullstack/backend/domain/apperror"
 
 type BodyVO string
 
 func NewBodyVO(v string) (BodyVO, error) {
     if len(v) > 1000 {
-        return "", errors.New("body cannot exceed 1000 characters")
+        return "", apperror.Validation("body cannot exceed 1000 characters")
     }
     return BodyVO(v), nil
 }
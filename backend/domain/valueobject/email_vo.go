@@ -1,8 +1,9 @@
 package valueobject
 
 import (
-    "errors"
     "regexp"
+
+    "github.com/ariangn/todo-fullstack/backend/domain/apperror"
 )
 
 var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
@@ -11,7 +12,7 @@ type EmailVO string
 
 func NewEmailVO(v string) (EmailVO, error) {
     if !emailRegex.MatchString(v) {
-        return "", errors.New("invalid email format")
+        return "", apperror.Validation("invalid email format")
     }
     return EmailVO(v), nil
 }
@@ -1,9 +1,9 @@
 package valueobject
 
 import (
-	"errors"
-
 	"golang.org/x/crypto/bcrypt"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 )
 
 type PasswordVO struct {
@@ -13,7 +13,7 @@ type PasswordVO struct {
 // NewPasswordVO takes a plain text password, validates length, and hashes
 func NewPasswordVO(plain string) (PasswordVO, error) {
 	if len(plain) < 6 {
-		return PasswordVO{}, errors.New("password must be at least 6 characters")
+		return PasswordVO{}, apperror.Validation("password must be at least 6 characters")
 	}
 	hashed, err := bcrypt.GenerateFromPassword([]byte(plain), bcrypt.DefaultCost)
 	if err != nil {
@@ -0,0 +1,83 @@
+package valueobject
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+// SearchTerm is one condition of a search query: a single word, or a phrase
+// of consecutive words. With Prefix set the last word also matches longer words.
+type SearchTerm struct {
+	Words  []string // lowercased
+	Prefix bool
+}
+
+func (t SearchTerm) IsPhrase() bool {
+	return len(t.Words) > 1
+}
+
+// SearchQueryVO is a parsed full-text query. Every term must match.
+//
+//	groceries            whole word
+//	groc*                prefix
+//	"buy milk"           phrase
+//	"buy mi*"            phrase ending in a prefix
+type SearchQueryVO struct {
+	Terms []SearchTerm
+}
+
+func NewSearchQueryVO(raw string) (SearchQueryVO, error) {
+	var q SearchQueryVO
+	rest := raw
+	for rest != "" {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+		var chunk string
+		if rest[0] == '"' {
+			// an unterminated quote runs to the end of the query
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				chunk, rest = rest[1:], ""
+			} else {
+				chunk, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexFunc(rest, func(r rune) bool { return unicode.IsSpace(r) || r == '"' })
+			if end < 0 {
+				end = len(rest)
+			}
+			chunk, rest = rest[:end], rest[end:]
+		}
+
+		chunk = strings.TrimSpace(chunk)
+		term := SearchTerm{
+			Words:  SplitSearchWords(chunk),
+			Prefix: strings.HasSuffix(chunk, "*"),
+		}
+		if len(term.Words) > 0 {
+			q.Terms = append(q.Terms, term)
+		}
+	}
+
+	if len(q.Terms) == 0 {
+		return q, apperror.Validation("search query must contain at least one word")
+	}
+	if len(q.Terms) > 16 {
+		return q, apperror.Validation("search query cannot have more than 16 terms")
+	}
+	return q, nil
+}
+
+// IsSearchWordRune reports whether r can be part of a searchable word.
+func IsSearchWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// SplitSearchWords lowercases s and splits it into runs of word runes.
+func SplitSearchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !IsSearchWordRune(r) })
+}
@@ -0,0 +1,38 @@
+package valueobject
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewSearchQueryVO(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []SearchTerm
+	}{
+		{"Milk", []SearchTerm{{Words: []string{"milk"}}}},
+		{"groc* milk", []SearchTerm{{Words: []string{"groc"}, Prefix: true}, {Words: []string{"milk"}}}},
+		{`"Buy milk" today`, []SearchTerm{{Words: []string{"buy", "milk"}}, {Words: []string{"today"}}}},
+		{`"buy mi*"`, []SearchTerm{{Words: []string{"buy", "mi"}, Prefix: true}}},
+		{`e-mail`, []SearchTerm{{Words: []string{"e", "mail"}}}},
+		{`"unterminated phrase`, []SearchTerm{{Words: []string{"unterminated", "phrase"}}}},
+		{`a"b c"`, []SearchTerm{{Words: []string{"a"}}, {Words: []string{"b", "c"}}}},
+		{`"" & !`, nil},
+	}
+	for _, tt := range tests {
+		got, err := NewSearchQueryVO(tt.raw)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("NewSearchQueryVO(%q) = %+v, want error", tt.raw, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewSearchQueryVO(%q) error: %v", tt.raw, err)
+			continue
+		}
+		if !reflect.DeepEqual(got.Terms, tt.want) {
+			t.Errorf("NewSearchQueryVO(%q) = %+v, want %+v", tt.raw, got.Terms, tt.want)
+		}
+	}
+}
@@ -1,15 +1,15 @@
 package valueobject
 
-import "errors"
+import "github.com/ariangn/todo-fullstack/backend/domain/apperror"
 
 type TitleVO string
 
 func NewTitleVO(v string) (TitleVO, error) {
 	if len(v) == 0 {
-		return "", errors.New("title cannot be empty")
+		return "", apperror.Validation("title cannot be empty")
 	}
 	if len(v) > 200 {
-		return "", errors.New("title cannot exceed 200 characters")
+		return "", apperror.Validation("title cannot exceed 200 characters")
 	}
 	return TitleVO(v), nil
 }
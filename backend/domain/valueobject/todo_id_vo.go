@@ -1,8 +1,9 @@
 package valueobject
 
 import (
-    "errors"
     "github.com/google/uuid"
+
+    "github.com/ariangn/todo-fullstack/backend/domain/apperror"
 )
 
 type TodoIDVO string
@@ -10,7 +11,7 @@ type TodoIDVO string
 func NewTodoIDVO(v string) (TodoIDVO, error) {
     _, err := uuid.Parse(v)
     if err != nil {
-        return "", errors.New("invalid UUID format for TodoID")
+        return "", apperror.Validation("invalid UUID format for TodoID")
     }
     return TodoIDVO(v), nil
 }
@@ -1,8 +1,9 @@
 package valueobject
 
 import (
-    "errors"
     "github.com/google/uuid"
+
+    "github.com/ariangn/todo-fullstack/backend/domain/apperror"
 )
 
 type UserIDVO string
@@ -10,7 +11,7 @@ type UserIDVO string
 func NewUserIDVO(v string) (UserIDVO, error) {
     _, err := uuid.Parse(v)
     if err != nil {
-        return "", errors.New("invalid UUID format for UserID")
+        return "", apperror.Validation("invalid UUID format for UserID")
     }
     return UserIDVO(v), nil
 }
@@ -14,6 +14,7 @@ require (
 	github.com/supabase-community/postgrest-go v0.0.11
 	github.com/supabase-community/storage-go v0.7.0
 	golang.org/x/crypto v0.37.0
+	golang.org/x/image v0.30.0
 )
 
 require (
@@ -10,7 +10,8 @@ import (
 
 // defines methods for generating/verifying JWTs
 type AuthClientInterface interface {
-    GenerateToken(userID string, ttl time.Duration) (string, error)
+    // GenerateToken signs an access token for userID bound to sessionID (the "sid" claim).
+    GenerateToken(userID, sessionID string, ttl time.Duration) (string, error)
     ValidateToken(tokenString string) (jwt.MapClaims, error)
 }
 
@@ -23,12 +24,18 @@ func NewAuthClient() AuthClientInterface {
     if secret == "" {
         panic("JWT_SECRET must be set in environment")
     }
+    return NewAuthClientWithSecret(secret)
+}
+
+// NewAuthClientWithSecret signs with the given key instead of reading JWT_SECRET.
+func NewAuthClientWithSecret(secret string) AuthClientInterface {
     return &AuthClient{secretKey: []byte(secret)}
 }
 
-func (a *AuthClient) GenerateToken(userID string, ttl time.Duration) (string, error) {
+func (a *AuthClient) GenerateToken(userID, sessionID string, ttl time.Duration) (string, error) {
     claims := jwt.MapClaims{
         "sub": userID,
+        "sid": sessionID,
         "exp": time.Now().Add(ttl).Unix(),
     }
     token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
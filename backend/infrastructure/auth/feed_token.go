@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// NewFeedToken returns a random URL-safe token for a calendar feed and the
+// hash to keep server-side.
+func NewFeedToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashFeedToken(token), nil
+}
+
+// HashFeedToken is the lookup key for a feed token. Feeds are found by hash
+// rather than compared, so the token alone identifies its owner.
+func HashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
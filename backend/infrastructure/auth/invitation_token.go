@@ -0,0 +1,14 @@
+package auth
+
+import "crypto/subtle"
+
+// NewInvitationToken returns a random URL-safe token for a workspace
+// invitation and the hash to keep server-side. It is made like a feed token.
+func NewInvitationToken() (token, hash string, err error) {
+	return NewFeedToken()
+}
+
+// InvitationTokenMatches compares token against a stored hash in constant time.
+func InvitationTokenMatches(token, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashFeedToken(token)), []byte(hash)) == 1
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// NewRefreshToken returns an opaque "<sessionID>.<secret>" token for the client
+// and the hash of its secret to keep server-side.
+func NewRefreshToken(sessionID string) (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+	return sessionID + "." + secret, HashRefreshSecret(secret), nil
+}
+
+// ParseRefreshToken splits a token made by NewRefreshToken.
+func ParseRefreshToken(token string) (sessionID, secret string, ok bool) {
+	sessionID, secret, ok = strings.Cut(token, ".")
+	if !ok || sessionID == "" || secret == "" {
+		return "", "", false
+	}
+	return sessionID, secret, true
+}
+
+func HashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// RefreshSecretMatches compares secret against a stored hash in constant time.
+func RefreshSecretMatches(secret, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashRefreshSecret(secret)), []byte(hash)) == 1
+}
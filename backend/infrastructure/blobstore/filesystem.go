@@ -0,0 +1,163 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileSystem keeps blobs as files under a directory, for development and
+// tests. Its signed URLs point back at the app: mount it at the prefix given
+// to NewFileSystem and it serves them itself. They are signed with a key
+// made at startup, so they stop working when the process restarts.
+//
+// The content type is not stored; files are served with the type sniffed
+// from their first bytes, which is how uploads are typed in the first place.
+type FileSystem struct {
+	dir    string
+	prefix string
+	secret []byte
+}
+
+// NewFileSystem stores blobs under dir, creating it if needed. prefix is the
+// URL path (e.g. "/api/blobs") that ServeHTTP is mounted at.
+func NewFileSystem(dir, prefix string) (*FileSystem, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return &FileSystem{dir: dir, prefix: strings.TrimSuffix(prefix, "/"), secret: secret}, nil
+}
+
+// path maps a key to its file, refusing keys that would escape dir.
+func (s *FileSystem) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return "", fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes to a temporary file first, so a failed upload never leaves a
+// partial object behind.
+func (s *FileSystem) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // a no-op once renamed
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileSystem) SignedURL(ctx context.Context, key string, ttl time.Duration, downloadName string) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	q := url.Values{}
+	q.Set("expires", expires)
+	if downloadName != "" {
+		q.Set("download", downloadName)
+	}
+	q.Set("sig", s.sign(key, expires, downloadName))
+	u := url.URL{Path: s.prefix + "/" + key, RawQuery: q.Encode()}
+	return u.String(), nil
+}
+
+func (s *FileSystem) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		path, err := s.path(key)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServeHTTP serves a blob to whoever holds an unexpired signed URL for it.
+// Anything else, including a blob that has since been deleted, is a 404.
+func (s *FileSystem) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, s.prefix+"/")
+	q := r.URL.Query()
+	expires, downloadName := q.Get("expires"), q.Get("download")
+	if !ok || !s.valid(key, expires, downloadName, q.Get("sig")) {
+		http.NotFound(w, r)
+		return
+	}
+	path, err := s.path(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if downloadName != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": downloadName}))
+	}
+	http.ServeContent(w, r, "", info.ModTime(), f)
+}
+
+func (s *FileSystem) valid(key, expires, downloadName, sig string) bool {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > exp {
+		return false
+	}
+	return hmac.Equal([]byte(sig), []byte(s.sign(key, expires, downloadName)))
+}
+
+func (s *FileSystem) sign(key, expires, downloadName string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires + "\n" + downloadName))
+	return hex.EncodeToString(mac.Sum(nil))
+}
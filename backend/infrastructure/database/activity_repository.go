@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type activityRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewActivityRepository(supabase *SupabaseClient) repository.ActivityRepository {
+	return &activityRepository{supabase}
+}
+
+// CreateMany inserts every entry in one request.
+func (r *activityRepository) CreateMany(ctx context.Context, entries []*entity.Activity) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	rows := make([]*model.ActivityModel, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, model.FromDomainActivity(e))
+	}
+	if _, _, err := r.supabase.DB.
+		From("activities").
+		Insert(rows, false, "", "minimal", "").
+		Execute(); err != nil {
+		return fmt.Errorf("failed to insert activities: %w", err)
+	}
+	return nil
+}
+
+func (r *activityRepository) FindByQuery(ctx context.Context, q repository.ActivityQuery) (*repository.ActivityPage, error) {
+	builder := r.supabase.DB.
+		From("activities").
+		Select("*", "exact", false)
+	if q.UserID != "" {
+		builder = builder.Eq("user_id", q.UserID)
+	}
+	if q.Resource != "" {
+		builder = builder.Eq("resource", string(q.Resource))
+	}
+	if q.ResourceID != "" {
+		builder = builder.Eq("resource_id", q.ResourceID)
+	}
+
+	raw, count, err := builder.
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Range(q.Offset, q.Offset+q.Limit-1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.ActivityModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	entries := make([]*entity.Activity, 0, len(models))
+	for i := range models {
+		entries = append(entries, model.ToDomainActivity(&models[i]))
+	}
+
+	page := &repository.ActivityPage{Entries: entries, Total: int(count)}
+	if next := q.Offset + len(entries); len(entries) > 0 && next < page.Total {
+		page.NextOffset = &next
+	}
+	return page, nil
+}
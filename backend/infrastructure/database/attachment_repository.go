@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type attachmentRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewAttachmentRepository(supabase *SupabaseClient) repository.AttachmentRepository {
+	return &attachmentRepository{supabase}
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, a *entity.Attachment) (*entity.Attachment, error) {
+	toInsert := map[string]interface{}{
+		"id":           a.ID,
+		"todo_id":      a.TodoID,
+		"user_id":      a.UserID,
+		"file_name":    a.FileName,
+		"content_type": a.ContentType,
+		"size":         a.Size,
+		"storage_key":  a.Key,
+	}
+
+	if _, _, err := r.supabase.DB.
+		From("attachments").
+		Insert(toInsert, false, "", "minimal", "").
+		Execute(); err != nil {
+		return nil, fmt.Errorf("failed to insert attachment: %w", err)
+	}
+	return r.FindByID(ctx, a.ID)
+}
+
+// FindByID returns (nil, nil) when no attachment has the given ID.
+func (r *attachmentRepository) FindByID(ctx context.Context, id string) (*entity.Attachment, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+
+	attachments, err := r.find(r.supabase.DB.
+		From("attachments").
+		Select("*", "", false).
+		Eq("id", id).
+		Limit(1, ""))
+	if err != nil || len(attachments) == 0 {
+		return nil, err
+	}
+	return attachments[0], nil
+}
+
+func (r *attachmentRepository) FindAllByTodo(ctx context.Context, todoID string) ([]*entity.Attachment, error) {
+	return r.find(r.supabase.DB.
+		From("attachments").
+		Select("*", "", false).
+		Eq("todo_id", todoID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}))
+}
+
+func (r *attachmentRepository) FindOrphaned(ctx context.Context, limit int) ([]*entity.Attachment, error) {
+	return r.find(r.supabase.DB.
+		From("attachments").
+		Select("*", "", false).
+		Is("todo_id", "null").
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		Limit(limit, ""))
+}
+
+func (r *attachmentRepository) find(q *postgrest.FilterBuilder) ([]*entity.Attachment, error) {
+	raw, _, err := q.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.AttachmentModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	attachments := make([]*entity.Attachment, 0, len(models))
+	for i := range models {
+		attachments = append(attachments, model.ToDomainAttachment(&models[i]))
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id string) error {
+	_, _, err := r.supabase.DB.
+		From("attachments").
+		Delete("*", "").
+		Eq("id", id).
+		Execute()
+	return err
+}
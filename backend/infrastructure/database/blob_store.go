@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	storage "github.com/supabase-community/storage-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/blob"
+)
+
+// blobStore keeps blobs in one Supabase Storage bucket. The bucket should be
+// private: objects are only reachable through the signed URLs it hands out.
+type blobStore struct {
+	supabase *SupabaseClient
+	bucket   string
+}
+
+func NewBlobStore(supabase *SupabaseClient, bucket string) blob.BlobStore {
+	return &blobStore{supabase, bucket}
+}
+
+func (s *blobStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	upsert := true
+	if _, err := s.supabase.newStorageClient().UploadFile(s.bucket, key, r, storage.FileOptions{
+		ContentType: &contentType,
+		Upsert:      &upsert,
+	}); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *blobStore) SignedURL(ctx context.Context, key string, ttl time.Duration, downloadName string) (string, error) {
+	resp, err := s.supabase.Storage.CreateSignedUrl(s.bucket, key, int(ttl/time.Second))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign %s: %w", key, err)
+	}
+	signed := resp.SignedURL
+	if downloadName != "" {
+		// Storage answers with Content-Disposition: attachment under this name
+		signed += "&download=" + url.QueryEscape(downloadName)
+	}
+	return signed, nil
+}
+
+func (s *blobStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if _, err := s.supabase.Storage.RemoveFile(s.bucket, keys); err != nil {
+		return fmt.Errorf("failed to remove %d objects: %w", len(keys), err)
+	}
+	return nil
+}
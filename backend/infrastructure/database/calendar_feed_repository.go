@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type calendarFeedRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewCalendarFeedRepository(supabase *SupabaseClient) repository.CalendarFeedRepository {
+	return &calendarFeedRepository{supabase}
+}
+
+// Save upserts on user_id, so rotating replaces the old token in one write.
+func (r *calendarFeedRepository) Save(ctx context.Context, f *entity.CalendarFeed) (*entity.CalendarFeed, error) {
+	if _, _, err := r.supabase.DB.
+		From("calendar_feeds").
+		Upsert(model.FromDomainCalendarFeed(f), "user_id", "minimal", "").
+		Execute(); err != nil {
+		return nil, fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+	return f, nil
+}
+
+// FindByUser returns (nil, nil) when the user has no feed.
+func (r *calendarFeedRepository) FindByUser(ctx context.Context, userID string) (*entity.CalendarFeed, error) {
+	return r.findOne("user_id", userID)
+}
+
+// FindByTokenHash returns (nil, nil) when no feed has the given token.
+func (r *calendarFeedRepository) FindByTokenHash(ctx context.Context, hash string) (*entity.CalendarFeed, error) {
+	return r.findOne("token_hash", hash)
+}
+
+func (r *calendarFeedRepository) findOne(column, value string) (*entity.CalendarFeed, error) {
+	raw, _, err := r.supabase.DB.
+		From("calendar_feeds").
+		Select("*", "", false).
+		Eq(column, value).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.CalendarFeedModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return model.ToDomainCalendarFeed(&models[0]), nil
+}
+
+func (r *calendarFeedRepository) Delete(ctx context.Context, userID string) error {
+	_, _, err := r.supabase.DB.
+		From("calendar_feeds").
+		Delete("minimal", "").
+		Eq("user_id", userID).
+		Execute()
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
 
 	"github.com/google/uuid"
 
@@ -23,11 +24,12 @@ func NewCategoryRepository(supabase *SupabaseClient) repository.CategoryReposito
 func (r *categoryRepository) Create(ctx context.Context, c *entity.Category) (*entity.Category, error) {
 	c.ID = uuid.NewString()
 	toInsert := map[string]interface{}{
-		"id":          c.ID,
-		"name":        c.Name,
-		"color":       c.Color,
-		"description": c.Description,
-		"user_id":     c.UserID,
+		"id":           c.ID,
+		"name":         c.Name,
+		"color":        c.Color,
+		"description":  c.Description,
+		"user_id":      c.UserID,
+		"workspace_id": c.WorkspaceID,
 	}
 
 	builder := r.supabase.DB.
@@ -43,30 +45,47 @@ func (r *categoryRepository) Create(ctx context.Context, c *entity.Category) (*e
 	return r.FindByID(ctx, c.ID)
 }
 
+// FindByID returns (nil, nil) when no category has the given ID.
 func (r *categoryRepository) FindByID(ctx context.Context, id string) (*entity.Category, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+
+	// no Single(): PostgREST reports zero rows as an error there
 	builder := r.supabase.DB.
 		From("categories").
 		Select("*", "", false).
 		Eq("id", id).
-		Single()
+		Limit(1, "")
 
 	raw, _, err := builder.Execute()
 	if err != nil {
 		return nil, err
 	}
 
-	var m model.CategoryModel
-	if err := json.Unmarshal(raw, &m); err != nil {
+	var models []model.CategoryModel
+	if err := json.Unmarshal(raw, &models); err != nil {
 		return nil, err
 	}
-	return model.ToDomainCategory(&m), nil
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return model.ToDomainCategory(&models[0]), nil
 }
 
 func (r *categoryRepository) FindAllByUser(ctx context.Context, userID string) ([]*entity.Category, error) {
+	return r.findAll(userID, nil)
+}
+
+func (r *categoryRepository) FindAllByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Category, error) {
+	return r.findAll("", &workspaceID)
+}
+
+func (r *categoryRepository) findAll(userID string, workspaceID *string) ([]*entity.Category, error) {
 	builder := r.supabase.DB.
 		From("categories").
-		Select("*", "", false).
-		Eq("user_id", userID)
+		Select("*", "", false)
+	builder = inScope(builder, userID, workspaceID)
 
 	raw, _, err := builder.Execute()
 	if err != nil {
@@ -101,18 +120,32 @@ func (r *categoryRepository) Update(ctx context.Context, c *entity.Category) (*e
 		updates["description"] = c.Description
 	}
 
-	// Perform the update, but ignore the raw JSON payload
+	// compare-and-swap: the row only changes while it is at the version that was read
 	builder := r.supabase.DB.
 		From("categories").
-		Update(updates, "", "").
-		Eq("id", c.ID)
+		Update(updates, "representation", "").
+		Eq("id", c.ID).
+		Eq("version", strconv.Itoa(c.Version))
 
-	if _, _, err := builder.Execute(); err != nil {
+	raw, _, err := builder.Execute()
+	if err != nil {
 		return nil, err
 	}
-
-	// Now re-fetch the row so we get a proper JSON object
-	return r.FindByID(ctx, c.ID)
+	var rows []model.CategoryModel
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		current, err := r.FindByID(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, errors.New("category not found")
+		}
+		return nil, repository.ErrStaleVersion
+	}
+	return model.ToDomainCategory(&rows[0]), nil
 }
 
 func (r *categoryRepository) Delete(ctx context.Context, id string) error {
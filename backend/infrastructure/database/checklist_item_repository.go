@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type checklistItemRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewChecklistItemRepository(supabase *SupabaseClient) repository.ChecklistItemRepository {
+	return &checklistItemRepository{supabase}
+}
+
+func (r *checklistItemRepository) Create(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	item.ID = uuid.NewString()
+	toInsert := map[string]interface{}{
+		"id":       item.ID,
+		"todo_id":  item.TodoID,
+		"title":    item.Title,
+		"done":     item.Done,
+		"position": item.Position,
+	}
+
+	if _, _, err := r.supabase.DB.
+		From("checklist_items").
+		Insert(toInsert, false, "", "minimal", "").
+		Execute(); err != nil {
+		return nil, fmt.Errorf("failed to insert checklist item: %w", err)
+	}
+	return r.FindByID(ctx, item.ID)
+}
+
+// FindByID returns (nil, nil) when no item has the given ID.
+func (r *checklistItemRepository) FindByID(ctx context.Context, id string) (*entity.ChecklistItem, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+
+	raw, _, err := r.supabase.DB.
+		From("checklist_items").
+		Select("*", "", false).
+		Eq("id", id).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.ChecklistItemModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return model.ToDomainChecklistItem(&models[0]), nil
+}
+
+func (r *checklistItemRepository) FindAllByTodo(ctx context.Context, todoID string) ([]*entity.ChecklistItem, error) {
+	raw, _, err := r.supabase.DB.
+		From("checklist_items").
+		Select("*", "", false).
+		Eq("todo_id", todoID).
+		Order("position", &postgrest.OrderOpts{Ascending: true}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.ChecklistItemModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+
+	items := make([]*entity.ChecklistItem, 0, len(models))
+	for i := range models {
+		items = append(items, model.ToDomainChecklistItem(&models[i]))
+	}
+	return items, nil
+}
+
+func (r *checklistItemRepository) Update(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	if item.ID == "" {
+		return nil, errors.New("checklist item ID is required")
+	}
+
+	updates := map[string]interface{}{
+		"title":      item.Title,
+		"done":       item.Done,
+		"position":   item.Position,
+		"updated_at": time.Now().UTC(),
+	}
+	if _, _, err := r.supabase.DB.
+		From("checklist_items").
+		Update(updates, "", "").
+		Eq("id", item.ID).
+		Execute(); err != nil {
+		return nil, err
+	}
+	return r.FindByID(ctx, item.ID)
+}
+
+// Reorder issues one update per item; the todo_id filter keeps a stray ID
+// from moving another todo's item.
+func (r *checklistItemRepository) Reorder(ctx context.Context, todoID string, itemIDs []string) error {
+	now := time.Now().UTC()
+	for i, id := range itemIDs {
+		if _, _, err := r.supabase.DB.
+			From("checklist_items").
+			Update(map[string]interface{}{"position": i, "updated_at": now}, "", "").
+			Eq("id", id).
+			Eq("todo_id", todoID).
+			Execute(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *checklistItemRepository) Delete(ctx context.Context, id string) error {
+	_, _, err := r.supabase.DB.
+		From("checklist_items").
+		Delete("*", "").
+		Eq("id", id).
+		Execute()
+	return err
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type commentRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewCommentRepository(supabase *SupabaseClient) repository.CommentRepository {
+	return &commentRepository{supabase}
+}
+
+func (r *commentRepository) Create(ctx context.Context, c *entity.Comment) (*entity.Comment, error) {
+	c.ID = uuid.NewString()
+	toInsert := map[string]interface{}{
+		"id":      c.ID,
+		"todo_id": c.TodoID,
+		"user_id": c.UserID,
+		"body":    c.Body,
+	}
+
+	if _, _, err := r.supabase.DB.
+		From("comments").
+		Insert(toInsert, false, "", "minimal", "").
+		Execute(); err != nil {
+		return nil, fmt.Errorf("failed to insert comment: %w", err)
+	}
+	return r.FindByID(ctx, c.ID)
+}
+
+// FindByID returns (nil, nil) when no comment has the given ID.
+func (r *commentRepository) FindByID(ctx context.Context, id string) (*entity.Comment, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+
+	raw, _, err := r.supabase.DB.
+		From("comments").
+		Select("*", "", false).
+		Eq("id", id).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.CommentModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return model.ToDomainComment(&models[0]), nil
+}
+
+func (r *commentRepository) FindByQuery(ctx context.Context, q repository.CommentQuery) (*repository.CommentPage, error) {
+	// count=exact makes PostgREST report the full match count in Content-Range
+	raw, count, err := r.supabase.DB.
+		From("comments").
+		Select("*", "exact", false).
+		Eq("todo_id", q.TodoID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Range(q.Offset, q.Offset+q.Limit-1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.CommentModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	comments := make([]*entity.Comment, 0, len(models))
+	for i := range models {
+		comments = append(comments, model.ToDomainComment(&models[i]))
+	}
+
+	page := &repository.CommentPage{Comments: comments, Total: int(count)}
+	if next := q.Offset + len(comments); len(comments) > 0 && next < page.Total {
+		page.NextOffset = &next
+	}
+	return page, nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, c *entity.Comment) (*entity.Comment, error) {
+	if c.ID == "" {
+		return nil, errors.New("comment ID is required")
+	}
+
+	updates := map[string]interface{}{
+		"body":       c.Body,
+		"edited":     c.Edited,
+		"updated_at": time.Now().UTC(),
+	}
+	if _, _, err := r.supabase.DB.
+		From("comments").
+		Update(updates, "", "").
+		Eq("id", c.ID).
+		Execute(); err != nil {
+		return nil, err
+	}
+	return r.FindByID(ctx, c.ID)
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id string) error {
+	_, _, err := r.supabase.DB.
+		From("comments").
+		Delete("*", "").
+		Eq("id", id).
+		Execute()
+	return err
+}
@@ -0,0 +1,22 @@
+package database
+
+import "github.com/google/uuid"
+
+// validID reports whether id can name a row at all. Every id column is a
+// uuid, so a malformed ID cannot match anything; asking Postgres would only
+// fail the cast, and lookups answer "not found" without a round trip instead.
+func validID(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+// validIDs keeps the IDs that pass validID.
+func validIDs(ids []string) []string {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if validID(id) {
+			valid = append(valid, id)
+		}
+	}
+	return valid
+}
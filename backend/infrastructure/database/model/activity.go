@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// mirrors the JSON for "activities" table
+type ActivityModel struct {
+	ID         string                `json:"id"`
+	UserID     string                `json:"user_id"`
+	ActorID    string                `json:"actor_id"`
+	Resource   string                `json:"resource"`
+	ResourceID string                `json:"resource_id"`
+	Action     string                `json:"action"`
+	Changes    []ActivityChangeModel `json:"changes"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
+// one element of the "changes" jsonb array
+type ActivityChangeModel struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+func ToDomainActivity(m *ActivityModel) *entity.Activity {
+	changes := make([]entity.FieldChange, 0, len(m.Changes))
+	for _, c := range m.Changes {
+		changes = append(changes, entity.FieldChange{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	return &entity.Activity{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		ActorID:    m.ActorID,
+		Resource:   entity.ActivityResource(m.Resource),
+		ResourceID: m.ResourceID,
+		Action:     entity.ActivityAction(m.Action),
+		Changes:    changes,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func FromDomainActivity(a *entity.Activity) *ActivityModel {
+	changes := make([]ActivityChangeModel, 0, len(a.Changes))
+	for _, c := range a.Changes {
+		changes = append(changes, ActivityChangeModel{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	return &ActivityModel{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		ActorID:    a.ActorID,
+		Resource:   string(a.Resource),
+		ResourceID: a.ResourceID,
+		Action:     string(a.Action),
+		Changes:    changes,
+		CreatedAt:  a.CreatedAt,
+	}
+}
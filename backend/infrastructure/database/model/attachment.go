@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type AttachmentModel struct {
+	ID          string    `json:"id"`
+	TodoID      *string   `json:"todo_id"` // null once the todo is deleted for good
+	UserID      string    `json:"user_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"storage_key"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func ToDomainAttachment(m *AttachmentModel) *entity.Attachment {
+	a := &entity.Attachment{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		FileName:    m.FileName,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		Key:         m.StorageKey,
+		CreatedAt:   m.CreatedAt,
+	}
+	if m.TodoID != nil {
+		a.TodoID = *m.TodoID
+	}
+	return a
+}
+
+func FromDomainAttachment(a *entity.Attachment) *AttachmentModel {
+	m := &AttachmentModel{
+		ID:          a.ID,
+		UserID:      a.UserID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		StorageKey:  a.Key,
+		CreatedAt:   a.CreatedAt,
+	}
+	if a.TodoID != "" {
+		m.TodoID = &a.TodoID
+	}
+	return m
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// mirrors the JSON for "calendar_feeds" table
+type CalendarFeedModel struct {
+	UserID    string    `json:"user_id"`
+	TokenHash string    `json:"token_hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ToDomainCalendarFeed(m *CalendarFeedModel) *entity.CalendarFeed {
+	return &entity.CalendarFeed{
+		UserID:    m.UserID,
+		TokenHash: m.TokenHash,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func FromDomainCalendarFeed(f *entity.CalendarFeed) *CalendarFeedModel {
+	return &CalendarFeedModel{
+		UserID:    f.UserID,
+		TokenHash: f.TokenHash,
+		CreatedAt: f.CreatedAt,
+	}
+}
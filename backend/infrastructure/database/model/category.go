@@ -12,6 +12,8 @@ type CategoryModel struct {
 	Color       string    `json:"color"`
 	Description *string   `json:"description"`
 	UserID      string    `json:"user_id"`
+	WorkspaceID *string   `json:"workspace_id"`
+	Version     int       `json:"version,omitempty"` // set by the database; omitted on insert
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -23,6 +25,8 @@ func ToDomainCategory(m *CategoryModel) *entity.Category {
 		Color:       m.Color,
 		Description: m.Description,
 		UserID:      m.UserID,
+		WorkspaceID: m.WorkspaceID,
+		Version:     m.Version,
 		CreatedAt:   m.CreatedAt,
 		UpdatedAt:   m.UpdatedAt,
 	}
@@ -35,6 +39,8 @@ func FromDomainCategory(c *entity.Category) *CategoryModel {
 		Color:       c.Color,
 		Description: c.Description,
 		UserID:      c.UserID,
+		WorkspaceID: c.WorkspaceID,
+		Version:     c.Version,
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
 	}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type ChecklistItemModel struct {
+	ID        string    `json:"id"`
+	TodoID    string    `json:"todo_id"`
+	Title     string    `json:"title"`
+	Done      bool      `json:"done"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func ToDomainChecklistItem(m *ChecklistItemModel) *entity.ChecklistItem {
+	return &entity.ChecklistItem{
+		ID:        m.ID,
+		TodoID:    m.TodoID,
+		Title:     m.Title,
+		Done:      m.Done,
+		Position:  m.Position,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func FromDomainChecklistItem(i *entity.ChecklistItem) *ChecklistItemModel {
+	return &ChecklistItemModel{
+		ID:        i.ID,
+		TodoID:    i.TodoID,
+		Title:     i.Title,
+		Done:      i.Done,
+		Position:  i.Position,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+type CommentModel struct {
+	ID        string    `json:"id"`
+	TodoID    string    `json:"todo_id"`
+	UserID    string    `json:"user_id"`
+	Body      string    `json:"body"`
+	Edited    bool      `json:"edited"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func ToDomainComment(m *CommentModel) *entity.Comment {
+	return &entity.Comment{
+		ID:        m.ID,
+		TodoID:    m.TodoID,
+		UserID:    m.UserID,
+		Body:      m.Body,
+		Edited:    m.Edited,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func FromDomainComment(c *entity.Comment) *CommentModel {
+	return &CommentModel{
+		ID:        c.ID,
+		TodoID:    c.TodoID,
+		UserID:    c.UserID,
+		Body:      c.Body,
+		Edited:    c.Edited,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// mirrors the JSON for "notifications" table
+type NotificationModel struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	TodoID    string     `json:"todo_id"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at"`
+}
+
+func ToDomainNotification(m *NotificationModel) *entity.Notification {
+	return &entity.Notification{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		TodoID:    m.TodoID,
+		Title:     m.Title,
+		Body:      m.Body,
+		CreatedAt: m.CreatedAt,
+		ReadAt:    m.ReadAt,
+	}
+}
+
+func FromDomainNotification(n *entity.Notification) *NotificationModel {
+	return &NotificationModel{
+		ID:        n.ID,
+		UserID:    n.UserID,
+		TodoID:    n.TodoID,
+		Title:     n.Title,
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+		ReadAt:    n.ReadAt,
+	}
+}
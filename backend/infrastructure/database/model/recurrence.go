@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// stored in the todos.recurrence jsonb column
+type RecurrenceModel struct {
+	Frequency string     `json:"frequency"`
+	Interval  int        `json:"interval"`
+	ByWeekday []string   `json:"by_weekday,omitempty"`
+	Until     *time.Time `json:"until,omitempty"`
+	Count     *int       `json:"count,omitempty"`
+}
+
+func ToDomainRecurrence(m *RecurrenceModel) *entity.Recurrence {
+	if m == nil {
+		return nil
+	}
+	r := &entity.Recurrence{
+		Frequency: entity.Frequency(m.Frequency),
+		Interval:  m.Interval,
+		Until:     m.Until,
+		Count:     m.Count,
+	}
+	for _, code := range m.ByWeekday {
+		if d, ok := entity.ParseWeekday(code); ok {
+			r.ByWeekday = append(r.ByWeekday, d)
+		}
+	}
+	return r
+}
+
+func FromDomainRecurrence(r *entity.Recurrence) *RecurrenceModel {
+	if r == nil {
+		return nil
+	}
+	m := &RecurrenceModel{
+		Frequency: string(r.Frequency),
+		Interval:  r.Interval,
+		Until:     r.Until,
+		Count:     r.Count,
+	}
+	for _, d := range r.ByWeekday {
+		m.ByWeekday = append(m.ByWeekday, entity.WeekdayCode(d))
+	}
+	return m
+}
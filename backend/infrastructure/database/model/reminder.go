@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// mirrors the JSON for "reminders" table
+type ReminderModel struct {
+	ID            string     `json:"id"`
+	TodoID        string     `json:"todo_id"`
+	UserID        string     `json:"user_id"`
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes"`
+	Channel       string     `json:"channel"`
+	FireAt        time.Time  `json:"fire_at"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     *string    `json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func ToDomainReminder(m *ReminderModel) *entity.Reminder {
+	return &entity.Reminder{
+		ID:            m.ID,
+		TodoID:        m.TodoID,
+		UserID:        m.UserID,
+		RemindAt:      m.RemindAt,
+		OffsetMinutes: m.OffsetMinutes,
+		Channel:       entity.ReminderChannel(m.Channel),
+		FireAt:        m.FireAt,
+		Status:        entity.ReminderStatus(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		SentAt:        m.SentAt,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+func FromDomainReminder(r *entity.Reminder) *ReminderModel {
+	return &ReminderModel{
+		ID:            r.ID,
+		TodoID:        r.TodoID,
+		UserID:        r.UserID,
+		RemindAt:      r.RemindAt,
+		OffsetMinutes: r.OffsetMinutes,
+		Channel:       string(r.Channel),
+		FireAt:        r.FireAt,
+		Status:        string(r.Status),
+		Attempts:      r.Attempts,
+		NextAttemptAt: r.NextAttemptAt,
+		LastError:     r.LastError,
+		SentAt:        r.SentAt,
+		CreatedAt:     r.CreatedAt,
+	}
+}
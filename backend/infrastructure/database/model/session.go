@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// mirrors the JSON for "sessions" table
+type SessionModel struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"user_id"`
+	RefreshTokenHash string     `json:"refresh_token_hash"`
+	UserAgent        string     `json:"user_agent"`
+	CreatedAt        time.Time  `json:"created_at"`
+	LastUsedAt       time.Time  `json:"last_used_at"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at"`
+}
+
+func ToDomainSession(m *SessionModel) *entity.Session {
+	return &entity.Session{
+		ID:               m.ID,
+		UserID:           m.UserID,
+		RefreshTokenHash: m.RefreshTokenHash,
+		UserAgent:        m.UserAgent,
+		CreatedAt:        m.CreatedAt,
+		LastUsedAt:       m.LastUsedAt,
+		ExpiresAt:        m.ExpiresAt,
+		RevokedAt:        m.RevokedAt,
+	}
+}
+
+func FromDomainSession(s *entity.Session) *SessionModel {
+	return &SessionModel{
+		ID:               s.ID,
+		UserID:           s.UserID,
+		RefreshTokenHash: s.RefreshTokenHash,
+		UserAgent:        s.UserAgent,
+		CreatedAt:        s.CreatedAt,
+		LastUsedAt:       s.LastUsedAt,
+		ExpiresAt:        s.ExpiresAt,
+		RevokedAt:        s.RevokedAt,
+	}
+}
@@ -7,29 +7,35 @@ import (
 )
 
 type TagModel struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	UserID    string    `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	UserID      string    `json:"user_id"`
+	WorkspaceID *string   `json:"workspace_id"`
+	Version     int       `json:"version,omitempty"` // set by the database; omitted on insert
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 func ToDomainTag(m *TagModel) *entity.Tag {
 	return &entity.Tag{
-		ID:        m.ID,
-		Name:      m.Name,
-		UserID:    m.UserID,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
+		ID:          m.ID,
+		Name:        m.Name,
+		UserID:      m.UserID,
+		WorkspaceID: m.WorkspaceID,
+		Version:     m.Version,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
 	}
 }
 
 func FromDomainTag(t *entity.Tag) *TagModel {
 	return &TagModel{
-		ID:        t.ID,
-		Name:      t.Name,
-		UserID:    t.UserID,
-		CreatedAt: t.CreatedAt,
-		UpdatedAt: t.UpdatedAt,
+		ID:          t.ID,
+		Name:        t.Name,
+		UserID:      t.UserID,
+		WorkspaceID: t.WorkspaceID,
+		Version:     t.Version,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
 	}
 }
@@ -8,47 +8,101 @@ import (
 
 // mirrors the JSON for "todos" table
 type TodoModel struct {
-	ID          string     `json:"id"`
-	Title       string     `json:"title"`
-	Body        *string    `json:"body"`
-	Status      string     `json:"status"`
-	DueDate     *time.Time `json:"due_date"`
-	CompletedAt *time.Time `json:"completed_at"`
-	UserID      string     `json:"user_id"`
-	CategoryID  *string    `json:"category_id"`
-	TagIDs      []string   `json:"tag_ids"` // assuming a computed JSON array of tag_ids
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID           string           `json:"id"`
+	Title        string           `json:"title"`
+	Body         *string          `json:"body"`
+	Status       string           `json:"status"`
+	Priority     string           `json:"priority"`
+	Rank         string           `json:"rank"`
+	DueDate      *time.Time       `json:"due_date"`
+	CompletedAt  *time.Time       `json:"completed_at"`
+	UserID       string           `json:"user_id"`
+	CategoryID   *string          `json:"category_id"`
+	WorkspaceID  *string          `json:"workspace_id"`
+	TagIDs       []string         `json:"tag_ids"`   // assuming a computed JSON array of tag_ids
+	Assignees    []AssigneeModel  `json:"assignees"` // computed by the todos_with_tag_ids view
+	Recurrence   *RecurrenceModel `json:"recurrence"`
+	AutoComplete bool             `json:"auto_complete"`
+	ItemsDone    int              `json:"items_done"` // computed by the todos_with_tag_ids view
+	ItemsTotal   int              `json:"items_total"`
+	Version      int              `json:"version,omitempty"` // set by the database; omitted on insert
+	DeletedAt    *time.Time       `json:"deleted_at"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+// AssigneeModel is one entry of the view's assignees column: a todo_assignees
+// row joined with the user's display info.
+type AssigneeModel struct {
+	UserID    string  `json:"user_id"`
+	Name      *string `json:"name"`
+	AvatarURL *string `json:"avatar_url"`
+}
+
+func toDomainAssignees(ms []AssigneeModel) []entity.Assignee {
+	assignees := make([]entity.Assignee, len(ms))
+	for i, m := range ms {
+		assignees[i] = entity.Assignee{UserID: m.UserID, Name: m.Name, AvatarURL: m.AvatarURL}
+	}
+	return assignees
+}
+
+func fromDomainAssignees(as []entity.Assignee) []AssigneeModel {
+	ms := make([]AssigneeModel, len(as))
+	for i, a := range as {
+		ms[i] = AssigneeModel{UserID: a.UserID, Name: a.Name, AvatarURL: a.AvatarURL}
+	}
+	return ms
 }
 
 func ToDomainTodo(m *TodoModel) *entity.Todo {
 	return &entity.Todo{
-		ID:          m.ID,
-		Title:       m.Title,
-		Body:        m.Body,
-		Status:      entity.Status(m.Status),
-		DueDate:     m.DueDate,
-		CompletedAt: m.CompletedAt,
-		UserID:      m.UserID,
-		CategoryID:  m.CategoryID,
-		TagIDs:      m.TagIDs,
-		CreatedAt:   m.CreatedAt,
-		UpdatedAt:   m.UpdatedAt,
+		ID:           m.ID,
+		Title:        m.Title,
+		Body:         m.Body,
+		Status:       entity.Status(m.Status),
+		Priority:     entity.Priority(m.Priority),
+		Rank:         m.Rank,
+		DueDate:      m.DueDate,
+		CompletedAt:  m.CompletedAt,
+		UserID:       m.UserID,
+		CategoryID:   m.CategoryID,
+		WorkspaceID:  m.WorkspaceID,
+		TagIDs:       m.TagIDs,
+		Assignees:    toDomainAssignees(m.Assignees),
+		Recurrence:   ToDomainRecurrence(m.Recurrence),
+		AutoComplete: m.AutoComplete,
+		ItemsDone:    m.ItemsDone,
+		ItemsTotal:   m.ItemsTotal,
+		Version:      m.Version,
+		DeletedAt:    m.DeletedAt,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
 	}
 }
 
 func FromDomainTodo(t *entity.Todo) *TodoModel {
 	return &TodoModel{
-		ID:          t.ID,
-		Title:       t.Title,
-		Body:        t.Body,
-		Status:      string(t.Status),
-		DueDate:     t.DueDate,
-		CompletedAt: t.CompletedAt,
-		UserID:      t.UserID,
-		CategoryID:  t.CategoryID,
-		TagIDs:      t.TagIDs,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+		ID:           t.ID,
+		Title:        t.Title,
+		Body:         t.Body,
+		Status:       string(t.Status),
+		Priority:     string(t.Priority),
+		Rank:         t.Rank,
+		DueDate:      t.DueDate,
+		CompletedAt:  t.CompletedAt,
+		UserID:       t.UserID,
+		CategoryID:   t.CategoryID,
+		WorkspaceID:  t.WorkspaceID,
+		TagIDs:       t.TagIDs,
+		Assignees:    fromDomainAssignees(t.Assignees),
+		Recurrence:   FromDomainRecurrence(t.Recurrence),
+		AutoComplete: t.AutoComplete,
+		ItemsDone:    t.ItemsDone,
+		ItemsTotal:   t.ItemsTotal,
+		Version:      t.Version,
+		DeletedAt:    t.DeletedAt,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
 	}
 }
@@ -13,6 +13,7 @@ type UserModel struct {
 	Password  string    `json:"password"`
 	Name      *string   `json:"name"`
 	AvatarURL *string   `json:"avatar_url"`
+	AvatarKey *string   `json:"avatar_key"`
 	Timezone  string    `json:"timezone"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -25,6 +26,7 @@ func ToDomainUser(m *UserModel) *entity.User {
 		Password:  m.Password,
 		Name:      m.Name,
 		AvatarURL: m.AvatarURL,
+		AvatarKey: m.AvatarKey,
 		Timezone:  m.Timezone,
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
@@ -38,6 +40,7 @@ func FromDomainUser(u *entity.User) *UserModel {
 		Password:  u.Password,
 		Name:      u.Name,
 		AvatarURL: u.AvatarURL,
+		AvatarKey: u.AvatarKey,
 		Timezone:  u.Timezone,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
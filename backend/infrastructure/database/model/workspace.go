@@ -0,0 +1,88 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// mirrors the JSON for "workspaces" table
+type WorkspaceModel struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// mirrors the JSON for "workspace_members" table
+type WorkspaceMemberModel struct {
+	WorkspaceID string    `json:"workspace_id"`
+	UserID      string    `json:"user_id"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// mirrors the JSON for "workspace_invitations" table
+type WorkspaceInvitationModel struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspace_id"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	InvitedBy   string    `json:"invited_by"`
+	TokenHash   string    `json:"token_hash"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func ToDomainWorkspace(m *WorkspaceModel) *entity.Workspace {
+	return &entity.Workspace{
+		ID:        m.ID,
+		Name:      m.Name,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func ToDomainWorkspaceMember(m *WorkspaceMemberModel) *entity.WorkspaceMember {
+	return &entity.WorkspaceMember{
+		WorkspaceID: m.WorkspaceID,
+		UserID:      m.UserID,
+		Role:        entity.WorkspaceRole(m.Role),
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func FromDomainWorkspaceMember(m *entity.WorkspaceMember) *WorkspaceMemberModel {
+	return &WorkspaceMemberModel{
+		WorkspaceID: m.WorkspaceID,
+		UserID:      m.UserID,
+		Role:        string(m.Role),
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func ToDomainWorkspaceInvitation(m *WorkspaceInvitationModel) *entity.WorkspaceInvitation {
+	return &entity.WorkspaceInvitation{
+		ID:          m.ID,
+		WorkspaceID: m.WorkspaceID,
+		Email:       m.Email,
+		Role:        entity.WorkspaceRole(m.Role),
+		InvitedBy:   m.InvitedBy,
+		TokenHash:   m.TokenHash,
+		CreatedAt:   m.CreatedAt,
+		ExpiresAt:   m.ExpiresAt,
+	}
+}
+
+func FromDomainWorkspaceInvitation(inv *entity.WorkspaceInvitation) *WorkspaceInvitationModel {
+	return &WorkspaceInvitationModel{
+		ID:          inv.ID,
+		WorkspaceID: inv.WorkspaceID,
+		Email:       inv.Email,
+		Role:        string(inv.Role),
+		InvitedBy:   inv.InvitedBy,
+		TokenHash:   inv.TokenHash,
+		CreatedAt:   inv.CreatedAt,
+		ExpiresAt:   inv.ExpiresAt,
+	}
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type notificationRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewNotificationRepository(supabase *SupabaseClient) repository.NotificationRepository {
+	return &notificationRepository{supabase}
+}
+
+// Create upserts on the primary key. read_at is left out of the row, so a
+// duplicate rewrites the same content and keeps whether it was read.
+func (r *notificationRepository) Create(ctx context.Context, n *entity.Notification) error {
+	row := map[string]interface{}{
+		"id":         n.ID,
+		"user_id":    n.UserID,
+		"todo_id":    n.TodoID,
+		"title":      n.Title,
+		"body":       n.Body,
+		"created_at": n.CreatedAt,
+	}
+	_, _, err := r.supabase.DB.
+		From("notifications").
+		Upsert(row, "id", "minimal", "").
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to insert notification: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) FindByUser(ctx context.Context, userID string, limit int) ([]*entity.Notification, error) {
+	raw, _, err := r.supabase.DB.
+		From("notifications").
+		Select("*", "", false).
+		Eq("user_id", userID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Limit(limit, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+	return decodeNotifications(raw)
+}
+
+// FindByID returns (nil, nil) when no notification has the given ID.
+func (r *notificationRepository) FindByID(ctx context.Context, id string) (*entity.Notification, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+	raw, _, err := r.supabase.DB.
+		From("notifications").
+		Select("*", "", false).
+		Eq("id", id).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := decodeNotifications(raw)
+	if err != nil || len(notifications) == 0 {
+		return nil, err
+	}
+	return notifications[0], nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, id string, at time.Time) error {
+	_, _, err := r.supabase.DB.
+		From("notifications").
+		Update(map[string]interface{}{"read_at": at.UTC()}, "minimal", "").
+		Eq("id", id).
+		Is("read_at", "null").
+		Execute()
+	return err
+}
+
+func decodeNotifications(raw []byte) ([]*entity.Notification, error) {
+	var models []model.NotificationModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	notifications := make([]*entity.Notification, 0, len(models))
+	for i := range models {
+		notifications = append(notifications, model.ToDomainNotification(&models[i]))
+	}
+	return notifications, nil
+}
@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type reminderRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewReminderRepository(supabase *SupabaseClient) repository.ReminderRepository {
+	return &reminderRepository{supabase}
+}
+
+func (r *reminderRepository) Create(ctx context.Context, rem *entity.Reminder) (*entity.Reminder, error) {
+	if rem.ID == "" {
+		rem.ID = uuid.NewString()
+	}
+	row := model.FromDomainReminder(rem)
+	row.CreatedAt = time.Now().UTC()
+	if _, _, err := r.supabase.DB.
+		From("reminders").
+		Insert(row, false, "", "minimal", "").
+		Execute(); err != nil {
+		return nil, fmt.Errorf("failed to insert reminder: %w", err)
+	}
+	return model.ToDomainReminder(row), nil
+}
+
+// FindByID returns (nil, nil) when no reminder has the given ID.
+func (r *reminderRepository) FindByID(ctx context.Context, id string) (*entity.Reminder, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+
+	raw, _, err := r.supabase.DB.
+		From("reminders").
+		Select("*", "", false).
+		Eq("id", id).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+	reminders, err := decodeReminders(raw)
+	if err != nil || len(reminders) == 0 {
+		return nil, err
+	}
+	return reminders[0], nil
+}
+
+func (r *reminderRepository) FindByTodo(ctx context.Context, todoID string) ([]*entity.Reminder, error) {
+	raw, _, err := r.supabase.DB.
+		From("reminders").
+		Select("*", "", false).
+		Eq("todo_id", todoID).
+		Order("fire_at", &postgrest.OrderOpts{Ascending: true}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+	return decodeReminders(raw)
+}
+
+func (r *reminderRepository) Update(ctx context.Context, rem *entity.Reminder) error {
+	updates := map[string]interface{}{
+		"fire_at":         rem.FireAt,
+		"status":          string(rem.Status),
+		"attempts":        rem.Attempts,
+		"next_attempt_at": rem.NextAttemptAt,
+		"last_error":      rem.LastError,
+		"sent_at":         rem.SentAt,
+	}
+	_, _, err := r.supabase.DB.
+		From("reminders").
+		Update(updates, "minimal", "").
+		Eq("id", rem.ID).
+		Execute()
+	return err
+}
+
+func (r *reminderRepository) Delete(ctx context.Context, id string) error {
+	_, _, err := r.supabase.DB.
+		From("reminders").
+		Delete("minimal", "").
+		Eq("id", id).
+		Execute()
+	return err
+}
+
+// ClaimDue goes through the claim_due_reminders function, which locks the
+// rows it picks with SKIP LOCKED so concurrent schedulers claim disjoint sets.
+func (r *reminderRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.Reminder, error) {
+	params := map[string]interface{}{
+		"p_now":           now.UTC(),
+		"p_lease_seconds": int(lease.Seconds()),
+		"p_limit":         limit,
+	}
+	var models []model.ReminderModel
+	if err := r.supabase.callRPC("claim_due_reminders", params, &models); err != nil {
+		return nil, err
+	}
+	reminders := make([]*entity.Reminder, 0, len(models))
+	for i := range models {
+		reminders = append(reminders, model.ToDomainReminder(&models[i]))
+	}
+	return reminders, nil
+}
+
+// RescheduleByTodos is one reschedule_reminders call, whatever the number of todos.
+func (r *reminderRepository) RescheduleByTodos(ctx context.Context, todoIDs []string, due *time.Time, now time.Time) error {
+	if len(todoIDs) == 0 {
+		return nil
+	}
+	params := map[string]interface{}{
+		"p_todo_ids": todoIDs,
+		"p_due":      due,
+		"p_now":      now.UTC(),
+	}
+	return r.supabase.callRPC("reschedule_reminders", params, nil)
+}
+
+func decodeReminders(raw []byte) ([]*entity.Reminder, error) {
+	var models []model.ReminderModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	reminders := make([]*entity.Reminder, 0, len(models))
+	for i := range models {
+		reminders = append(reminders, model.ToDomainReminder(&models[i]))
+	}
+	return reminders, nil
+}
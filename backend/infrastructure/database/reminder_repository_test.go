@@ -0,0 +1,27 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRescheduleByTodosIsOneCall(t *testing.T) {
+	supabase, calls := fakeSupabase(t, map[string]string{"/rest/v1/rpc/reschedule_reminders": "2"})
+	repo := NewReminderRepository(supabase)
+
+	due := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	if err := repo.RescheduleByTodos(context.Background(), []string{"t1", "t2"}, &due, due.Add(-time.Hour)); err != nil {
+		t.Fatalf("RescheduleByTodos: %v", err)
+	}
+	if len(*calls) != 1 || string((*calls)[0].Body["p_todo_ids"]) != `["t1","t2"]` {
+		t.Fatalf("calls = %+v", *calls)
+	}
+
+	// a void function answers with an empty body, which callRPC cannot tell
+	// from a request that never reached Postgres
+	supabase, _ = fakeSupabase(t, map[string]string{"/rest/v1/rpc/reschedule_reminders": ""})
+	if err := NewReminderRepository(supabase).RescheduleByTodos(context.Background(), []string{"t1"}, nil, due); err == nil {
+		t.Fatal("RescheduleByTodos succeeded on an empty reply")
+	}
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// rpcError is the body PostgREST sends when a function raises or a
+// statement inside it fails; the function's transaction is rolled back.
+type rpcError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+}
+
+// callRPC runs a Postgres function through PostgREST and decodes its result
+// into out. Rpc reports neither the HTTP status nor transport errors, so an
+// empty body or an error object in the body is treated as the failure.
+func (s *SupabaseClient) callRPC(name string, params, out interface{}) error {
+	raw := s.DB.Rpc(name, "", params)
+	if raw == "" {
+		return fmt.Errorf("%s: empty response", name)
+	}
+	var rpcErr rpcError
+	if json.Unmarshal([]byte(raw), &rpcErr) == nil && rpcErr.Code != "" {
+		return fmt.Errorf("%s: %s (%s)", name, rpcErr.Message, rpcErr.Code)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal([]byte(raw), out)
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// inScope narrows a query to one workspace's rows, or to userID's personal
+// rows when workspaceID is nil; rows a user created in a workspace are not
+// personal.
+func inScope(b *postgrest.FilterBuilder, userID string, workspaceID *string) *postgrest.FilterBuilder {
+	if workspaceID != nil {
+		return b.Eq("workspace_id", *workspaceID)
+	}
+	return b.Eq("user_id", userID).Is("workspace_id", "null")
+}
+
+// inQueryScope is inScope for a todo listing, widened by q.MemberOf.
+func inQueryScope(b *postgrest.FilterBuilder, q repository.TodoQuery) *postgrest.FilterBuilder {
+	if q.WorkspaceID != nil || len(q.MemberOf) == 0 {
+		return inScope(b, q.UserID, q.WorkspaceID)
+	}
+	return b.Or(fmt.Sprintf("and(user_id.eq.%s,workspace_id.is.null),workspace_id.in.(%s)",
+		q.UserID, strings.Join(q.MemberOf, ",")), "")
+}
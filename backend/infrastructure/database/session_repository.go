@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type sessionRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewSessionRepository(supabase *SupabaseClient) repository.SessionRepository {
+	return &sessionRepository{supabase}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, s *entity.Session) (*entity.Session, error) {
+	if s.ID == "" {
+		return nil, errors.New("session ID is required")
+	}
+
+	// the ID is already baked into the refresh token, so it is inserted as given
+	if _, _, err := r.supabase.DB.
+		From("sessions").
+		Insert(model.FromDomainSession(s), false, "", "minimal", "").
+		Execute(); err != nil {
+		return nil, fmt.Errorf("failed to insert session: %w", err)
+	}
+	return s, nil
+}
+
+// FindByID returns (nil, nil) when no session has the given ID.
+func (r *sessionRepository) FindByID(ctx context.Context, id string) (*entity.Session, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+
+	raw, _, err := r.supabase.DB.
+		From("sessions").
+		Select("*", "", false).
+		Eq("id", id).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.SessionModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return model.ToDomainSession(&models[0]), nil
+}
+
+func (r *sessionRepository) FindAllByUser(ctx context.Context, userID string) ([]*entity.Session, error) {
+	raw, _, err := r.supabase.DB.
+		From("sessions").
+		Select("*", "", false).
+		Eq("user_id", userID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.SessionModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*entity.Session, 0, len(models))
+	for i := range models {
+		sessions = append(sessions, model.ToDomainSession(&models[i]))
+	}
+	return sessions, nil
+}
+
+func (r *sessionRepository) Update(ctx context.Context, s *entity.Session) (*entity.Session, error) {
+	if s.ID == "" {
+		return nil, errors.New("session ID is required")
+	}
+
+	updates := map[string]interface{}{
+		"refresh_token_hash": s.RefreshTokenHash,
+		"last_used_at":       s.LastUsedAt,
+		"expires_at":         s.ExpiresAt,
+		"revoked_at":         s.RevokedAt,
+	}
+	if _, _, err := r.supabase.DB.
+		From("sessions").
+		Update(updates, "", "").
+		Eq("id", s.ID).
+		Execute(); err != nil {
+		return nil, err
+	}
+	return r.FindByID(ctx, s.ID)
+}
+
+func (r *sessionRepository) Rotate(ctx context.Context, s *entity.Session, previousHash string) (bool, error) {
+	if s.ID == "" {
+		return false, errors.New("session ID is required")
+	}
+
+	updates := map[string]interface{}{
+		"refresh_token_hash": s.RefreshTokenHash,
+		"last_used_at":       s.LastUsedAt,
+		"expires_at":         s.ExpiresAt,
+	}
+	// compare-and-swap: of two refreshes holding the same token, only one rotates it
+	raw, _, err := r.supabase.DB.
+		From("sessions").
+		Update(updates, "representation", "").
+		Eq("id", s.ID).
+		Eq("refresh_token_hash", previousHash).
+		Is("revoked_at", "null").
+		Execute()
+	if err != nil {
+		return false, err
+	}
+	var rows []model.SessionModel
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+func (r *sessionRepository) RevokeAllByUser(ctx context.Context, userID string, at time.Time) error {
+	_, _, err := r.supabase.DB.
+		From("sessions").
+		Update(map[string]interface{}{"revoked_at": at}, "", "").
+		Eq("user_id", userID).
+		Is("revoked_at", "null").
+		Execute()
+	return err
+}
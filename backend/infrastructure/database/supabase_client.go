@@ -14,6 +14,11 @@ type SupabaseClient struct {
 	Auth    auth.Client // auth.New returns auth.Client (an interface)
 	DB      *postgrest.Client
 	Storage *storage.Client
+
+	// what Storage was built from, for newStorageClient
+	storageURL     string
+	apiKey         string
+	storageHeaders map[string]string
 }
 
 // NewSupabaseClient initializes the Supabase Auth, PostgREST, and Storage clients.
@@ -49,5 +54,16 @@ func NewSupabaseClient() (*SupabaseClient, error) {
 		Auth:    clientAuth,
 		DB:      dbClient,
 		Storage: storageClient,
+
+		storageURL:     storageURL,
+		apiKey:         apiKey,
+		storageHeaders: headers,
 	}, nil
 }
+
+// newStorageClient builds a Storage client of its own. storage-go keeps upload
+// options such as the content type in the client's shared headers, where they
+// would leak into every later request, so uploads must not go through Storage.
+func (c *SupabaseClient) newStorageClient() *storage.Client {
+	return storage.NewClient(c.storageURL, c.apiKey, c.storageHeaders)
+}
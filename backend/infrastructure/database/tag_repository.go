@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
 
 	"github.com/google/uuid"
 
@@ -25,6 +26,7 @@ func (r *tagRepository) FindByName(ctx context.Context, userID string, name stri
 		From("tags").
 		Select("*", "", false).
 		Eq("user_id", userID).
+		Is("workspace_id", "null").
 		Eq("name", name).
 		Single()
 
@@ -51,9 +53,10 @@ func (r *tagRepository) Create(ctx context.Context, t *entity.Tag) (*entity.Tag,
 		id = uuid.NewString()
 	}
 	insert := map[string]interface{}{
-		"id":      id,
-		"user_id": t.UserID,
-		"name":    t.Name,
+		"id":           id,
+		"user_id":      t.UserID,
+		"workspace_id": t.WorkspaceID,
+		"name":         t.Name,
 	}
 
 	_, _, err := r.supabase.DB.
@@ -64,33 +67,51 @@ func (r *tagRepository) Create(ctx context.Context, t *entity.Tag) (*entity.Tag,
 		return nil, err
 	}
 
+	t.Version = 1 // the column default
 	return t, nil
 }
 
+// FindByID returns (nil, nil) when no tag has the given ID.
 func (r *tagRepository) FindByID(ctx context.Context, id string) (*entity.Tag, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+
+	// no Single(): PostgREST reports zero rows as an error there
 	builder := r.supabase.DB.
 		From("tags").
 		Select("*", "", false).
 		Eq("id", id).
-		Single()
+		Limit(1, "")
 
 	raw, _, err := builder.Execute()
 	if err != nil {
 		return nil, err
 	}
 
-	var m model.TagModel
-	if err := json.Unmarshal(raw, &m); err != nil {
+	var models []model.TagModel
+	if err := json.Unmarshal(raw, &models); err != nil {
 		return nil, err
 	}
-	return model.ToDomainTag(&m), nil
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return model.ToDomainTag(&models[0]), nil
 }
 
 func (r *tagRepository) FindAllByUser(ctx context.Context, userID string) ([]*entity.Tag, error) {
+	return r.findAll(userID, nil)
+}
+
+func (r *tagRepository) FindAllByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Tag, error) {
+	return r.findAll("", &workspaceID)
+}
+
+func (r *tagRepository) findAll(userID string, workspaceID *string) ([]*entity.Tag, error) {
 	builder := r.supabase.DB.
 		From("tags").
-		Select("*", "", false).
-		Eq("user_id", userID)
+		Select("*", "", false)
+	builder = inScope(builder, userID, workspaceID)
 
 	raw, _, err := builder.Execute()
 	if err != nil {
@@ -118,22 +139,33 @@ func (r *tagRepository) Update(ctx context.Context, t *entity.Tag) (*entity.Tag,
 		updates["name"] = t.Name
 	}
 
+	// compare-and-swap: the row only changes while it is at the version that was read
 	builder := r.supabase.DB.
 		From("tags").
-		Update(updates, "*", "").
+		Update(updates, "representation", "").
 		Eq("id", t.ID).
-		Single()
+		Eq("version", strconv.Itoa(t.Version))
 
 	raw, _, err := builder.Execute()
 	if err != nil {
 		return nil, err
 	}
 
-	var m model.TagModel
-	if err := json.Unmarshal(raw, &m); err != nil {
+	var rows []model.TagModel
+	if err := json.Unmarshal(raw, &rows); err != nil {
 		return nil, err
 	}
-	return model.ToDomainTag(&m), nil
+	if len(rows) == 0 {
+		current, err := r.FindByID(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, errors.New("tag not found")
+		}
+		return nil, repository.ErrStaleVersion
+	}
+	return model.ToDomainTag(&rows[0]), nil
 }
 
 func (r *tagRepository) Delete(ctx context.Context, id string) error {
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+func (r *todoRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Todo, error) {
+	valid := validIDs(ids)
+	if len(valid) == 0 {
+		return []*entity.Todo{}, nil
+	}
+
+	raw, _, err := r.supabase.DB.
+		From("todos_with_tag_ids").
+		Select("*", "", false).
+		In("id", valid).
+		Is("deleted_at", "null").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.TodoModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	todos := make([]*entity.Todo, 0, len(models))
+	for i := range models {
+		todos = append(todos, model.ToDomainTodo(&models[i]))
+	}
+	return todos, nil
+}
+
+// CreateMany inserts the todos and their tag links in a single call to the
+// create_todos function; if any row fails, none of them is kept.
+func (r *todoRepository) CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	if len(todos) == 0 {
+		return todos, nil
+	}
+
+	rows := make([]map[string]interface{}, 0, len(todos))
+	for _, t := range todos {
+		t.ID = uuid.NewString()
+		rows = append(rows, todoRow(t))
+	}
+	if err := r.supabase.callRPC("create_todos", map[string]interface{}{"p_todos": rows}, nil); err != nil {
+		return nil, fmt.Errorf("failed to insert todos: %w", err)
+	}
+	// the column default; tag links written in the same call don't advance it
+	for _, t := range todos {
+		t.Version = 1
+	}
+	return todos, nil
+}
+
+func (r *todoRepository) UpdateMany(ctx context.Context, ids []string, patch repository.TodoPatch) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	updates := map[string]interface{}{"updated_at": time.Now().UTC()}
+	if patch.Status != nil {
+		updates["status"] = string(*patch.Status)
+		updates["completed_at"] = patch.CompletedAt
+	}
+	if patch.SetCategory {
+		updates["category_id"] = patch.CategoryID
+	}
+	if patch.SetDueDate {
+		updates["due_date"] = patch.DueDate
+	}
+	if patch.ClearRecurrence {
+		updates["recurrence"] = nil
+	}
+
+	_, _, err := r.supabase.DB.
+		From("todos").
+		Update(updates, "", "").
+		In("id", ids).
+		Is("deleted_at", "null").
+		Execute()
+	if err != nil || len(patch.Ranks) == 0 {
+		return err
+	}
+	// every todo gets its own rank, which one PATCH cannot express
+	return r.supabase.callRPC("update_todo_ranks", map[string]interface{}{"p_ranks": patch.Ranks}, nil)
+}
+
+// AddTags upserts the cross product of ids and tagIDs, so links that already exist are kept.
+func (r *todoRepository) AddTags(ctx context.Context, ids []string, tagIDs []string) error {
+	joins := make([]map[string]interface{}, 0, len(ids)*len(tagIDs))
+	for _, id := range ids {
+		for _, tagID := range tagIDs {
+			joins = append(joins, map[string]interface{}{"todo_id": id, "tag_id": tagID})
+		}
+	}
+	if len(joins) == 0 {
+		return nil
+	}
+
+	_, _, err := r.supabase.DB.
+		From("todo_tags").
+		Insert(joins, true, "todo_id,tag_id", "minimal", "").
+		Execute()
+	return err
+}
+
+func (r *todoRepository) RemoveTags(ctx context.Context, ids []string, tagIDs []string) error {
+	if len(ids) == 0 || len(tagIDs) == 0 {
+		return nil
+	}
+
+	_, _, err := r.supabase.DB.
+		From("todo_tags").
+		Delete("", "").
+		In("todo_id", ids).
+		In("tag_id", tagIDs).
+		Execute()
+	return err
+}
+
+func (r *todoRepository) DeleteMany(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// already trashed todos keep their original deleted_at
+	now := time.Now().UTC()
+	_, _, err := r.supabase.DB.
+		From("todos").
+		Update(map[string]interface{}{"deleted_at": now, "updated_at": now}, "minimal", "").
+		In("id", ids).
+		Is("deleted_at", "null").
+		Execute()
+	return err
+}
@@ -4,10 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"strings"
+	"time"
 
-	"github.com/google/uuid"
+	postgrest "github.com/supabase-community/postgrest-go"
 
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
@@ -22,74 +22,83 @@ func NewTodoRepository(supabase *SupabaseClient) repository.TodoRepository {
 	return &todoRepository{supabase}
 }
 
+// Create inserts the todo and its tag links through the create_todos
+// function, which runs in one transaction: a failed tag link rolls back the todo.
 func (r *todoRepository) Create(ctx context.Context, t *entity.Todo) (*entity.Todo, error) {
-	t.ID = uuid.NewString()
-
-	toInsert := map[string]interface{}{
-		"id":           t.ID,
-		"title":        t.Title,
-		"body":         t.Body,
-		"status":       string(t.Status),
-		"due_date":     t.DueDate,
-		"completed_at": t.CompletedAt,
-		"user_id":      t.UserID,
-		"category_id":  t.CategoryID,
+	if _, err := r.CreateMany(ctx, []*entity.Todo{t}); err != nil {
+		return nil, err
 	}
+	return t, nil
+}
 
-	// Step 1: Insert todo using return=minimal (no unmarshalling needed)
-	_, _, err := r.supabase.DB.
-		From("todos").
-		Insert(toInsert, false, "", "minimal", ""). // ← use return=minimal
-		Single().
-		Execute()
-	if err != nil {
-		return nil, fmt.Errorf("failed to insert todo: %w", err)
+// todoRow is the create_todos input for t, including its tag and assignee IDs.
+func todoRow(t *entity.Todo) map[string]interface{} {
+	tagIDs := t.TagIDs
+	if tagIDs == nil {
+		tagIDs = []string{}
 	}
-
-	// Step 2: Insert into todo_tags
-	for _, tagID := range t.TagIDs {
-		join := map[string]interface{}{
-			"todo_id": t.ID,
-			"tag_id":  tagID,
-		}
-		_, _, err := r.supabase.DB.
-			From("todo_tags").
-			Insert(join, false, "", "minimal", ""). // also minimal here
-			Execute()
-		if err != nil {
-			return nil, fmt.Errorf("failed to insert todo_tag: %w", err)
-		}
+	return map[string]interface{}{
+		"id":            t.ID,
+		"title":         t.Title,
+		"body":          t.Body,
+		"status":        string(t.Status),
+		"priority":      string(t.Priority),
+		"rank":          t.Rank,
+		"due_date":      t.DueDate,
+		"completed_at":  t.CompletedAt,
+		"user_id":       t.UserID,
+		"category_id":   t.CategoryID,
+		"workspace_id":  t.WorkspaceID,
+		"recurrence":    model.FromDomainRecurrence(t.Recurrence),
+		"auto_complete": t.AutoComplete,
+		"tag_ids":       tagIDs,
+		"assignee_ids":  t.AssigneeIDs(),
 	}
-
-	// Step 3: Return the original entity
-	return t, nil
 }
 
+// FindByID returns (nil, nil) when no todo has the given ID.
 func (r *todoRepository) FindByID(ctx context.Context, id string) (*entity.Todo, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+
 	// Assumes a view “todos_with_tag_ids” exists that aggregates tag_ids.
 	builder := r.supabase.DB.
 		From("todos_with_tag_ids").
 		Select("*", "", false).
 		Eq("id", id).
-		Single()
+		Is("deleted_at", "null").
+		Limit(1, "")
 
 	raw, _, err := builder.Execute()
 	if err != nil {
 		return nil, err
 	}
 
-	var m model.TodoModel
-	if err := json.Unmarshal(raw, &m); err != nil {
+	var models []model.TodoModel
+	if err := json.Unmarshal(raw, &models); err != nil {
 		return nil, err
 	}
-	return model.ToDomainTodo(&m), nil
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return model.ToDomainTodo(&models[0]), nil
 }
 
 func (r *todoRepository) FindAllByUser(ctx context.Context, userID string) ([]*entity.Todo, error) {
+	return r.findAll(userID, nil)
+}
+
+func (r *todoRepository) FindAllByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Todo, error) {
+	return r.findAll("", &workspaceID)
+}
+
+func (r *todoRepository) findAll(userID string, workspaceID *string) ([]*entity.Todo, error) {
 	builder := r.supabase.DB.
 		From("todos_with_tag_ids").
-		Select("*", "", false).
-		Eq("user_id", userID)
+		Select("*", "", false)
+	builder = inScope(builder, userID, workspaceID).
+		Is("deleted_at", "null")
 
 	raw, _, err := builder.Execute()
 	if err != nil {
@@ -108,121 +117,161 @@ func (r *todoRepository) FindAllByUser(ctx context.Context, userID string) ([]*e
 	return todos, nil
 }
 
+func (r *todoRepository) FindByQuery(ctx context.Context, q repository.TodoQuery) (*repository.TodoPage, error) {
+	// count=exact makes PostgREST report the full match count in Content-Range
+	builder := r.supabase.DB.
+		From("todos_with_tag_ids").
+		Select("*", "exact", false)
+	builder = inQueryScope(builder, q).
+		Is("deleted_at", "null")
+
+	if q.AssigneeID != nil {
+		builder = builder.Contains("assignee_ids", []string{*q.AssigneeID})
+	}
+	if q.Status != nil {
+		builder = builder.Eq("status", string(*q.Status))
+	}
+	if q.CategoryID != nil {
+		builder = builder.Eq("category_id", *q.CategoryID)
+	}
+	if q.TagID != nil {
+		builder = builder.Contains("tag_ids", []string{*q.TagID})
+	}
+	if q.DueDateFrom != nil {
+		builder = builder.Gte("due_date", q.DueDateFrom.UTC().Format(time.RFC3339))
+	}
+	if q.DueDateTo != nil {
+		builder = builder.Lte("due_date", q.DueDateTo.UTC().Format(time.RFC3339))
+	}
+
+	// order by the requested column, then by id so pages are stable
+	builder = builder.
+		Order(string(q.SortBy), &postgrest.OrderOpts{Ascending: !q.SortDesc}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Range(q.Offset, q.Offset+q.Limit-1, "")
+
+	raw, count, err := builder.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.TodoModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+
+	todos := make([]*entity.Todo, 0, len(models))
+	for _, m := range models {
+		todos = append(todos, model.ToDomainTodo(&m))
+	}
+
+	page := &repository.TodoPage{Todos: todos, Total: int(count)}
+	if next := q.Offset + len(todos); len(todos) > 0 && next < page.Total {
+		page.NextOffset = &next
+	}
+	return page, nil
+}
+
+// Update patches the todo and, when TagIDs is non-nil, replaces its tags
+// through the update_todo function, so either every change lands or none does.
+// The write only applies while the row is still at t.Version.
 func (r *todoRepository) Update(ctx context.Context, t *entity.Todo) (*entity.Todo, error) {
 	if t.ID == "" {
 		return nil, errors.New("todo ID is required")
 	}
 
-	// 1) Update core todo fields
-	updates := map[string]interface{}{}
+	// only the keys present are written; empty fields are left alone
+	fields := map[string]interface{}{}
 	if t.Title != "" {
-		updates["title"] = t.Title
+		fields["title"] = t.Title
 	}
 	if t.Body != nil {
-		updates["body"] = t.Body
+		fields["body"] = t.Body
 	}
 	if t.Status != "" {
-		updates["status"] = string(t.Status)
+		fields["status"] = string(t.Status)
+	}
+	if t.Priority != "" {
+		fields["priority"] = string(t.Priority)
+	}
+	if t.Rank != "" {
+		fields["rank"] = t.Rank
 	}
 	if t.DueDate != nil {
-		updates["due_date"] = t.DueDate
+		fields["due_date"] = t.DueDate
 	}
 	if t.CompletedAt != nil {
-		updates["completed_at"] = t.CompletedAt
+		fields["completed_at"] = t.CompletedAt
+	} else if t.Status != "" && t.Status != entity.StatusCompleted {
+		// a reopened todo is no longer completed
+		fields["completed_at"] = nil
 	}
 	if t.CategoryID != nil {
-		updates["category_id"] = t.CategoryID
-	}
-	if len(updates) > 0 {
-		if _, _, err := r.supabase.DB.
-			From("todos").
-			Update(updates, "", "").
-			Eq("id", t.ID).
-			Execute(); err != nil {
-			return nil, err
-		}
+		fields["category_id"] = t.CategoryID
 	}
+	// always written: a nil rule means the todo stopped recurring
+	fields["recurrence"] = model.FromDomainRecurrence(t.Recurrence)
+	fields["auto_complete"] = t.AutoComplete
 
-	// 2) Only touch tags if caller provided TagIDs
+	// a nil list leaves the tags alone; blanks and duplicates are dropped
+	var tagIDs []string
 	if t.TagIDs != nil {
-		// a) sanitize new list (drop empty)
-		newIDs := make(map[string]struct{}, len(t.TagIDs))
+		tagIDs = []string{}
+		seen := make(map[string]struct{}, len(t.TagIDs))
 		for _, tid := range t.TagIDs {
-			if s := strings.TrimSpace(tid); s != "" {
-				newIDs[s] = struct{}{}
-			}
-		}
-
-		// b) fetch existing tag_ids for this todo
-		raw, _, err := r.supabase.DB.
-			From("todo_tags").
-			Select("tag_id", "", false).
-			Eq("todo_id", t.ID).
-			Execute()
-		if err != nil {
-			return nil, err
-		}
-		var rows []struct {
-			TagID string `json:"tag_id"`
-		}
-		if err := json.Unmarshal(raw, &rows); err != nil {
-			return nil, err
-		}
-		oldIDs := make(map[string]struct{}, len(rows))
-		for _, r := range rows {
-			oldIDs[r.TagID] = struct{}{}
-		}
-
-		// c) compute diffs
-		toAdd := []map[string]interface{}{}
-		for id := range newIDs {
-			if _, seen := oldIDs[id]; !seen {
-				toAdd = append(toAdd, map[string]interface{}{
-					"todo_id": t.ID,
-					"tag_id":  id,
-				})
-			}
-		}
-		toRemove := []string{}
-		for id := range oldIDs {
-			if _, keep := newIDs[id]; !keep {
-				toRemove = append(toRemove, id)
-			}
-		}
-
-		// d) batch delete removed tags
-		if len(toRemove) > 0 {
-			if _, _, err := r.supabase.DB.
-				From("todo_tags").
-				Delete("", "").
-				Eq("todo_id", t.ID).
-				In("tag_id", toRemove).
-				Execute(); err != nil {
-				return nil, err
-			}
-		}
-
-		// e) batch insert added tags
-		if len(toAdd) > 0 {
-			if _, _, err := r.supabase.DB.
-				From("todo_tags").
-				Insert(toAdd, false, "", "", "").
-				Execute(); err != nil {
-				return nil, err
+			s := strings.TrimSpace(tid)
+			if _, dup := seen[s]; s == "" || dup {
+				continue
 			}
+			seen[s] = struct{}{}
+			tagIDs = append(tagIDs, s)
 		}
 	}
 
-	// 3) Return the fresh todo
-	return r.FindByID(ctx, t.ID)
+	params := map[string]interface{}{
+		"p_id":      t.ID,
+		"p_version": t.Version,
+		"p_fields":  fields,
+		"p_tag_ids": tagIDs,
+	}
+	var n int
+	if err := r.supabase.callRPC("update_todo", params, &n); err != nil {
+		return nil, err
+	}
+	updated, err := r.FindByID(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	// nothing matched the ID and version: either the todo is gone or it moved on
+	if updated == nil {
+		return nil, repository.ErrTodoNotFound
+	}
+	if n == 0 {
+		return nil, repository.ErrStaleVersion
+	}
+	return updated, nil
 }
 
-func (r *todoRepository) Delete(ctx context.Context, id string) error {
-	builder := r.supabase.DB.
-		From("todos").
-		Delete("*", "").
-		Eq("id", id)
+// AddAssignee upserts the link, so assigning someone twice keeps the first assignment.
+func (r *todoRepository) AddAssignee(ctx context.Context, todoID, userID string) error {
+	_, _, err := r.supabase.DB.
+		From("todo_assignees").
+		Insert(map[string]interface{}{"todo_id": todoID, "user_id": userID}, true, "todo_id,user_id", "minimal", "").
+		Execute()
+	return err
+}
 
-	_, _, err := builder.Execute()
+func (r *todoRepository) RemoveAssignee(ctx context.Context, todoID, userID string) error {
+	_, _, err := r.supabase.DB.
+		From("todo_assignees").
+		Delete("", "").
+		Eq("todo_id", todoID).
+		Eq("user_id", userID).
+		Execute()
 	return err
 }
+
+// Delete only stamps deleted_at; PurgeTrashed removes the row later.
+func (r *todoRepository) Delete(ctx context.Context, id string) error {
+	return r.DeleteMany(ctx, []string{id})
+}
@@ -0,0 +1,239 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type recordedCall struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]json.RawMessage
+}
+
+// fakePostgREST answers every request with the reply for its path and records
+// the calls, so tests can check how many round trips a write takes.
+func fakePostgREST(t *testing.T, replies map[string]string) (*todoRepository, *[]recordedCall) {
+	supabase, calls := fakeSupabase(t, replies)
+	return &todoRepository{supabase}, calls
+}
+
+// fakeSupabase is fakePostgREST for any repository.
+func fakeSupabase(t *testing.T, replies map[string]string) (*SupabaseClient, *[]recordedCall) {
+	t.Helper()
+	calls := &[]recordedCall{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		call := recordedCall{Method: req.Method, Path: req.URL.Path, Query: req.URL.RawQuery}
+		if raw, _ := io.ReadAll(req.Body); len(raw) > 0 {
+			if err := json.Unmarshal(raw, &call.Body); err != nil {
+				t.Errorf("request body %q: %v", raw, err)
+			}
+		}
+		*calls = append(*calls, call)
+		reply, ok := replies[req.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, reply)
+	}))
+	t.Cleanup(srv.Close)
+
+	db := postgrest.NewClient(srv.URL+"/rest/v1", "public", map[string]string{})
+	return &SupabaseClient{DB: db}, calls
+}
+
+// a foreign key violation raised inside the function, after which Postgres has rolled back
+const fkViolation = `{"code":"23503","message":"insert or update on table \"todo_tags\" violates foreign key constraint \"todo_tags_tag_id_fkey\"","details":null,"hint":null}`
+
+func TestCreateWritesTodoAndTagsInOneCall(t *testing.T) {
+	repo, calls := fakePostgREST(t, map[string]string{"/rest/v1/rpc/create_todos": "1"})
+
+	todo := &entity.Todo{Title: "Pack", Status: entity.StatusTodo, UserID: "u1", TagIDs: []string{"t1", "t2"}}
+	created, err := repo.Create(context.Background(), todo)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("Create did not assign an ID")
+	}
+	if len(*calls) != 1 {
+		t.Fatalf("Create made %d requests, want 1: %+v", len(*calls), *calls)
+	}
+
+	var rows []struct {
+		ID     string   `json:"id"`
+		TagIDs []string `json:"tag_ids"`
+	}
+	if err := json.Unmarshal((*calls)[0].Body["p_todos"], &rows); err != nil {
+		t.Fatalf("p_todos: %v", err)
+	}
+	if len(rows) != 1 || rows[0].ID != created.ID || len(rows[0].TagIDs) != 2 {
+		t.Fatalf("p_todos = %+v", rows)
+	}
+}
+
+func TestCreateReportsRolledBackInsert(t *testing.T) {
+	repo, calls := fakePostgREST(t, map[string]string{"/rest/v1/rpc/create_todos": fkViolation})
+
+	created, err := repo.Create(context.Background(), &entity.Todo{
+		Title: "Pack", Status: entity.StatusTodo, UserID: "u1", TagIDs: []string{"t1", "missing"},
+	})
+	if err == nil || created != nil {
+		t.Fatalf("Create = %+v, %v; want an error", created, err)
+	}
+	// nothing was written outside the function, so there is nothing left to clean up
+	if len(*calls) != 1 {
+		t.Fatalf("Create made %d requests, want 1", len(*calls))
+	}
+}
+
+func TestUpdateReplacesTagsInOneCall(t *testing.T) {
+	const id = "5b0c9a3e-8f0e-4a57-9d33-3f3f4d1c2b10"
+	repo, calls := fakePostgREST(t, map[string]string{
+		"/rest/v1/rpc/update_todo":    "1",
+		"/rest/v1/todos_with_tag_ids": `[{"id":"` + id + `","title":"Renamed","status":"TODO","user_id":"u1","tag_ids":["t2"]}]`,
+	})
+
+	updated, err := repo.Update(context.Background(), &entity.Todo{ID: id, Title: "Renamed", TagIDs: []string{" t2 ", "t2", ""}})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated == nil || updated.Title != "Renamed" {
+		t.Fatalf("Update = %+v", updated)
+	}
+	if len(*calls) != 2 || (*calls)[0].Path != "/rest/v1/rpc/update_todo" {
+		t.Fatalf("calls = %+v", *calls)
+	}
+	if got := string((*calls)[0].Body["p_tag_ids"]); got != `["t2"]` {
+		t.Fatalf("p_tag_ids = %s", got)
+	}
+
+	// leaving TagIDs nil keeps the current tags
+	*calls = nil
+	if _, err := repo.Update(context.Background(), &entity.Todo{ID: id, Title: "Again"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := string((*calls)[0].Body["p_tag_ids"]); got != "null" {
+		t.Fatalf("p_tag_ids = %s, want null", got)
+	}
+}
+
+func TestUpdateClearsCompletedAtOnReopen(t *testing.T) {
+	const id = "5b0c9a3e-8f0e-4a57-9d33-3f3f4d1c2b10"
+	repo, calls := fakePostgREST(t, map[string]string{
+		"/rest/v1/rpc/update_todo":    "1",
+		"/rest/v1/todos_with_tag_ids": `[{"id":"` + id + `","title":"Reopened","status":"TODO","user_id":"u1"}]`,
+	})
+
+	if _, err := repo.Update(context.Background(), &entity.Todo{ID: id, Status: entity.StatusTodo}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal((*calls)[0].Body["p_fields"], &fields); err != nil {
+		t.Fatalf("p_fields: %v", err)
+	}
+	if got, ok := fields["completed_at"]; !ok || string(got) != "null" {
+		t.Fatalf("completed_at = %s (sent %v), want null", got, ok)
+	}
+}
+
+func TestUpdateReportsRolledBackWrite(t *testing.T) {
+	repo, calls := fakePostgREST(t, map[string]string{"/rest/v1/rpc/update_todo": fkViolation})
+
+	updated, err := repo.Update(context.Background(), &entity.Todo{ID: "5b0c9a3e-8f0e-4a57-9d33-3f3f4d1c2b10", Title: "Renamed", TagIDs: []string{"missing"}})
+	if err == nil || updated != nil {
+		t.Fatalf("Update = %+v, %v; want an error", updated, err)
+	}
+	if len(*calls) != 1 {
+		t.Fatalf("Update made %d requests, want 1", len(*calls))
+	}
+}
+
+func TestDeleteMovesTodoToTrash(t *testing.T) {
+	repo, calls := fakePostgREST(t, map[string]string{
+		"/rest/v1/todos":              "",
+		"/rest/v1/todos_with_tag_ids": "[]",
+	})
+	ctx := context.Background()
+	id := "0b7e2a8c-9d2e-4c43-a4a5-2b8f7d1c9e10"
+
+	if err := repo.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	del := (*calls)[0]
+	if del.Method != http.MethodPatch || del.Body["deleted_at"] == nil || !strings.Contains(del.Query, "deleted_at=is.null") {
+		t.Fatalf("Delete sent %s %s?%s %v, want a PATCH stamping deleted_at", del.Method, del.Path, del.Query, del.Body)
+	}
+
+	// reads outside the trash skip trashed rows
+	got, err := repo.FindByID(ctx, id)
+	if err != nil || got != nil {
+		t.Fatalf("FindByID = %v, %v; want nil, nil", got, err)
+	}
+	if q := (*calls)[1].Query; !strings.Contains(q, "deleted_at=is.null") {
+		t.Fatalf("FindByID query %q does not filter out the trash", q)
+	}
+}
+
+func TestFindByQuerySpansMemberWorkspaces(t *testing.T) {
+	repo, calls := fakePostgREST(t, map[string]string{"/rest/v1/todos_with_tag_ids": "[]"})
+	me := "u1"
+
+	_, err := repo.FindByQuery(context.Background(), repository.TodoQuery{
+		UserID:     me,
+		MemberOf:   []string{"w1", "w2"},
+		AssigneeID: &me,
+		SortBy:     repository.TodoSortCreatedAt,
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("FindByQuery: %v", err)
+	}
+	q, _ := url.QueryUnescape((*calls)[0].Query)
+	for _, want := range []string{
+		"or=(and(user_id.eq.u1,workspace_id.is.null),workspace_id.in.(w1,w2))",
+		`assignee_ids=cs.{"u1"}`,
+	} {
+		if !strings.Contains(q, want) {
+			t.Fatalf("query %q does not contain %q", q, want)
+		}
+	}
+	if strings.Contains(q, "user_id=eq") {
+		t.Fatalf("query %q still narrows to personal todos", q)
+	}
+}
+
+func TestUpdateManyWritesRanksInOneCall(t *testing.T) {
+	repo, calls := fakePostgREST(t, map[string]string{
+		"/rest/v1/todos":                 "",
+		"/rest/v1/rpc/update_todo_ranks": "2",
+	})
+
+	status := entity.StatusInProgress
+	err := repo.UpdateMany(context.Background(), []string{"t1", "t2"}, repository.TodoPatch{
+		Status: &status,
+		Ranks:  map[string]string{"t1": "m", "t2": "n"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateMany: %v", err)
+	}
+	if len(*calls) != 2 || (*calls)[1].Path != "/rest/v1/rpc/update_todo_ranks" {
+		t.Fatalf("calls = %+v", *calls)
+	}
+	if got := string((*calls)[1].Body["p_ranks"]); got != `{"t1":"m","t2":"n"}` {
+		t.Fatalf("p_ranks = %s", got)
+	}
+}
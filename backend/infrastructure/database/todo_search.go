@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+// ts_headline wraps matches in these control characters (see search_todos in the README)
+const (
+	headlineStart = "\x01"
+	headlineStop  = "\x02"
+)
+
+// searchResult is the jsonb object returned by the search_todos function.
+type searchResult struct {
+	Total int               `json:"total"`
+	Hits  *[]searchHitModel `json:"hits"`
+}
+
+type searchHitModel struct {
+	model.TodoModel
+	Rank         float64 `json:"rank"`
+	TitleSnippet string  `json:"title_snippet"`
+	BodySnippet  *string `json:"body_snippet"`
+}
+
+func (r *todoRepository) Search(ctx context.Context, q repository.TodoSearch) (*repository.TodoSearchPage, error) {
+	params := map[string]interface{}{
+		"p_user_id": q.UserID,
+		"p_query":   toTSQuery(q.Query),
+		"p_limit":   q.Limit,
+		"p_offset":  q.Offset,
+	}
+	if q.WorkspaceID != nil {
+		params["p_workspace_id"] = *q.WorkspaceID
+	} else if len(q.MemberOf) > 0 {
+		params["p_member_of"] = q.MemberOf
+	}
+	if q.Status != nil {
+		params["p_status"] = string(*q.Status)
+	}
+	if q.CategoryID != nil {
+		params["p_category_id"] = *q.CategoryID
+	}
+	if q.TagID != nil {
+		params["p_tag_id"] = *q.TagID
+	}
+
+	var res searchResult
+	if err := r.supabase.callRPC("search_todos", params, &res); err != nil {
+		return nil, err
+	}
+	if res.Hits == nil {
+		return nil, errors.New("search_todos: response has no hits")
+	}
+
+	page := &repository.TodoSearchPage{Hits: []*repository.TodoSearchHit{}, Total: res.Total}
+	for i := range *res.Hits {
+		m := &(*res.Hits)[i]
+		hit := &repository.TodoSearchHit{
+			Todo:         model.ToDomainTodo(&m.TodoModel),
+			Rank:         m.Rank,
+			TitleSnippet: parseHeadline(m.TitleSnippet),
+		}
+		if m.BodySnippet != nil {
+			hit.BodySnippet = parseHeadline(*m.BodySnippet)
+		}
+		page.Hits = append(page.Hits, hit)
+	}
+	if next := q.Offset + len(page.Hits); len(page.Hits) > 0 && next < page.Total {
+		page.NextOffset = &next
+	}
+	return page, nil
+}
+
+// toTSQuery renders the query in to_tsquery syntax. Words hold only letters
+// and digits, so they never need quoting.
+func toTSQuery(q valueobject.SearchQueryVO) string {
+	terms := make([]string, 0, len(q.Terms))
+	for _, t := range q.Terms {
+		expr := strings.Join(t.Words, " <-> ")
+		if t.Prefix {
+			expr += ":*"
+		}
+		if t.IsPhrase() {
+			expr = "(" + expr + ")"
+		}
+		terms = append(terms, expr)
+	}
+	return strings.Join(terms, " & ")
+}
+
+// parseHeadline splits ts_headline output at the highlight markers.
+func parseHeadline(s string) []repository.TextSegment {
+	segs := []repository.TextSegment{}
+	for s != "" {
+		start := strings.Index(s, headlineStart)
+		if start < 0 {
+			segs = append(segs, repository.TextSegment{Text: s})
+			break
+		}
+		if start > 0 {
+			segs = append(segs, repository.TextSegment{Text: s[:start]})
+		}
+		s = s[start+len(headlineStart):]
+		stop := strings.Index(s, headlineStop)
+		if stop < 0 {
+			stop = len(s)
+		}
+		segs = append(segs, repository.TextSegment{Text: s[:stop], Match: true})
+		s = strings.TrimPrefix(s[stop:], headlineStop)
+	}
+	return segs
+}
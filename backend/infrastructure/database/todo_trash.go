@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+func (r *todoRepository) FindTrashByUser(ctx context.Context, userID string) ([]*entity.Todo, error) {
+	return r.findTrash(userID, nil)
+}
+
+func (r *todoRepository) FindTrashByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Todo, error) {
+	return r.findTrash("", &workspaceID)
+}
+
+func (r *todoRepository) findTrash(userID string, workspaceID *string) ([]*entity.Todo, error) {
+	builder := r.supabase.DB.
+		From("todos_with_tag_ids").
+		Select("*", "", false)
+	raw, _, err := inScope(builder, userID, workspaceID).
+		Not("deleted_at", "is", "null").
+		Order("deleted_at", &postgrest.OrderOpts{Ascending: false}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+	return decodeTodos(raw)
+}
+
+// FindTrashedByID returns (nil, nil) unless the todo exists and is in the trash.
+func (r *todoRepository) FindTrashedByID(ctx context.Context, id string) (*entity.Todo, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+	raw, _, err := r.supabase.DB.
+		From("todos_with_tag_ids").
+		Select("*", "", false).
+		Eq("id", id).
+		Not("deleted_at", "is", "null").
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+	todos, err := decodeTodos(raw)
+	if err != nil || len(todos) == 0 {
+		return nil, err
+	}
+	return todos[0], nil
+}
+
+func (r *todoRepository) Restore(ctx context.Context, id string) (*entity.Todo, error) {
+	raw, _, err := r.supabase.DB.
+		From("todos").
+		Update(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now().UTC()}, "representation", "").
+		Eq("id", id).
+		Not("deleted_at", "is", "null").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+	var rows []model.TodoModel
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("todo is not in the trash")
+	}
+	// re-read through the view for the tag IDs and checklist progress
+	return r.FindByID(ctx, id)
+}
+
+// PurgeTrashed deletes the rows outright; todo_tags and checklist_items
+// follow through their on delete cascade.
+func (r *todoRepository) PurgeTrashed(ctx context.Context, cutoff time.Time) (int, error) {
+	_, count, err := r.supabase.DB.
+		From("todos").
+		Delete("minimal", "exact").
+		Lt("deleted_at", cutoff.UTC().Format(time.RFC3339)).
+		Execute()
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func decodeTodos(raw []byte) ([]*entity.Todo, error) {
+	var models []model.TodoModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	todos := make([]*entity.Todo, 0, len(models))
+	for i := range models {
+		todos = append(todos, model.ToDomainTodo(&models[i]))
+	}
+	return todos, nil
+}
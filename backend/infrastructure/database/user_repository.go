@@ -3,11 +3,12 @@ package database
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
@@ -41,7 +42,7 @@ func (r *userRepository) Create(ctx context.Context, u *entity.User) (*entity.Us
 		Execute(); err != nil {
 		// handle duplicate‐email more cleanly
 		if strings.Contains(err.Error(), "duplicate key value") {
-			return nil, errors.New("email is already taken")
+			return nil, apperror.Conflict("email is already taken")
 		}
 		return nil, err
 	}
@@ -50,50 +51,56 @@ func (r *userRepository) Create(ctx context.Context, u *entity.User) (*entity.Us
 	return r.FindByID(ctx, u.ID)
 }
 
+// FindByEmail returns (nil, nil) when no user has the given email.
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	// SELECT * FROM users WHERE email = '<email>' LIMIT 1
-	builder := r.supabase.DB.
-		From("users").
-		Select("*", "", false).
-		Eq("email", email).
-		Single()
-
-	raw, _, err := builder.Execute()
-	if err != nil {
-		return nil, err
-	}
-
-	var m model.UserModel
-	if err := json.Unmarshal(raw, &m); err != nil {
-		return nil, err
-	}
-	return model.ToDomainUser(&m), nil
+	return r.findOne("email", email)
 }
 
-// FindByID fetches a user by its ID. Returns an error if not found.
+// FindByID fetches a user by its ID. Returns (nil, nil) if not found.
 func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
 	// SELECT * FROM users WHERE id = '<id>' LIMIT 1
+	if !validID(id) {
+		return nil, nil
+	}
+	return r.findOne("id", id)
+}
+
+func (r *userRepository) findOne(column, value string) (*entity.User, error) {
+	// no Single(): PostgREST reports zero rows as an error there
 	builder := r.supabase.DB.
 		From("users").
 		Select("*", "", false).
-		Eq("id", id).
-		Single()
+		Eq(column, value).
+		Limit(1, "")
 
 	raw, _, err := builder.Execute()
 	if err != nil {
 		return nil, err
 	}
 
-	// If raw is empty or unmarshal fails, treat as “not found”
-	var m model.UserModel
-	if err := json.Unmarshal(raw, &m); err != nil {
+	var models []model.UserModel
+	if err := json.Unmarshal(raw, &models); err != nil {
 		return nil, err
 	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return model.ToDomainUser(&models[0]), nil
+}
 
-	// Convert to domain and return
-	userEntity := model.ToDomainUser(&m)
-	if userEntity == nil || userEntity.ID == "" {
-		return nil, errors.New("user not found")
+func (r *userRepository) SetAvatar(ctx context.Context, id string, avatarURL, avatarKey *string) (*entity.User, error) {
+	updates := map[string]interface{}{
+		"avatar_url": avatarURL,
+		"avatar_key": avatarKey,
+		"updated_at": time.Now().UTC(),
+	}
+	if _, _, err := r.supabase.DB.
+		From("users").
+		Update(updates, "", "").
+		Eq("id", id).
+		Execute(); err != nil {
+		return nil, err
 	}
-	return userEntity, nil
+	return r.FindByID(ctx, id)
 }
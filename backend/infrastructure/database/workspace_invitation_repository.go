@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type workspaceInvitationRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewWorkspaceInvitationRepository(supabase *SupabaseClient) repository.WorkspaceInvitationRepository {
+	return &workspaceInvitationRepository{supabase}
+}
+
+func (r *workspaceInvitationRepository) Create(ctx context.Context, inv *entity.WorkspaceInvitation) (*entity.WorkspaceInvitation, error) {
+	if _, _, err := r.supabase.DB.
+		From("workspace_invitations").
+		Insert(model.FromDomainWorkspaceInvitation(inv), false, "", "minimal", "").
+		Execute(); err != nil {
+		// the unique (workspace_id, email) constraint
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return nil, apperror.Conflict("that email has already been invited")
+		}
+		return nil, fmt.Errorf("failed to insert workspace invitation: %w", err)
+	}
+	return inv, nil
+}
+
+// FindByID returns (nil, nil) when no invitation has the given ID.
+func (r *workspaceInvitationRepository) FindByID(ctx context.Context, id string) (*entity.WorkspaceInvitation, error) {
+	if !validID(id) {
+		return nil, nil
+	}
+	invitations, err := r.find("id", id)
+	if err != nil || len(invitations) == 0 {
+		return nil, err
+	}
+	return invitations[0], nil
+}
+
+func (r *workspaceInvitationRepository) FindByEmail(ctx context.Context, email string) ([]*entity.WorkspaceInvitation, error) {
+	return r.find("email", email)
+}
+
+func (r *workspaceInvitationRepository) FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.WorkspaceInvitation, error) {
+	return r.find("workspace_id", workspaceID)
+}
+
+// find returns the invitations whose column equals value, newest first.
+func (r *workspaceInvitationRepository) find(column, value string) ([]*entity.WorkspaceInvitation, error) {
+	raw, _, err := r.supabase.DB.
+		From("workspace_invitations").
+		Select("*", "", false).
+		Eq(column, value).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.WorkspaceInvitationModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	invitations := make([]*entity.WorkspaceInvitation, 0, len(models))
+	for i := range models {
+		invitations = append(invitations, model.ToDomainWorkspaceInvitation(&models[i]))
+	}
+	return invitations, nil
+}
+
+func (r *workspaceInvitationRepository) Delete(ctx context.Context, id string) error {
+	_, _, err := r.supabase.DB.
+		From("workspace_invitations").
+		Delete("minimal", "").
+		Eq("id", id).
+		Execute()
+	return err
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	postgrest "github.com/supabase-community/postgrest-go"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/infrastructure/database/model"
+)
+
+type workspaceRepository struct {
+	supabase *SupabaseClient
+}
+
+func NewWorkspaceRepository(supabase *SupabaseClient) repository.WorkspaceRepository {
+	return &workspaceRepository{supabase}
+}
+
+// Create inserts the workspace and its owner in one transaction through the
+// create_workspace function, which returns the new row.
+func (r *workspaceRepository) Create(ctx context.Context, w *entity.Workspace, owner *entity.WorkspaceMember) (*entity.Workspace, error) {
+	var m model.WorkspaceModel
+	err := r.supabase.callRPC("create_workspace", map[string]interface{}{
+		"p_id":       w.ID,
+		"p_name":     w.Name,
+		"p_owner_id": owner.UserID,
+	}, &m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	if m.ID == "" {
+		return nil, errors.New("failed to create workspace: create_workspace returned no row")
+	}
+	return model.ToDomainWorkspace(&m), nil
+}
+
+// FindByID returns (nil, nil) when no workspace has the given ID.
+func (r *workspaceRepository) FindByID(ctx context.Context, id string) (*entity.Workspace, error) {
+	workspaces, err := r.FindByIDs(ctx, []string{id})
+	if err != nil || len(workspaces) == 0 {
+		return nil, err
+	}
+	return workspaces[0], nil
+}
+
+func (r *workspaceRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Workspace, error) {
+	valid := validIDs(ids)
+	if len(valid) == 0 {
+		return []*entity.Workspace{}, nil
+	}
+
+	raw, _, err := r.supabase.DB.
+		From("workspaces").
+		Select("*", "", false).
+		In("id", valid).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.WorkspaceModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	workspaces := make([]*entity.Workspace, 0, len(models))
+	for i := range models {
+		workspaces = append(workspaces, model.ToDomainWorkspace(&models[i]))
+	}
+	return workspaces, nil
+}
+
+func (r *workspaceRepository) Update(ctx context.Context, w *entity.Workspace) (*entity.Workspace, error) {
+	updates := map[string]interface{}{}
+	if w.Name != "" {
+		updates["name"] = w.Name
+	}
+	raw, _, err := r.supabase.DB.
+		From("workspaces").
+		Update(updates, "representation", "").
+		Eq("id", w.ID).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.WorkspaceModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, errors.New("workspace not found")
+	}
+	return model.ToDomainWorkspace(&models[0]), nil
+}
+
+// Delete relies on the workspace_id foreign keys, which are all "on delete cascade".
+func (r *workspaceRepository) Delete(ctx context.Context, id string) error {
+	_, _, err := r.supabase.DB.
+		From("workspaces").
+		Delete("minimal", "").
+		Eq("id", id).
+		Execute()
+	return err
+}
+
+// FindMember returns (nil, nil) when the user is not a member.
+func (r *workspaceRepository) FindMember(ctx context.Context, workspaceID, userID string) (*entity.WorkspaceMember, error) {
+	if !validID(workspaceID) {
+		return nil, nil
+	}
+	members, err := r.findMembers(r.supabase.DB.
+		From("workspace_members").
+		Select("*", "", false).
+		Eq("workspace_id", workspaceID).
+		Eq("user_id", userID))
+	if err != nil || len(members) == 0 {
+		return nil, err
+	}
+	return members[0], nil
+}
+
+func (r *workspaceRepository) FindMembers(ctx context.Context, workspaceID string) ([]*entity.WorkspaceMember, error) {
+	return r.findMembers(r.supabase.DB.
+		From("workspace_members").
+		Select("*", "", false).
+		Eq("workspace_id", workspaceID))
+}
+
+func (r *workspaceRepository) FindMemberships(ctx context.Context, userID string) ([]*entity.WorkspaceMember, error) {
+	return r.findMembers(r.supabase.DB.
+		From("workspace_members").
+		Select("*", "", false).
+		Eq("user_id", userID))
+}
+
+// findMembers runs a workspace_members query, oldest member first.
+func (r *workspaceRepository) findMembers(builder *postgrest.FilterBuilder) ([]*entity.WorkspaceMember, error) {
+	raw, _, err := builder.
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		Order("user_id", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.WorkspaceMemberModel
+	if err := json.Unmarshal(raw, &models); err != nil {
+		return nil, err
+	}
+	members := make([]*entity.WorkspaceMember, 0, len(models))
+	for i := range models {
+		members = append(members, model.ToDomainWorkspaceMember(&models[i]))
+	}
+	return members, nil
+}
+
+// SaveMember upserts on the (workspace_id, user_id) primary key.
+func (r *workspaceRepository) SaveMember(ctx context.Context, m *entity.WorkspaceMember) error {
+	if _, _, err := r.supabase.DB.
+		From("workspace_members").
+		Upsert(model.FromDomainWorkspaceMember(m), "workspace_id,user_id", "minimal", "").
+		Execute(); err != nil {
+		return fmt.Errorf("failed to save workspace member: %w", err)
+	}
+	return nil
+}
+
+func (r *workspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	_, _, err := r.supabase.DB.
+		From("workspace_members").
+		Delete("minimal", "").
+		Eq("workspace_id", workspaceID).
+		Eq("user_id", userID).
+		Execute()
+	return err
+}
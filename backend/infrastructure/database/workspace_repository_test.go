@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+func TestCreateWorkspaceReturnsTheInsertedRow(t *testing.T) {
+	const id = "0e6b1f0a-4c1d-4f43-9a7e-2d5b8c9e1f20"
+	supabase, calls := fakeSupabase(t, map[string]string{
+		"/rest/v1/rpc/create_workspace": `{"id":"` + id + `","name":"Home","created_at":"2026-10-17T09:00:00Z","updated_at":"2026-10-17T09:00:00Z"}`,
+	})
+	repo := NewWorkspaceRepository(supabase)
+
+	w := &entity.Workspace{ID: id, Name: "Home"}
+	created, err := repo.Create(context.Background(), w, &entity.WorkspaceMember{WorkspaceID: id, UserID: "u1", Role: entity.RoleOwner})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID != id || created.Name != "Home" || created.CreatedAt.IsZero() {
+		t.Fatalf("Create = %+v", created)
+	}
+	if len(*calls) != 1 || string((*calls)[0].Body["p_owner_id"]) != `"u1"` {
+		t.Fatalf("calls = %+v", *calls)
+	}
+}
+
+func TestCreateWorkspaceRejectsAnEmptyResult(t *testing.T) {
+	supabase, _ := fakeSupabase(t, map[string]string{"/rest/v1/rpc/create_workspace": "null"})
+	repo := NewWorkspaceRepository(supabase)
+
+	created, err := repo.Create(context.Background(), &entity.Workspace{ID: "w1", Name: "Home"}, &entity.WorkspaceMember{UserID: "u1"})
+	if err == nil || created != nil {
+		t.Fatalf("Create = %+v, %v; want an error", created, err)
+	}
+}
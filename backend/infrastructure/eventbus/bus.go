@@ -0,0 +1,133 @@
+package eventbus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+)
+
+const (
+	// historySize is how many recent events are kept for reconnecting clients.
+	historySize = 1024
+	// bufferSize is how far a subscriber may lag before it is dropped.
+	bufferSize = 64
+)
+
+// Bus is an in-process event.Bus. Events only reach subscribers connected to
+// the same process.
+type Bus struct {
+	mu      sync.Mutex
+	lastID  int64
+	history []event.Event // oldest first
+	subs    map[*subscription]struct{}
+}
+
+var _ event.Bus = (*Bus)(nil)
+
+func NewBus() *Bus {
+	// IDs start at the boot time in microseconds, so they keep growing across
+	// restarts and a Last-Event-ID from an earlier process reads as a gap.
+	return &Bus{
+		lastID: time.Now().UnixMicro(),
+		subs:   map[*subscription]struct{}{},
+	}
+}
+
+func (b *Bus) Publish(e event.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	e.ID = b.lastID
+	e.At = time.Now().UTC()
+
+	if len(b.history) == historySize {
+		copy(b.history, b.history[1:])
+		b.history = b.history[:historySize-1]
+	}
+	b.history = append(b.history, e)
+
+	for s := range b.subs {
+		if !s.follows(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			// too far behind; it resumes from history when it reconnects
+			b.remove(s)
+		}
+	}
+}
+
+func (b *Bus) Subscribe(userID string, workspaceIDs []string, lastID int64) (event.Subscription, []event.Event, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := &subscription{
+		bus:        b,
+		userID:     userID,
+		workspaces: make(map[string]struct{}, len(workspaceIDs)),
+		ch:         make(chan event.Event, bufferSize),
+	}
+	for _, id := range workspaceIDs {
+		s.workspaces[id] = struct{}{}
+	}
+	b.subs[s] = struct{}{}
+
+	if lastID == 0 {
+		return s, nil, true
+	}
+	if lastID < 0 || lastID > b.lastID {
+		return s, nil, false
+	}
+	// some events after lastID have already been dropped from history
+	if lastID < b.lastID && (len(b.history) == 0 || b.history[0].ID > lastID+1) {
+		return s, nil, false
+	}
+
+	var backlog []event.Event
+	for _, e := range b.history {
+		if e.ID > lastID && s.follows(e) {
+			backlog = append(backlog, e)
+		}
+	}
+	return s, backlog, true
+}
+
+// remove unregisters s and closes its channel. Callers must hold b.mu.
+func (b *Bus) remove(s *subscription) {
+	if _, ok := b.subs[s]; !ok {
+		return
+	}
+	delete(b.subs, s)
+	close(s.ch)
+}
+
+type subscription struct {
+	bus        *Bus
+	userID     string
+	workspaces map[string]struct{}
+	ch         chan event.Event
+}
+
+// follows reports whether e is one of the user's personal events or belongs
+// to one of their workspaces.
+func (s *subscription) follows(e event.Event) bool {
+	if e.WorkspaceID != "" {
+		_, ok := s.workspaces[e.WorkspaceID]
+		return ok
+	}
+	return e.UserID == s.userID
+}
+
+func (s *subscription) Events() <-chan event.Event {
+	return s.ch
+}
+
+func (s *subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.remove(s)
+}
@@ -0,0 +1,106 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+)
+
+func publishTodo(b *Bus, userID, id string) {
+	b.Publish(event.New(userID, event.ResourceTodo, event.ActionUpdated, id, nil))
+}
+
+func TestBusResumesFromLastID(t *testing.T) {
+	b := NewBus()
+	sub, _, _ := b.Subscribe("u1", nil, 0)
+	publishTodo(b, "u1", "a")
+	seen := <-sub.Events()
+	sub.Close()
+
+	publishTodo(b, "u2", "other user")
+	publishTodo(b, "u1", "b")
+	publishTodo(b, "u1", "c")
+
+	sub, backlog, complete := b.Subscribe("u1", nil, seen.ID)
+	defer sub.Close()
+	if !complete || len(backlog) != 2 || backlog[0].EntityID != "b" || backlog[1].EntityID != "c" {
+		t.Fatalf("backlog = %+v, complete = %v; want b, c", backlog, complete)
+	}
+
+	// nothing missed
+	_, backlog, complete = b.Subscribe("u1", nil, backlog[1].ID)
+	if !complete || len(backlog) != 0 {
+		t.Fatalf("up-to-date resume: backlog = %+v, complete = %v", backlog, complete)
+	}
+}
+
+func TestBusReportsGaps(t *testing.T) {
+	b := NewBus()
+	sub, _, _ := b.Subscribe("u1", nil, 0)
+	publishTodo(b, "u1", "first")
+	first := <-sub.Events()
+	sub.Close()
+
+	for i := 0; i < historySize+1; i++ {
+		publishTodo(b, "u1", "filler")
+	}
+	if _, backlog, complete := b.Subscribe("u1", nil, first.ID); complete || backlog != nil {
+		t.Fatalf("resume past history: complete = %v, %d events", complete, len(backlog))
+	}
+	// an ID from before this process started, or from the future
+	for _, id := range []int64{1, first.ID + 10*historySize, -1} {
+		if _, _, complete := b.Subscribe("u1", nil, id); complete {
+			t.Fatalf("Subscribe(%d) reported complete", id)
+		}
+	}
+}
+
+func TestBusDropsSlowSubscribers(t *testing.T) {
+	b := NewBus()
+	slow, _, _ := b.Subscribe("u1", nil, 0)
+	for i := 0; i < bufferSize+1; i++ {
+		publishTodo(b, "u1", "x")
+	}
+	n := 0
+	for range slow.Events() {
+		n++
+	}
+	if n != bufferSize {
+		t.Fatalf("received %d events before the channel closed, want %d", n, bufferSize)
+	}
+	slow.Close() // closing again is harmless
+}
+
+func TestBusFansOutWorkspaceEvents(t *testing.T) {
+	b := NewBus()
+	member, _, _ := b.Subscribe("u2", []string{"w1"}, 0)
+	defer member.Close()
+	outsider, _, _ := b.Subscribe("u3", nil, 0)
+	defer outsider.Close()
+
+	ws := "w1"
+	b.Publish(event.New("u1", event.ResourceTodo, event.ActionCreated, "shared", nil).In(&ws))
+	publishTodo(b, "u1", "personal")
+	publishTodo(b, "u2", "own")
+
+	if e := <-member.Events(); e.EntityID != "shared" || e.WorkspaceID != "w1" {
+		t.Fatalf("member got %+v, want the workspace event", e)
+	}
+	// u1's personal todo stays private; u2's own events still arrive
+	own := <-member.Events()
+	if own.EntityID != "own" {
+		t.Fatalf("member got %+v, want their own event", own)
+	}
+	select {
+	case e := <-outsider.Events():
+		t.Fatalf("outsider got %+v", e)
+	default:
+	}
+
+	// a resumed member replays the workspace's events too
+	b.Publish(event.New("u1", event.ResourceTodo, event.ActionUpdated, "shared", nil).In(&ws))
+	_, backlog, complete := b.Subscribe("u2", []string{"w1"}, own.ID)
+	if !complete || len(backlog) != 1 || backlog[0].EntityID != "shared" {
+		t.Fatalf("backlog = %+v, complete = %v", backlog, complete)
+	}
+}
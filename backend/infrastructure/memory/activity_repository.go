@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type activityRepository struct {
+	store *Store
+}
+
+func NewActivityRepository(store *Store) repository.ActivityRepository {
+	return &activityRepository{store}
+}
+
+func (r *activityRepository) CreateMany(ctx context.Context, entries []*entity.Activity) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, e := range entries {
+		row := *e
+		if row.ID == "" {
+			row.ID = uuid.NewString()
+		}
+		row.Changes = slices.Clone(e.Changes)
+		r.store.activities = append(r.store.activities, &row)
+	}
+	return nil
+}
+
+func (r *activityRepository) FindByQuery(ctx context.Context, q repository.ActivityQuery) (*repository.ActivityPage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var matched []*entity.Activity
+	// walk backwards: entries were appended in the order they happened
+	for i := len(r.store.activities) - 1; i >= 0; i-- {
+		row := r.store.activities[i]
+		if (q.UserID != "" && row.UserID != q.UserID) ||
+			(q.Resource != "" && row.Resource != q.Resource) ||
+			(q.ResourceID != "" && row.ResourceID != q.ResourceID) {
+			continue
+		}
+		out := *row
+		matched = append(matched, &out)
+	}
+
+	page := &repository.ActivityPage{Entries: []*entity.Activity{}, Total: len(matched)}
+	if q.Offset < len(matched) {
+		end := min(q.Offset+q.Limit, len(matched))
+		page.Entries = matched[q.Offset:end]
+	}
+	if next := q.Offset + len(page.Entries); len(page.Entries) > 0 && next < page.Total {
+		page.NextOffset = &next
+	}
+	return page, nil
+}
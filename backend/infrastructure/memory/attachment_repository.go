@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type attachmentRepository struct {
+	store *Store
+}
+
+func NewAttachmentRepository(store *Store) repository.AttachmentRepository {
+	return &attachmentRepository{store}
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, a *entity.Attachment) (*entity.Attachment, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.todos[a.TodoID]; !ok {
+		return nil, errors.New("failed to insert attachment: todo does not exist")
+	}
+	if _, ok := r.store.attachments[a.ID]; ok {
+		return nil, errors.New("failed to insert attachment: duplicate id")
+	}
+	row := *a
+	row.CreatedAt = time.Now().UTC()
+	r.store.attachments[row.ID] = &row
+
+	created := row
+	return &created, nil
+}
+
+// FindByID returns (nil, nil) when no attachment has the given ID.
+func (r *attachmentRepository) FindByID(ctx context.Context, id string) (*entity.Attachment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.attachments[id]
+	if !ok {
+		return nil, nil
+	}
+	a := *row
+	return &a, nil
+}
+
+func (r *attachmentRepository) FindAllByTodo(ctx context.Context, todoID string) ([]*entity.Attachment, error) {
+	return r.find(func(a *entity.Attachment) bool { return a.TodoID == todoID }, 0), nil
+}
+
+func (r *attachmentRepository) FindOrphaned(ctx context.Context, limit int) ([]*entity.Attachment, error) {
+	return r.find(func(a *entity.Attachment) bool { return a.TodoID == "" }, limit), nil
+}
+
+// find returns copies of the matching rows, oldest first, at most limit of
+// them unless limit is 0.
+func (r *attachmentRepository) find(match func(*entity.Attachment) bool, limit int) []*entity.Attachment {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []*entity.Attachment{}
+	for _, row := range r.store.attachments {
+		if match(row) {
+			a := *row
+			matched = append(matched, &a)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.attachments, id)
+	return nil
+}
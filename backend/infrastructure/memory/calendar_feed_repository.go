@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type calendarFeedRepository struct {
+	store *Store
+}
+
+func NewCalendarFeedRepository(store *Store) repository.CalendarFeedRepository {
+	return &calendarFeedRepository{store}
+}
+
+func (r *calendarFeedRepository) Save(ctx context.Context, f *entity.CalendarFeed) (*entity.CalendarFeed, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := *f
+	r.store.calendarFeeds[row.UserID] = &row
+
+	out := row
+	return &out, nil
+}
+
+// FindByUser returns (nil, nil) when the user has no feed.
+func (r *calendarFeedRepository) FindByUser(ctx context.Context, userID string) (*entity.CalendarFeed, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.calendarFeeds[userID]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+// FindByTokenHash returns (nil, nil) when no feed has the given token.
+func (r *calendarFeedRepository) FindByTokenHash(ctx context.Context, hash string) (*entity.CalendarFeed, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, row := range r.store.calendarFeeds {
+		if row.TokenHash == hash {
+			out := *row
+			return &out, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *calendarFeedRepository) Delete(ctx context.Context, userID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.calendarFeeds, userID)
+	return nil
+}
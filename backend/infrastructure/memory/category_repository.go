@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type categoryRepository struct {
+	store *Store
+}
+
+func NewCategoryRepository(store *Store) repository.CategoryRepository {
+	return &categoryRepository{store}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, c *entity.Category) (*entity.Category, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	c.ID = uuid.NewString()
+	row := *c
+	row.Version = 1
+	now := time.Now().UTC()
+	row.CreatedAt, row.UpdatedAt = now, now
+	r.store.categories[c.ID] = &row
+
+	out := row
+	return &out, nil
+}
+
+// FindByID returns (nil, nil) when no category has the given ID.
+func (r *categoryRepository) FindByID(ctx context.Context, id string) (*entity.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.categories[id]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+func (r *categoryRepository) FindAllByUser(ctx context.Context, userID string) ([]*entity.Category, error) {
+	return r.findAll(userID, nil), nil
+}
+
+func (r *categoryRepository) FindAllByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Category, error) {
+	return r.findAll("", &workspaceID), nil
+}
+
+// findAll lists the categories in scope, oldest first; see inScope.
+func (r *categoryRepository) findAll(userID string, scope *string) []*entity.Category {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var cats []*entity.Category
+	for _, row := range r.store.categories {
+		if inScope(row.UserID, row.WorkspaceID, userID, scope) {
+			out := *row
+			cats = append(cats, &out)
+		}
+	}
+	sort.Slice(cats, func(i, j int) bool {
+		return cats[i].CreatedAt.Before(cats[j].CreatedAt)
+	})
+	return cats
+}
+
+func (r *categoryRepository) Update(ctx context.Context, c *entity.Category) (*entity.Category, error) {
+	if c.ID == "" {
+		return nil, errors.New("category ID is required")
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.categories[c.ID]
+	if !ok {
+		return nil, errors.New("category not found")
+	}
+	if row.Version != c.Version {
+		return nil, repository.ErrStaleVersion
+	}
+	if c.Name != "" {
+		row.Name = c.Name
+	}
+	if c.Color != "" {
+		row.Color = c.Color
+	}
+	if c.Description != nil {
+		row.Description = c.Description
+	}
+	row.UpdatedAt = time.Now().UTC()
+	row.Version++
+
+	out := *row
+	return &out, nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.categories, id)
+	// todos.category_id is "on delete set null"
+	for _, t := range r.store.todos {
+		if t.CategoryID != nil && *t.CategoryID == id {
+			t.CategoryID = nil
+			t.Version++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type checklistItemRepository struct {
+	store *Store
+}
+
+func NewChecklistItemRepository(store *Store) repository.ChecklistItemRepository {
+	return &checklistItemRepository{store}
+}
+
+func (r *checklistItemRepository) Create(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.todos[item.TodoID]; !ok {
+		return nil, errors.New("failed to insert checklist item: todo does not exist")
+	}
+	item.ID = uuid.NewString()
+	row := *item
+	now := time.Now().UTC()
+	row.CreatedAt, row.UpdatedAt = now, now
+	r.store.items[row.ID] = &row
+	r.store.bumpTodo(row.TodoID)
+
+	created := row
+	return &created, nil
+}
+
+// FindByID returns (nil, nil) when no item has the given ID.
+func (r *checklistItemRepository) FindByID(ctx context.Context, id string) (*entity.ChecklistItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.items[id]
+	if !ok {
+		return nil, nil
+	}
+	item := *row
+	return &item, nil
+}
+
+func (r *checklistItemRepository) FindAllByTodo(ctx context.Context, todoID string) ([]*entity.ChecklistItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	items := []*entity.ChecklistItem{}
+	for _, row := range r.store.items {
+		if row.TodoID == todoID {
+			item := *row
+			items = append(items, &item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items, nil
+}
+
+func (r *checklistItemRepository) Update(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.items[item.ID]
+	if !ok {
+		return nil, errors.New("checklist item not found")
+	}
+	row.Title = item.Title
+	row.Done = item.Done
+	row.Position = item.Position
+	row.UpdatedAt = time.Now().UTC()
+	r.store.bumpTodo(row.TodoID)
+
+	updated := *row
+	return &updated, nil
+}
+
+func (r *checklistItemRepository) Reorder(ctx context.Context, todoID string, itemIDs []string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, id := range itemIDs {
+		if row, ok := r.store.items[id]; !ok || row.TodoID != todoID {
+			return errors.New("checklist item does not belong to todo")
+		}
+	}
+	now := time.Now().UTC()
+	for i, id := range itemIDs {
+		r.store.items[id].Position = i
+		r.store.items[id].UpdatedAt = now
+	}
+	r.store.bumpTodo(todoID)
+	return nil
+}
+
+func (r *checklistItemRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if row, ok := r.store.items[id]; ok {
+		delete(r.store.items, id)
+		r.store.bumpTodo(row.TodoID)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type commentRepository struct {
+	store *Store
+}
+
+func NewCommentRepository(store *Store) repository.CommentRepository {
+	return &commentRepository{store}
+}
+
+func (r *commentRepository) Create(ctx context.Context, c *entity.Comment) (*entity.Comment, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.todos[c.TodoID]; !ok {
+		return nil, errors.New("failed to insert comment: todo does not exist")
+	}
+	c.ID = uuid.NewString()
+	row := *c
+	now := time.Now().UTC()
+	row.CreatedAt, row.UpdatedAt = now, now
+	r.store.comments[row.ID] = &row
+
+	created := row
+	return &created, nil
+}
+
+// FindByID returns (nil, nil) when no comment has the given ID.
+func (r *commentRepository) FindByID(ctx context.Context, id string) (*entity.Comment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.comments[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *row
+	return &c, nil
+}
+
+func (r *commentRepository) FindByQuery(ctx context.Context, q repository.CommentQuery) (*repository.CommentPage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var matched []*entity.Comment
+	for _, row := range r.store.comments {
+		if row.TodoID == q.TodoID {
+			c := *row
+			matched = append(matched, &c)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	page := &repository.CommentPage{Comments: []*entity.Comment{}, Total: len(matched)}
+	if q.Offset < len(matched) {
+		end := min(q.Offset+q.Limit, len(matched))
+		page.Comments = matched[q.Offset:end]
+	}
+	if next := q.Offset + len(page.Comments); len(page.Comments) > 0 && next < page.Total {
+		page.NextOffset = &next
+	}
+	return page, nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, c *entity.Comment) (*entity.Comment, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.comments[c.ID]
+	if !ok {
+		return nil, errors.New("comment not found")
+	}
+	row.Body = c.Body
+	row.Edited = c.Edited
+	row.UpdatedAt = time.Now().UTC()
+
+	updated := *row
+	return &updated, nil
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.comments, id)
+	return nil
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type notificationRepository struct {
+	store *Store
+}
+
+func NewNotificationRepository(store *Store) repository.NotificationRepository {
+	return &notificationRepository{store}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, n *entity.Notification) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.notifications[n.ID]; exists {
+		return nil
+	}
+	row := *n
+	r.store.notifications[row.ID] = &row
+	return nil
+}
+
+func (r *notificationRepository) FindByUser(ctx context.Context, userID string, limit int) ([]*entity.Notification, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	notifications := []*entity.Notification{}
+	for _, row := range r.store.notifications {
+		if row.UserID == userID {
+			out := *row
+			notifications = append(notifications, &out)
+		}
+	}
+	sort.Slice(notifications, func(i, j int) bool {
+		if !notifications[i].CreatedAt.Equal(notifications[j].CreatedAt) {
+			return notifications[i].CreatedAt.After(notifications[j].CreatedAt)
+		}
+		return notifications[i].ID < notifications[j].ID
+	})
+	return notifications[:min(len(notifications), limit)], nil
+}
+
+// FindByID returns (nil, nil) when no notification has the given ID.
+func (r *notificationRepository) FindByID(ctx context.Context, id string) (*entity.Notification, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.notifications[id]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, id string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if row, ok := r.store.notifications[id]; ok && row.ReadAt == nil {
+		at := at.UTC()
+		row.ReadAt = &at
+	}
+	return nil
+}
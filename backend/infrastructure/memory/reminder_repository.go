@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type reminderRepository struct {
+	store *Store
+}
+
+func NewReminderRepository(store *Store) repository.ReminderRepository {
+	return &reminderRepository{store}
+}
+
+func (r *reminderRepository) Create(ctx context.Context, rem *entity.Reminder) (*entity.Reminder, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.todos[rem.TodoID]; !ok {
+		return nil, errors.New("failed to insert reminder: todo does not exist")
+	}
+	row := *rem
+	if row.ID == "" {
+		row.ID = uuid.NewString()
+	}
+	row.CreatedAt = time.Now().UTC()
+	r.store.reminders[row.ID] = &row
+
+	created := row
+	return &created, nil
+}
+
+// FindByID returns (nil, nil) when no reminder has the given ID.
+func (r *reminderRepository) FindByID(ctx context.Context, id string) (*entity.Reminder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.reminders[id]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+func (r *reminderRepository) FindByTodo(ctx context.Context, todoID string) ([]*entity.Reminder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	reminders := []*entity.Reminder{}
+	for _, row := range r.store.reminders {
+		if row.TodoID == todoID {
+			out := *row
+			reminders = append(reminders, &out)
+		}
+	}
+	sortReminders(reminders)
+	return reminders, nil
+}
+
+func (r *reminderRepository) Update(ctx context.Context, rem *entity.Reminder) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.reminders[rem.ID]
+	if !ok {
+		return nil // deleted meanwhile, like an UPDATE matching no row
+	}
+	created := row.CreatedAt
+	*row = *rem
+	row.CreatedAt = created
+	return nil
+}
+
+func (r *reminderRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.reminders, id)
+	return nil
+}
+
+func (r *reminderRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.Reminder, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var due []*entity.Reminder
+	for _, row := range r.store.reminders {
+		if row.Status == entity.ReminderPending && !row.NextAttemptAt.After(now) {
+			due = append(due, row)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+
+	claimed := make([]*entity.Reminder, 0, min(len(due), limit))
+	for _, row := range due[:min(len(due), limit)] {
+		row.NextAttemptAt = now.Add(lease)
+		out := *row
+		claimed = append(claimed, &out)
+	}
+	return claimed, nil
+}
+
+func (r *reminderRepository) RescheduleByTodos(ctx context.Context, todoIDs []string, due *time.Time, now time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, row := range r.store.reminders {
+		if !slices.Contains(todoIDs, row.TodoID) || row.OffsetMinutes == nil {
+			continue
+		}
+		if due == nil {
+			if row.Status == entity.ReminderPending {
+				row.Status = entity.ReminderCancelled
+			}
+			continue
+		}
+		fireAt := due.Add(-time.Duration(*row.OffsetMinutes) * time.Minute).UTC()
+		if row.Status == entity.ReminderPending || fireAt.After(now) {
+			_ = row.Schedule(due) // cannot fail: the offset was checked on create
+		}
+	}
+	return nil
+}
+
+// sortReminders orders reminders soonest first, as FindByTodo promises.
+func sortReminders(reminders []*entity.Reminder) {
+	sort.Slice(reminders, func(i, j int) bool {
+		if !reminders[i].FireAt.Equal(reminders[j].FireAt) {
+			return reminders[i].FireAt.Before(reminders[j].FireAt)
+		}
+		return reminders[i].ID < reminders[j].ID
+	})
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type sessionRepository struct {
+	store *Store
+}
+
+func NewSessionRepository(store *Store) repository.SessionRepository {
+	return &sessionRepository{store}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, s *entity.Session) (*entity.Session, error) {
+	if s.ID == "" {
+		return nil, errors.New("session ID is required")
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.sessions[s.ID]; exists {
+		return nil, errors.New("duplicate key value violates unique constraint \"sessions_pkey\"")
+	}
+	row := *s
+	r.store.sessions[row.ID] = &row
+
+	out := row
+	return &out, nil
+}
+
+// FindByID returns (nil, nil) when no session has the given ID.
+func (r *sessionRepository) FindByID(ctx context.Context, id string) (*entity.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+func (r *sessionRepository) FindAllByUser(ctx context.Context, userID string) ([]*entity.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var sessions []*entity.Session
+	for _, row := range r.store.sessions {
+		if row.UserID == userID {
+			out := *row
+			sessions = append(sessions, &out)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+func (r *sessionRepository) Update(ctx context.Context, s *entity.Session) (*entity.Session, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.sessions[s.ID]
+	if !ok {
+		return nil, errors.New("session not found")
+	}
+	row.RefreshTokenHash = s.RefreshTokenHash
+	row.LastUsedAt = s.LastUsedAt
+	row.ExpiresAt = s.ExpiresAt
+	row.RevokedAt = s.RevokedAt
+
+	out := *row
+	return &out, nil
+}
+
+func (r *sessionRepository) Rotate(ctx context.Context, s *entity.Session, previousHash string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.sessions[s.ID]
+	if !ok || row.RefreshTokenHash != previousHash || row.RevokedAt != nil {
+		return false, nil
+	}
+	row.RefreshTokenHash = s.RefreshTokenHash
+	row.LastUsedAt = s.LastUsedAt
+	row.ExpiresAt = s.ExpiresAt
+	return true, nil
+}
+
+func (r *sessionRepository) RevokeAllByUser(ctx context.Context, userID string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, row := range r.store.sessions {
+		if row.UserID == userID && row.RevokedAt == nil {
+			revokedAt := at
+			row.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
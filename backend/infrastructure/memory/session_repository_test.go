@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+func TestSessionRotateIsCompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSessionRepository(NewStore())
+
+	s, err := entity.NewSession("s1", "u1", "hash-1", "test", time.Hour)
+	if err != nil {
+		t.Fatalf("new session: %v", err)
+	}
+	if _, err := repo.Create(ctx, s); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// two refreshes read the session with hash-1; only the first rotates it
+	first, second := *s, *s
+	first.RefreshTokenHash = "hash-2"
+	if ok, err := repo.Rotate(ctx, &first, "hash-1"); err != nil || !ok {
+		t.Fatalf("first Rotate = %v, %v; want true", ok, err)
+	}
+	second.RefreshTokenHash = "hash-3"
+	if ok, err := repo.Rotate(ctx, &second, "hash-1"); err != nil || ok {
+		t.Fatalf("second Rotate = %v, %v; want false", ok, err)
+	}
+
+	got, _ := repo.FindByID(ctx, s.ID)
+	if got.RefreshTokenHash != "hash-2" || got.RevokedAt != nil {
+		t.Fatalf("session = %+v, want hash-2 and still active", got)
+	}
+}
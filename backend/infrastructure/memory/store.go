@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// Store is a process-local stand-in for the Supabase tables. It is shared by
+// all memory repositories so that the todo↔tag join behaves like todo_tags.
+type Store struct {
+	mu            sync.RWMutex
+	users         map[string]*entity.User
+	todos         map[string]*entity.Todo
+	categories    map[string]*entity.Category
+	tags          map[string]*entity.Tag
+	todoTags      map[string]map[string]struct{} // todo_id -> set of tag_id
+	todoAssignees map[string][]string            // todo_id -> user_ids, oldest assignment first
+	items         map[string]*entity.ChecklistItem
+	comments      map[string]*entity.Comment
+	attachments   map[string]*entity.Attachment
+	sessions      map[string]*entity.Session
+	activities    []*entity.Activity // oldest first
+	reminders     map[string]*entity.Reminder
+	notifications map[string]*entity.Notification
+	calendarFeeds map[string]*entity.CalendarFeed // keyed by user_id
+	workspaces    map[string]*entity.Workspace
+	members       map[string]map[string]*entity.WorkspaceMember // workspace_id -> user_id -> member
+	invitations   map[string]*entity.WorkspaceInvitation
+}
+
+func NewStore() *Store {
+	return &Store{
+		users:         map[string]*entity.User{},
+		todos:         map[string]*entity.Todo{},
+		categories:    map[string]*entity.Category{},
+		tags:          map[string]*entity.Tag{},
+		todoTags:      map[string]map[string]struct{}{},
+		todoAssignees: map[string][]string{},
+		items:         map[string]*entity.ChecklistItem{},
+		comments:      map[string]*entity.Comment{},
+		attachments:   map[string]*entity.Attachment{},
+		sessions:      map[string]*entity.Session{},
+		reminders:     map[string]*entity.Reminder{},
+		notifications: map[string]*entity.Notification{},
+		calendarFeeds: map[string]*entity.CalendarFeed{},
+		workspaces:    map[string]*entity.Workspace{},
+		members:       map[string]map[string]*entity.WorkspaceMember{},
+		invitations:   map[string]*entity.WorkspaceInvitation{},
+	}
+}
+
+// bumpTodo advances a todo's version, as the version triggers do for writes to
+// the todo, its tags and its checklist items. Callers must hold the store lock.
+func (s *Store) bumpTodo(id string) {
+	if row, ok := s.todos[id]; ok {
+		row.Version++
+	}
+}
+
+// deleteTodo removes a todo along with the rows that cascade from it. Callers
+// must hold the store lock.
+func (s *Store) deleteTodo(id string) {
+	delete(s.todos, id)
+	delete(s.todoTags, id)
+	delete(s.todoAssignees, id)
+	for itemID, item := range s.items {
+		if item.TodoID == id {
+			delete(s.items, itemID)
+		}
+	}
+	for commentID, comment := range s.comments {
+		if comment.TodoID == id {
+			delete(s.comments, commentID)
+		}
+	}
+	for reminderID, reminder := range s.reminders {
+		if reminder.TodoID == id {
+			delete(s.reminders, reminderID)
+		}
+	}
+	// attachments.todo_id is "on delete set null": the rows stay until their
+	// blobs have been swept
+	for _, attachment := range s.attachments {
+		if attachment.TodoID == id {
+			attachment.TodoID = ""
+		}
+	}
+}
+
+// inScope reports whether a record created by ownerID in workspaceID is part
+// of a listing of workspace scope, or of userID's personal records when scope
+// is nil.
+func inScope(ownerID string, workspaceID *string, userID string, scope *string) bool {
+	if scope != nil {
+		return workspaceID != nil && *workspaceID == *scope
+	}
+	return workspaceID == nil && ownerID == userID
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type tagRepository struct {
+	store *Store
+}
+
+func NewTagRepository(store *Store) repository.TagRepository {
+	return &tagRepository{store}
+}
+
+func (r *tagRepository) FindByName(ctx context.Context, userID string, name string) (*entity.Tag, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, row := range r.store.tags {
+		if inScope(row.UserID, row.WorkspaceID, userID, nil) && row.Name == name {
+			out := *row
+			return &out, nil
+		}
+	}
+	// not found is not a fatal error
+	return nil, nil
+}
+
+func (r *tagRepository) Create(ctx context.Context, t *entity.Tag) (*entity.Tag, error) {
+	if t == nil {
+		return nil, errors.New("tag entity is required")
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t.Version = 1
+	row := *t
+	if row.ID == "" {
+		row.ID = uuid.NewString()
+	}
+	if _, exists := r.store.tags[row.ID]; exists {
+		return nil, errors.New("duplicate key value violates unique constraint \"tags_pkey\"")
+	}
+	now := time.Now().UTC()
+	row.CreatedAt, row.UpdatedAt = now, now
+	r.store.tags[row.ID] = &row
+
+	return t, nil
+}
+
+// FindByID returns (nil, nil) when no tag has the given ID.
+func (r *tagRepository) FindByID(ctx context.Context, id string) (*entity.Tag, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.tags[id]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+func (r *tagRepository) FindAllByUser(ctx context.Context, userID string) ([]*entity.Tag, error) {
+	return r.findAll(userID, nil), nil
+}
+
+func (r *tagRepository) FindAllByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Tag, error) {
+	return r.findAll("", &workspaceID), nil
+}
+
+// findAll lists the tags in scope, oldest first; see inScope.
+func (r *tagRepository) findAll(userID string, scope *string) []*entity.Tag {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var tags []*entity.Tag
+	for _, row := range r.store.tags {
+		if inScope(row.UserID, row.WorkspaceID, userID, scope) {
+			out := *row
+			tags = append(tags, &out)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].CreatedAt.Before(tags[j].CreatedAt)
+	})
+	return tags
+}
+
+func (r *tagRepository) Update(ctx context.Context, t *entity.Tag) (*entity.Tag, error) {
+	if t.ID == "" {
+		return nil, errors.New("tag ID is required")
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.tags[t.ID]
+	if !ok {
+		return nil, errors.New("tag not found")
+	}
+	if row.Version != t.Version {
+		return nil, repository.ErrStaleVersion
+	}
+	if t.Name != "" {
+		row.Name = t.Name
+	}
+	row.UpdatedAt = time.Now().UTC()
+	row.Version++
+
+	out := *row
+	return &out, nil
+}
+
+func (r *tagRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.tags, id)
+	// todo_tags.tag_id is "on delete cascade"
+	for todoID, tags := range r.store.todoTags {
+		if _, ok := tags[id]; ok {
+			delete(tags, id)
+			r.store.bumpTodo(todoID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+func (r *todoRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Todo, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	todos := []*entity.Todo{}
+	for _, id := range ids {
+		if row, ok := r.store.todos[id]; ok && row.DeletedAt == nil {
+			todos = append(todos, r.withViewColumns(row))
+		}
+	}
+	return todos, nil
+}
+
+// CreateMany inserts nothing unless every todo's references are valid,
+// like a single multi-row INSERT.
+func (r *todoRepository) CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, t := range todos {
+		if err := r.checkReferences(t); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range todos {
+		r.insert(t)
+	}
+	return todos, nil
+}
+
+func (r *todoRepository) UpdateMany(ctx context.Context, ids []string, patch repository.TodoPatch) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if patch.SetCategory && patch.CategoryID != nil {
+		if _, ok := r.store.categories[*patch.CategoryID]; !ok {
+			return errors.New("category does not exist")
+		}
+	}
+	now := time.Now().UTC()
+	for _, id := range ids {
+		row, ok := r.store.todos[id]
+		if !ok || row.DeletedAt != nil {
+			continue
+		}
+		if patch.Status != nil {
+			row.Status = *patch.Status
+			row.CompletedAt = patch.CompletedAt
+		}
+		if patch.SetCategory {
+			row.CategoryID = patch.CategoryID
+		}
+		if patch.SetDueDate {
+			row.DueDate = patch.DueDate
+		}
+		if patch.ClearRecurrence {
+			row.Recurrence = nil
+		}
+		if rank, ok := patch.Ranks[id]; ok {
+			row.Rank = rank
+		}
+		row.UpdatedAt = now
+		row.Version++
+	}
+	return nil
+}
+
+func (r *todoRepository) AddTags(ctx context.Context, ids []string, tagIDs []string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, tagID := range tagIDs {
+		if _, ok := r.store.tags[tagID]; !ok {
+			return errors.New("failed to insert todo_tag: tag does not exist")
+		}
+	}
+	for _, id := range ids {
+		tags, ok := r.store.todoTags[id]
+		if !ok {
+			continue
+		}
+		for _, tagID := range tagIDs {
+			if _, ok := tags[tagID]; !ok {
+				tags[tagID] = struct{}{}
+				r.store.bumpTodo(id)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *todoRepository) RemoveTags(ctx context.Context, ids []string, tagIDs []string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, id := range ids {
+		for _, tagID := range tagIDs {
+			if _, ok := r.store.todoTags[id][tagID]; ok {
+				delete(r.store.todoTags[id], tagID)
+				r.store.bumpTodo(id)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *todoRepository) DeleteMany(ctx context.Context, ids []string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now().UTC()
+	for _, id := range ids {
+		r.trash(id, now)
+	}
+	return nil
+}
@@ -0,0 +1,380 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type todoRepository struct {
+	store *Store
+}
+
+func NewTodoRepository(store *Store) repository.TodoRepository {
+	return &todoRepository{store}
+}
+
+func (r *todoRepository) Create(ctx context.Context, t *entity.Todo) (*entity.Todo, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkReferences(t); err != nil {
+		return nil, err
+	}
+	r.insert(t)
+	return t, nil
+}
+
+// checkReferences mirrors the category and todo_tags foreign keys; it runs
+// before anything is written so a bad reference inserts nothing, like the
+// create_todos function. Callers must hold the store lock.
+func (r *todoRepository) checkReferences(t *entity.Todo) error {
+	if t.CategoryID != nil {
+		if _, ok := r.store.categories[*t.CategoryID]; !ok {
+			return errors.New("failed to insert todo: category does not exist")
+		}
+	}
+	for _, tagID := range t.TagIDs {
+		if _, ok := r.store.tags[tagID]; !ok {
+			return errors.New("failed to insert todo_tag: tag does not exist")
+		}
+	}
+	for _, a := range t.Assignees {
+		if _, ok := r.store.users[a.UserID]; !ok {
+			return errors.New("failed to insert todo_assignee: user does not exist")
+		}
+	}
+	return nil
+}
+
+// insert stores t under a fresh ID, which is written back to t. Callers must hold the store lock.
+func (r *todoRepository) insert(t *entity.Todo) {
+	t.ID = uuid.NewString()
+	t.Version = 1
+	row := *t
+	row.TagIDs = nil
+	row.Assignees = nil
+	now := time.Now().UTC()
+	row.CreatedAt, row.UpdatedAt = now, now
+	r.store.todos[t.ID] = &row
+
+	tags := make(map[string]struct{}, len(t.TagIDs))
+	for _, tagID := range t.TagIDs {
+		tags[tagID] = struct{}{}
+	}
+	r.store.todoTags[t.ID] = tags
+
+	var assignees []string
+	for _, userID := range t.AssigneeIDs() {
+		if !slices.Contains(assignees, userID) {
+			assignees = append(assignees, userID)
+		}
+	}
+	r.store.todoAssignees[t.ID] = assignees
+}
+
+// FindByID returns (nil, nil) when no todo has the given ID.
+func (r *todoRepository) FindByID(ctx context.Context, id string) (*entity.Todo, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.todos[id]
+	if !ok || row.DeletedAt != nil {
+		return nil, nil
+	}
+	return r.withViewColumns(row), nil
+}
+
+func (r *todoRepository) FindAllByUser(ctx context.Context, userID string) ([]*entity.Todo, error) {
+	return r.findAll(userID, nil), nil
+}
+
+func (r *todoRepository) FindAllByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Todo, error) {
+	return r.findAll("", &workspaceID), nil
+}
+
+// findAll lists the todos in scope, oldest first; see inScope.
+func (r *todoRepository) findAll(userID string, scope *string) []*entity.Todo {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var todos []*entity.Todo
+	for _, row := range r.store.todos {
+		if inScope(row.UserID, row.WorkspaceID, userID, scope) && row.DeletedAt == nil {
+			todos = append(todos, r.withViewColumns(row))
+		}
+	}
+	sort.Slice(todos, func(i, j int) bool {
+		return todos[i].CreatedAt.Before(todos[j].CreatedAt)
+	})
+	return todos
+}
+
+func (r *todoRepository) FindByQuery(ctx context.Context, q repository.TodoQuery) (*repository.TodoPage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var matched []*entity.Todo
+	for _, row := range r.store.todos {
+		if !r.inQueryScope(row, q) || row.DeletedAt != nil {
+			continue
+		}
+		t := r.withViewColumns(row)
+		if q.Status != nil && t.Status != *q.Status {
+			continue
+		}
+		if q.CategoryID != nil && (t.CategoryID == nil || *t.CategoryID != *q.CategoryID) {
+			continue
+		}
+		if q.TagID != nil && !slices.Contains(t.TagIDs, *q.TagID) {
+			continue
+		}
+		if q.AssigneeID != nil && !t.IsAssigned(*q.AssigneeID) {
+			continue
+		}
+		if q.DueDateFrom != nil && (t.DueDate == nil || t.DueDate.Before(*q.DueDateFrom)) {
+			continue
+		}
+		if q.DueDateTo != nil && (t.DueDate == nil || t.DueDate.After(*q.DueDateTo)) {
+			continue
+		}
+		matched = append(matched, t)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if c := compareTodos(matched[i], matched[j], q.SortBy); c != 0 {
+			if nullsLast(matched[i], matched[j], q.SortBy) {
+				return c < 0
+			}
+			if q.SortDesc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	page := &repository.TodoPage{Todos: []*entity.Todo{}, Total: len(matched)}
+	if q.Offset < len(matched) {
+		end := min(q.Offset+q.Limit, len(matched))
+		page.Todos = matched[q.Offset:end]
+	}
+	if next := q.Offset + len(page.Todos); len(page.Todos) > 0 && next < page.Total {
+		page.NextOffset = &next
+	}
+	return page, nil
+}
+
+// inQueryScope is inScope widened by q.MemberOf.
+func (r *todoRepository) inQueryScope(row *entity.Todo, q repository.TodoQuery) bool {
+	return inMemberScope(row, q.UserID, q.WorkspaceID, q.MemberOf)
+}
+
+// inMemberScope is inScope widened by the workspaces in memberOf, which only
+// apply without a workspace scope.
+func inMemberScope(row *entity.Todo, userID string, scope *string, memberOf []string) bool {
+	if scope == nil && row.WorkspaceID != nil && slices.Contains(memberOf, *row.WorkspaceID) {
+		return true
+	}
+	return inScope(row.UserID, row.WorkspaceID, userID, scope)
+}
+
+func (r *todoRepository) Update(ctx context.Context, t *entity.Todo) (*entity.Todo, error) {
+	if t.ID == "" {
+		return nil, errors.New("todo ID is required")
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.todos[t.ID]
+	if !ok || row.DeletedAt != nil {
+		return nil, repository.ErrTodoNotFound
+	}
+	if row.Version != t.Version {
+		return nil, repository.ErrStaleVersion
+	}
+
+	// every reference is checked before the first write, so a failed update
+	// leaves the todo and its tags as they were, like the update_todo function
+	if t.CategoryID != nil {
+		if _, ok := r.store.categories[*t.CategoryID]; !ok {
+			return nil, errors.New("category does not exist")
+		}
+	}
+	var tags map[string]struct{}
+	if t.TagIDs != nil {
+		tags = make(map[string]struct{}, len(t.TagIDs))
+		for _, tid := range t.TagIDs {
+			s := strings.TrimSpace(tid)
+			if s == "" {
+				continue
+			}
+			if _, ok := r.store.tags[s]; !ok {
+				return nil, errors.New("tag does not exist")
+			}
+			tags[s] = struct{}{}
+		}
+	}
+
+	// same patch semantics as the Supabase repository: empty fields are left alone
+	if t.Title != "" {
+		row.Title = t.Title
+	}
+	if t.Body != nil {
+		row.Body = t.Body
+	}
+	if t.Status != "" {
+		row.Status = t.Status
+	}
+	if t.Priority != "" {
+		row.Priority = t.Priority
+	}
+	if t.Rank != "" {
+		row.Rank = t.Rank
+	}
+	if t.DueDate != nil {
+		row.DueDate = t.DueDate
+	}
+	if t.CompletedAt != nil {
+		row.CompletedAt = t.CompletedAt
+	}
+	if t.CategoryID != nil {
+		row.CategoryID = t.CategoryID
+	}
+	// always written: a nil rule means the todo stopped recurring
+	row.Recurrence = t.Recurrence
+	row.AutoComplete = t.AutoComplete
+	row.UpdatedAt = time.Now().UTC()
+	row.Version++
+	if tags != nil {
+		r.store.todoTags[t.ID] = tags
+	}
+
+	return r.withViewColumns(row), nil
+}
+
+func (r *todoRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.trash(id, time.Now().UTC())
+	return nil
+}
+
+func (r *todoRepository) AddAssignee(ctx context.Context, todoID, userID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.todos[todoID]; !ok {
+		return errors.New("failed to insert todo_assignee: todo does not exist")
+	}
+	if _, ok := r.store.users[userID]; !ok {
+		return errors.New("failed to insert todo_assignee: user does not exist")
+	}
+	if slices.Contains(r.store.todoAssignees[todoID], userID) {
+		return nil
+	}
+	r.store.todoAssignees[todoID] = append(r.store.todoAssignees[todoID], userID)
+	r.store.bumpTodo(todoID)
+	return nil
+}
+
+func (r *todoRepository) RemoveAssignee(ctx context.Context, todoID, userID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	assignees := r.store.todoAssignees[todoID]
+	if i := slices.Index(assignees, userID); i >= 0 {
+		r.store.todoAssignees[todoID] = slices.Delete(assignees, i, i+1)
+		r.store.bumpTodo(todoID)
+	}
+	return nil
+}
+
+// trash marks a todo as deleted at now, unless it already is. Callers must hold the store lock.
+func (r *todoRepository) trash(id string, now time.Time) {
+	row, ok := r.store.todos[id]
+	if !ok || row.DeletedAt != nil {
+		return
+	}
+	row.DeletedAt = &now
+	row.UpdatedAt = now
+	row.Version++
+}
+
+// withViewColumns returns a copy of row with TagIDs, assignees and checklist
+// progress filled in, mirroring the todos_with_tag_ids view. Callers must hold
+// the store lock.
+func (r *todoRepository) withViewColumns(row *entity.Todo) *entity.Todo {
+	t := *row
+	t.TagIDs = []string{}
+	for tagID := range r.store.todoTags[row.ID] {
+		t.TagIDs = append(t.TagIDs, tagID)
+	}
+	sort.Strings(t.TagIDs)
+
+	t.Assignees = []entity.Assignee{}
+	for _, userID := range r.store.todoAssignees[row.ID] {
+		a := entity.Assignee{UserID: userID}
+		if u, ok := r.store.users[userID]; ok {
+			a.Name, a.AvatarURL = u.Name, u.AvatarURL
+		}
+		t.Assignees = append(t.Assignees, a)
+	}
+
+	t.ItemsDone, t.ItemsTotal = 0, 0
+	for _, item := range r.store.items {
+		if item.TodoID != row.ID {
+			continue
+		}
+		t.ItemsTotal++
+		if item.Done {
+			t.ItemsDone++
+		}
+	}
+	return &t
+}
+
+// compareTodos orders a and b by field; a missing due date sorts after any date.
+func compareTodos(a, b *entity.Todo, field repository.TodoSortField) int {
+	switch field {
+	case repository.TodoSortUpdatedAt:
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case repository.TodoSortDueDate:
+		if a.DueDate == nil && b.DueDate == nil {
+			return 0
+		}
+		if a.DueDate == nil {
+			return 1
+		}
+		if b.DueDate == nil {
+			return -1
+		}
+		return a.DueDate.Compare(*b.DueDate)
+	case repository.TodoSortTitle:
+		return strings.Compare(a.Title, b.Title)
+	case repository.TodoSortStatus:
+		return strings.Compare(string(a.Status), string(b.Status))
+	case repository.TodoSortPriority:
+		return slices.Index(entity.Priorities, a.Priority) - slices.Index(entity.Priorities, b.Priority)
+	case repository.TodoSortRank:
+		return strings.Compare(a.Rank, b.Rank)
+	default:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	}
+}
+
+// nullsLast reports whether exactly one of a, b has no due date while sorting by it,
+// in which case the comparison must not be flipped for descending order.
+func nullsLast(a, b *entity.Todo, field repository.TodoSortField) bool {
+	return field == repository.TodoSortDueDate && (a.DueDate == nil) != (b.DueDate == nil)
+}
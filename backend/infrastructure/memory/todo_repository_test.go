@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// newTaggedStore returns a todo repository whose store holds one tag.
+func newTaggedStore(t *testing.T) (*todoRepository, *entity.Tag) {
+	t.Helper()
+	store := NewStore()
+	tag, err := NewTagRepository(store).Create(context.Background(), &entity.Tag{ID: "tag-home", Name: "home", UserID: "u1"})
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+	return &todoRepository{store}, tag
+}
+
+func TestTodoCreateIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	repo, tag := newTaggedStore(t)
+
+	// the second tag is unknown, so neither the todo nor the first link may be kept
+	_, err := repo.Create(ctx, &entity.Todo{
+		Title:  "Half tagged",
+		Status: entity.StatusTodo,
+		UserID: "u1",
+		TagIDs: []string{tag.ID, "missing"},
+	})
+	if err == nil {
+		t.Fatal("Create with an unknown tag succeeded")
+	}
+	if n := len(repo.store.todos); n != 0 {
+		t.Fatalf("store has %d todos after a failed create", n)
+	}
+	if n := len(repo.store.todoTags); n != 0 {
+		t.Fatalf("store has %d tag sets after a failed create", n)
+	}
+
+	// one bad todo rolls back the whole batch
+	_, err = repo.CreateMany(ctx, []*entity.Todo{
+		{Title: "Good", Status: entity.StatusTodo, UserID: "u1", TagIDs: []string{tag.ID}},
+		{Title: "Bad", Status: entity.StatusTodo, UserID: "u1", TagIDs: []string{"missing"}},
+	})
+	if err == nil || len(repo.store.todos) != 0 {
+		t.Fatalf("CreateMany err = %v, todos = %d", err, len(repo.store.todos))
+	}
+}
+
+func TestTodoUpdateIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	repo, tag := newTaggedStore(t)
+
+	created, err := repo.Create(ctx, &entity.Todo{
+		Title:  "Original",
+		Status: entity.StatusTodo,
+		UserID: "u1",
+		TagIDs: []string{tag.ID},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	missing := "missing"
+	for name, patch := range map[string]*entity.Todo{
+		"unknown tag":      {ID: created.ID, Version: created.Version, Title: "Changed", Status: entity.StatusCompleted, TagIDs: []string{"missing"}},
+		"unknown category": {ID: created.ID, Version: created.Version, Title: "Changed", CategoryID: &missing, TagIDs: []string{}},
+	} {
+		if _, err := repo.Update(ctx, patch); err == nil {
+			t.Fatalf("%s: Update succeeded", name)
+		}
+		got, _ := repo.FindByID(ctx, created.ID)
+		if got.Title != "Original" || got.Status != entity.StatusTodo || len(got.TagIDs) != 1 || got.TagIDs[0] != tag.ID {
+			t.Fatalf("%s: todo changed by a failed update: %+v", name, got)
+		}
+	}
+}
+
+func TestTodoUpdateRejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	repo, tag := newTaggedStore(t)
+
+	created, err := repo.Create(ctx, &entity.Todo{Title: "Original", Status: entity.StatusTodo, UserID: "u1"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	first, _ := repo.FindByID(ctx, created.ID)
+	second, _ := repo.FindByID(ctx, created.ID)
+
+	first.Title = "First"
+	if _, err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	second.Title = "Second"
+	if _, err := repo.Update(ctx, second); !errors.Is(err, repository.ErrStaleVersion) {
+		t.Fatalf("second update err = %v, want ErrStaleVersion", err)
+	}
+
+	// tag links count as changes to the todo
+	current, _ := repo.FindByID(ctx, created.ID)
+	if err := repo.AddTags(ctx, []string{created.ID}, []string{tag.ID}); err != nil {
+		t.Fatalf("add tags: %v", err)
+	}
+	current.Title = "Late"
+	if _, err := repo.Update(ctx, current); !errors.Is(err, repository.ErrStaleVersion) {
+		t.Fatalf("update after AddTags err = %v, want ErrStaleVersion", err)
+	}
+
+	got, _ := repo.FindByID(ctx, created.ID)
+	if got.Title != "First" {
+		t.Fatalf("title = %q, want First", got.Title)
+	}
+}
+
+func TestTodoUpdateOfTrashedTodoIsNotFound(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTaggedStore(t)
+
+	created, err := repo.Create(ctx, &entity.Todo{Title: "Original", Status: entity.StatusTodo, UserID: "u1"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	read, _ := repo.FindByID(ctx, created.ID)
+	if err := repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	read.Title = "Late"
+	if updated, err := repo.Update(ctx, read); updated != nil || !errors.Is(err, repository.ErrTodoNotFound) {
+		t.Fatalf("Update = %+v, %v; want ErrTodoNotFound", updated, err)
+	}
+}
+
+func TestTodoTrashAndPurge(t *testing.T) {
+	ctx := context.Background()
+	repo, tag := newTaggedStore(t)
+	items := NewChecklistItemRepository(repo.store)
+
+	var ids []string
+	for _, title := range []string{"Old", "Recent", "Kept"} {
+		created, err := repo.Create(ctx, &entity.Todo{Title: title, Status: entity.StatusTodo, UserID: "u1", TagIDs: []string{tag.ID}})
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		ids = append(ids, created.ID)
+	}
+	item, err := items.Create(ctx, &entity.ChecklistItem{TodoID: ids[0], Title: "step"})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	if err := repo.DeleteMany(ctx, ids[:2]); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if got, _ := repo.FindByID(ctx, ids[0]); got != nil {
+		t.Fatalf("FindByID returned a trashed todo: %+v", got)
+	}
+	if all, _ := repo.FindAllByUser(ctx, "u1"); len(all) != 1 || all[0].ID != ids[2] {
+		t.Fatalf("FindAllByUser = %+v, want only Kept", all)
+	}
+	trash, _ := repo.FindTrashByUser(ctx, "u1")
+	if len(trash) != 2 || trash[0].DeletedAt == nil {
+		t.Fatalf("trash = %+v", trash)
+	}
+
+	// only the todo trashed before the cutoff goes, with its tags and checklist
+	old := time.Now().Add(-48 * time.Hour)
+	repo.store.todos[ids[0]].DeletedAt = &old
+	n, err := repo.PurgeTrashed(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("PurgeTrashed = %d, %v; want 1", n, err)
+	}
+	if _, ok := repo.store.todos[ids[0]]; ok {
+		t.Fatal("purged todo is still stored")
+	}
+	if _, ok := repo.store.todoTags[ids[0]]; ok {
+		t.Fatal("purged todo kept its tag links")
+	}
+	if _, ok := repo.store.items[item.ID]; ok {
+		t.Fatal("purged todo kept its checklist")
+	}
+
+	restored, err := repo.Restore(ctx, ids[1])
+	if err != nil || restored.DeletedAt != nil || len(restored.TagIDs) != 1 {
+		t.Fatalf("Restore = %+v, %v", restored, err)
+	}
+	if _, err := repo.Restore(ctx, ids[1]); err == nil {
+		t.Fatal("restoring a todo outside the trash succeeded")
+	}
+}
@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
+)
+
+// weights mirror setweight 'A' (title) and 'B' (body) in the search_todos function
+const (
+	titleWeight = 1.0
+	bodyWeight  = 0.4
+
+	// body snippets show this many words, starting a few words before the first match
+	snippetWords  = 20
+	snippetLeadIn = 5
+)
+
+func (r *todoRepository) Search(ctx context.Context, q repository.TodoSearch) (*repository.TodoSearchPage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var hits []*repository.TodoSearchHit
+	for _, row := range r.store.todos {
+		if !inMemberScope(row, q.UserID, q.WorkspaceID, q.MemberOf) || row.DeletedAt != nil {
+			continue
+		}
+		if q.Status != nil && row.Status != *q.Status {
+			continue
+		}
+		if q.CategoryID != nil && (row.CategoryID == nil || *row.CategoryID != *q.CategoryID) {
+			continue
+		}
+		t := r.withViewColumns(row)
+		if q.TagID != nil && !slices.Contains(t.TagIDs, *q.TagID) {
+			continue
+		}
+		if hit := searchTodo(t, q.Query); hit != nil {
+			hits = append(hits, hit)
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		if !a.Todo.UpdatedAt.Equal(b.Todo.UpdatedAt) {
+			return a.Todo.UpdatedAt.After(b.Todo.UpdatedAt)
+		}
+		return a.Todo.ID < b.Todo.ID
+	})
+
+	page := &repository.TodoSearchPage{Hits: []*repository.TodoSearchHit{}, Total: len(hits)}
+	if q.Offset < len(hits) {
+		end := min(q.Offset+q.Limit, len(hits))
+		page.Hits = hits[q.Offset:end]
+	}
+	if next := q.Offset + len(page.Hits); len(page.Hits) > 0 && next < page.Total {
+		page.NextOffset = &next
+	}
+	return page, nil
+}
+
+// searchTodo returns nil unless every term matches the title or the body.
+func searchTodo(t *entity.Todo, q valueobject.SearchQueryVO) *repository.TodoSearchHit {
+	title := tokenize(t.Title)
+	var body []token
+	if t.Body != nil {
+		body = tokenize(*t.Body)
+	}
+	titleMarks := make([]bool, len(title))
+	bodyMarks := make([]bool, len(body))
+
+	var rank float64
+	for _, term := range q.Terms {
+		inTitle := markMatches(title, titleMarks, term)
+		inBody := markMatches(body, bodyMarks, term)
+		if inTitle == 0 && inBody == 0 {
+			return nil
+		}
+		rank += titleWeight*float64(inTitle) + bodyWeight*float64(inBody)
+	}
+
+	hit := &repository.TodoSearchHit{
+		Todo:         t,
+		Rank:         rank,
+		TitleSnippet: snippet(t.Title, title, titleMarks, 0, len(title)),
+	}
+	if t.Body != nil {
+		start := slices.Index(bodyMarks, true)
+		start = max(start-snippetLeadIn, 0)
+		end := min(start+snippetWords, len(body))
+		hit.BodySnippet = snippet(*t.Body, body, bodyMarks, start, end)
+	}
+	return hit
+}
+
+type token struct {
+	word       string // lowercased
+	start, end int    // byte offsets into the source text
+}
+
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		if valueobject.IsSearchWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, token{strings.ToLower(text[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{strings.ToLower(text[start:]), start, len(text)})
+	}
+	return tokens
+}
+
+// markMatches flags the tokens of every occurrence of term and returns how many there were.
+func markMatches(tokens []token, marks []bool, term valueobject.SearchTerm) int {
+	n := len(term.Words)
+	count := 0
+	for i := 0; i+n <= len(tokens); i++ {
+		if !matchesAt(tokens[i:i+n], term) {
+			continue
+		}
+		for k := i; k < i+n; k++ {
+			marks[k] = true
+		}
+		count++
+	}
+	return count
+}
+
+func matchesAt(tokens []token, term valueobject.SearchTerm) bool {
+	last := len(term.Words) - 1
+	for k, w := range term.Words {
+		if k == last && term.Prefix {
+			if !strings.HasPrefix(tokens[k].word, w) {
+				return false
+			}
+		} else if tokens[k].word != w {
+			return false
+		}
+	}
+	return true
+}
+
+// snippet cuts text from tokens[from] to tokens[to-1] and splits it into
+// matched and unmatched segments. A whole-text snippet keeps leading and
+// trailing punctuation.
+func snippet(text string, tokens []token, marks []bool, from, to int) []repository.TextSegment {
+	if len(tokens) == 0 {
+		if text == "" {
+			return []repository.TextSegment{}
+		}
+		return []repository.TextSegment{{Text: text}}
+	}
+	begin, finish := tokens[from].start, tokens[to-1].end
+	if from == 0 && to == len(tokens) {
+		begin, finish = 0, len(text)
+	}
+
+	var segs []repository.TextSegment
+	add := func(s string, match bool) {
+		if s == "" {
+			return
+		}
+		if n := len(segs); n > 0 && segs[n-1].Match == match {
+			segs[n-1].Text += s
+			return
+		}
+		segs = append(segs, repository.TextSegment{Text: s, Match: match})
+	}
+	pos := begin
+	for i := from; i < to; i++ {
+		if !marks[i] {
+			continue
+		}
+		add(text[pos:tokens[i].start], false)
+		add(text[tokens[i].start:tokens[i].end], true)
+		pos = tokens[i].end
+	}
+	add(text[pos:finish], false)
+	return segs
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+func (r *todoRepository) FindTrashByUser(ctx context.Context, userID string) ([]*entity.Todo, error) {
+	return r.findTrash(userID, nil), nil
+}
+
+func (r *todoRepository) FindTrashByWorkspace(ctx context.Context, workspaceID string) ([]*entity.Todo, error) {
+	return r.findTrash("", &workspaceID), nil
+}
+
+// findTrash lists the trashed todos in scope, most recently trashed first;
+// see inScope.
+func (r *todoRepository) findTrash(userID string, scope *string) []*entity.Todo {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	todos := []*entity.Todo{}
+	for _, row := range r.store.todos {
+		if inScope(row.UserID, row.WorkspaceID, userID, scope) && row.DeletedAt != nil {
+			todos = append(todos, r.withViewColumns(row))
+		}
+	}
+	sort.Slice(todos, func(i, j int) bool {
+		if c := todos[i].DeletedAt.Compare(*todos[j].DeletedAt); c != 0 {
+			return c > 0
+		}
+		return todos[i].ID < todos[j].ID
+	})
+	return todos
+}
+
+func (r *todoRepository) FindTrashedByID(ctx context.Context, id string) (*entity.Todo, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.todos[id]
+	if !ok || row.DeletedAt == nil {
+		return nil, nil
+	}
+	return r.withViewColumns(row), nil
+}
+
+func (r *todoRepository) Restore(ctx context.Context, id string) (*entity.Todo, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.todos[id]
+	if !ok || row.DeletedAt == nil {
+		return nil, errors.New("todo is not in the trash")
+	}
+	row.DeletedAt = nil
+	row.UpdatedAt = time.Now().UTC()
+	row.Version++
+	return r.withViewColumns(row), nil
+}
+
+func (r *todoRepository) PurgeTrashed(ctx context.Context, cutoff time.Time) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	n := 0
+	for id, row := range r.store.todos {
+		if row.DeletedAt != nil && row.DeletedAt.Before(cutoff) {
+			r.store.deleteTodo(id)
+			n++
+		}
+	}
+	return n, nil
+}
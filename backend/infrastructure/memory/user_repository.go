@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type userRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &userRepository{store}
+}
+
+func (r *userRepository) Create(ctx context.Context, u *entity.User) (*entity.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.users {
+		if existing.Email == u.Email {
+			return nil, apperror.Conflict("email is already taken")
+		}
+	}
+
+	u.ID = uuid.NewString()
+	row := *u
+	now := time.Now().UTC()
+	row.CreatedAt, row.UpdatedAt = now, now
+	r.store.users[u.ID] = &row
+
+	out := row
+	return &out, nil
+}
+
+// FindByEmail returns (nil, nil) when no user has the given email.
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, row := range r.store.users {
+		if row.Email == email {
+			out := *row
+			return &out, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindByID fetches a user by its ID. Returns (nil, nil) if not found.
+func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.users[id]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+func (r *userRepository) SetAvatar(ctx context.Context, id string, avatarURL, avatarKey *string) (*entity.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	row.AvatarURL, row.AvatarKey = avatarURL, avatarKey
+	row.UpdatedAt = time.Now().UTC()
+
+	out := *row
+	return &out, nil
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type workspaceInvitationRepository struct {
+	store *Store
+}
+
+func NewWorkspaceInvitationRepository(store *Store) repository.WorkspaceInvitationRepository {
+	return &workspaceInvitationRepository{store}
+}
+
+func (r *workspaceInvitationRepository) Create(ctx context.Context, inv *entity.WorkspaceInvitation) (*entity.WorkspaceInvitation, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// mirrors the unique (workspace_id, email) constraint
+	for _, row := range r.store.invitations {
+		if row.WorkspaceID == inv.WorkspaceID && row.Email == inv.Email {
+			return nil, apperror.Conflict("that email has already been invited")
+		}
+	}
+	row := *inv
+	r.store.invitations[row.ID] = &row
+
+	out := row
+	return &out, nil
+}
+
+// FindByID returns (nil, nil) when no invitation has the given ID.
+func (r *workspaceInvitationRepository) FindByID(ctx context.Context, id string) (*entity.WorkspaceInvitation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.invitations[id]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+func (r *workspaceInvitationRepository) FindByEmail(ctx context.Context, email string) ([]*entity.WorkspaceInvitation, error) {
+	return r.find(func(inv *entity.WorkspaceInvitation) bool { return inv.Email == email }), nil
+}
+
+func (r *workspaceInvitationRepository) FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.WorkspaceInvitation, error) {
+	return r.find(func(inv *entity.WorkspaceInvitation) bool { return inv.WorkspaceID == workspaceID }), nil
+}
+
+// find returns the invitations that match, newest first.
+func (r *workspaceInvitationRepository) find(match func(*entity.WorkspaceInvitation) bool) []*entity.WorkspaceInvitation {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	invitations := []*entity.WorkspaceInvitation{}
+	for _, row := range r.store.invitations {
+		if match(row) {
+			out := *row
+			invitations = append(invitations, &out)
+		}
+	}
+	sort.Slice(invitations, func(i, j int) bool {
+		if c := invitations[i].CreatedAt.Compare(invitations[j].CreatedAt); c != 0 {
+			return c > 0
+		}
+		return invitations[i].ID < invitations[j].ID
+	})
+	return invitations
+}
+
+func (r *workspaceInvitationRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.invitations, id)
+	return nil
+}
@@ -0,0 +1,189 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+type workspaceRepository struct {
+	store *Store
+}
+
+func NewWorkspaceRepository(store *Store) repository.WorkspaceRepository {
+	return &workspaceRepository{store}
+}
+
+func (r *workspaceRepository) Create(ctx context.Context, w *entity.Workspace, owner *entity.WorkspaceMember) (*entity.Workspace, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.workspaces[w.ID]; exists {
+		return nil, errors.New("duplicate key value violates unique constraint \"workspaces_pkey\"")
+	}
+	row := *w
+	now := time.Now().UTC()
+	row.CreatedAt, row.UpdatedAt = now, now
+	r.store.workspaces[row.ID] = &row
+
+	m := *owner
+	m.CreatedAt = now
+	r.store.members[row.ID] = map[string]*entity.WorkspaceMember{m.UserID: &m}
+
+	out := row
+	return &out, nil
+}
+
+// FindByID returns (nil, nil) when no workspace has the given ID.
+func (r *workspaceRepository) FindByID(ctx context.Context, id string) (*entity.Workspace, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.workspaces[id]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+func (r *workspaceRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Workspace, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var workspaces []*entity.Workspace
+	for _, id := range ids {
+		if row, ok := r.store.workspaces[id]; ok {
+			out := *row
+			workspaces = append(workspaces, &out)
+		}
+	}
+	return workspaces, nil
+}
+
+func (r *workspaceRepository) Update(ctx context.Context, w *entity.Workspace) (*entity.Workspace, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.workspaces[w.ID]
+	if !ok {
+		return nil, errors.New("workspace not found")
+	}
+	if w.Name != "" {
+		row.Name = w.Name
+	}
+	row.UpdatedAt = time.Now().UTC()
+
+	out := *row
+	return &out, nil
+}
+
+func (r *workspaceRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.workspaces, id)
+	// every workspace_id foreign key is "on delete cascade"
+	delete(r.store.members, id)
+	for invID, inv := range r.store.invitations {
+		if inv.WorkspaceID == id {
+			delete(r.store.invitations, invID)
+		}
+	}
+	for todoID, t := range r.store.todos {
+		if t.WorkspaceID != nil && *t.WorkspaceID == id {
+			r.store.deleteTodo(todoID)
+		}
+	}
+	for catID, c := range r.store.categories {
+		if c.WorkspaceID != nil && *c.WorkspaceID == id {
+			delete(r.store.categories, catID)
+		}
+	}
+	for tagID, t := range r.store.tags {
+		if t.WorkspaceID != nil && *t.WorkspaceID == id {
+			delete(r.store.tags, tagID)
+		}
+	}
+	return nil
+}
+
+// FindMember returns (nil, nil) when the user is not a member.
+func (r *workspaceRepository) FindMember(ctx context.Context, workspaceID, userID string) (*entity.WorkspaceMember, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.members[workspaceID][userID]
+	if !ok {
+		return nil, nil
+	}
+	out := *row
+	return &out, nil
+}
+
+func (r *workspaceRepository) FindMembers(ctx context.Context, workspaceID string) ([]*entity.WorkspaceMember, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	members := []*entity.WorkspaceMember{}
+	for _, row := range r.store.members[workspaceID] {
+		out := *row
+		members = append(members, &out)
+	}
+	sortMembers(members)
+	return members, nil
+}
+
+func (r *workspaceRepository) FindMemberships(ctx context.Context, userID string) ([]*entity.WorkspaceMember, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	members := []*entity.WorkspaceMember{}
+	for _, byUser := range r.store.members {
+		if row, ok := byUser[userID]; ok {
+			out := *row
+			members = append(members, &out)
+		}
+	}
+	sortMembers(members)
+	return members, nil
+}
+
+func (r *workspaceRepository) SaveMember(ctx context.Context, m *entity.WorkspaceMember) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	byUser, ok := r.store.members[m.WorkspaceID]
+	if !ok {
+		return errors.New("insert or update on table \"workspace_members\" violates foreign key constraint")
+	}
+	if row, ok := byUser[m.UserID]; ok {
+		row.Role = m.Role
+		return nil
+	}
+	row := *m
+	byUser[m.UserID] = &row
+	return nil
+}
+
+func (r *workspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.members[workspaceID], userID)
+	return nil
+}
+
+// sortMembers orders members by when they joined, oldest first.
+func sortMembers(members []*entity.WorkspaceMember) {
+	sort.Slice(members, func(i, j int) bool {
+		if c := members[i].CreatedAt.Compare(members[j].CreatedAt); c != 0 {
+			return c < 0
+		}
+		return members[i].WorkspaceID+members[i].UserID < members[j].WorkspaceID+members[j].UserID
+	})
+}
@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/domain/notify"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+)
+
+// InApp delivers reminders to the user's notification inbox and announces
+// them on the change feed, so open tabs can show them straight away.
+type InApp struct {
+	repo   repository.NotificationRepository
+	events event.Publisher
+}
+
+var _ notify.Notifier = (*InApp)(nil)
+
+func NewInApp(repo repository.NotificationRepository, events event.Publisher) *InApp {
+	return &InApp{repo, events}
+}
+
+func (n *InApp) Notify(ctx context.Context, m notify.Message) error {
+	notification := &entity.Notification{
+		// derived from the key, so a retried delivery hits the same row
+		ID:        uuid.NewSHA1(uuid.NameSpaceURL, []byte("reminder:"+m.Key)).String(),
+		UserID:    m.UserID,
+		TodoID:    m.TodoID,
+		Title:     m.Subject,
+		Body:      m.Text,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := n.repo.Create(ctx, notification); err != nil {
+		return err
+	}
+	n.events.Publish(event.New(m.UserID, event.ResourceNotification, event.ActionCreated, notification.ID, notification))
+	return nil
+}
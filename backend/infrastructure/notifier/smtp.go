@@ -0,0 +1,118 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/notify"
+)
+
+// SMTPConfig is the mail server reminders are sent through. Username may be
+// empty for servers that accept unauthenticated mail from this host.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// smtpTimeout bounds a whole send, from dialing to QUIT.
+const smtpTimeout = 30 * time.Second
+
+// SMTP emails reminders to the user's address. The message key becomes the
+// Message-ID, so mail systems that drop duplicate IDs hide retried sends.
+type SMTP struct {
+	cfg SMTPConfig
+}
+
+var _ notify.Notifier = (*SMTP)(nil)
+
+func NewSMTP(cfg SMTPConfig) *SMTP {
+	return &SMTP{cfg}
+}
+
+func (s *SMTP) Notify(ctx context.Context, m notify.Message) error {
+	if m.Email == "" {
+		return fmt.Errorf("user %s has no email address", m.UserID)
+	}
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	dialer := net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.cfg.Host, s.cfg.Port))
+	if err != nil {
+		return err
+	}
+	// the deadline bounds a stalled server; closing on cancel stops a send under way
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	return s.send(c, m)
+}
+
+// send is smtp.SendMail over an open client.
+func (s *SMTP) send(c *smtp.Client, m notify.Message) error {
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.cfg.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(m.Email); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.message(m)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// message renders m as a plain-text RFC 5322 message.
+func (s *SMTP) message(m notify.Message) []byte {
+	var b strings.Builder
+	header := func(k, v string) { fmt.Fprintf(&b, "%s: %s\r\n", k, v) }
+	header("From", s.cfg.From)
+	header("To", m.Email)
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<reminder.%s@%s>", m.Key, s.cfg.Host))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/plain; charset="utf-8"`)
+	header("Content-Transfer-Encoding", "8bit")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Text, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/notify"
+)
+
+// Webhook POSTs reminders as JSON to a fixed URL. Each request carries the
+// message key as Idempotency-Key and, when a secret is set, an
+// X-Signature-256 header: "sha256=" and the hex HMAC-SHA256 of the body.
+type Webhook struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+var _ notify.Notifier = (*Webhook)(nil)
+
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{url: url, secret: secret, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// webhookPayload is the JSON body of a webhook delivery.
+type webhookPayload struct {
+	ID      string     `json:"id"`
+	Type    string     `json:"type"`
+	UserID  string     `json:"userId"`
+	TodoID  string     `json:"todoId"`
+	Title   string     `json:"title"`
+	DueDate *time.Time `json:"dueDate,omitempty"`
+	Subject string     `json:"subject"`
+	Text    string     `json:"text"`
+}
+
+func (w *Webhook) Notify(ctx context.Context, m notify.Message) error {
+	body, err := json.Marshal(webhookPayload{
+		ID:      m.Key,
+		Type:    "reminder",
+		UserID:  m.UserID,
+		TodoID:  m.TodoID,
+		Title:   m.Title,
+		DueDate: m.DueDate,
+		Subject: m.Subject,
+		Text:    m.Text,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", m.Key)
+	if w.secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.secret))
+		mac.Write(body)
+		req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package request
+
+import "net/url"
+
+// query parameters accepted by GET /api/activity and GET /api/todos/{id}/history
+// (which ignores resource)
+type ActivityFiltersDTO struct {
+	Resource *string `form:"resource,omitempty"`
+	Limit    *string `form:"limit,omitempty"`
+	Cursor   *string `form:"cursor,omitempty"`
+}
+
+// NewActivityFiltersDTO reads the filters from a URL query; empty ones are left nil.
+func NewActivityFiltersDTO(q url.Values) ActivityFiltersDTO {
+	get := func(key string) *string {
+		if v := q.Get(key); v != "" {
+			return &v
+		}
+		return nil
+	}
+	return ActivityFiltersDTO{
+		Resource: get("resource"),
+		Limit:    get("limit"),
+		Cursor:   get("cursor"),
+	}
+}
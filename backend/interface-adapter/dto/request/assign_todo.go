@@ -0,0 +1,6 @@
+package request
+
+// body of POST /api/todos/{id}/assignees
+type AssignTodoDTO struct {
+	UserID string `json:"userId"`
+}
@@ -0,0 +1,16 @@
+package request
+
+import "time"
+
+// body of POST /api/todos/bulk; only the field the action needs is read,
+// and a null categoryId or dueDate clears it. workspaceId names the workspace
+// the todos are in; without it they are the caller's personal todos
+type BulkTodosDTO struct {
+	IDs         []string   `json:"ids"`
+	WorkspaceID *string    `json:"workspaceId,omitempty"`
+	Action      string     `json:"action"`
+	Status      string     `json:"status,omitempty"`
+	CategoryID  *string    `json:"categoryId,omitempty"`
+	TagIDs      []string   `json:"tagIds,omitempty"`
+	DueDate     *time.Time `json:"dueDate,omitempty"`
+}
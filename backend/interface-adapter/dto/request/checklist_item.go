@@ -0,0 +1,15 @@
+package request
+
+type CreateChecklistItemDTO struct {
+	Title string `json:"title"`
+}
+
+type UpdateChecklistItemDTO struct {
+	Title *string `json:"title,omitempty"`
+	Done  *bool   `json:"done,omitempty"`
+}
+
+// full new order of the todo's items, e.g. {"itemIds":["b","a","c"]}
+type ReorderChecklistItemsDTO struct {
+	ItemIDs []string `json:"itemIds"`
+}
@@ -0,0 +1,28 @@
+package request
+
+import "net/url"
+
+// body of POST /api/todos/{id}/comments and PATCH /api/todos/{id}/comments/{commentId}
+type CommentDTO struct {
+	Body string `json:"body"`
+}
+
+// query parameters accepted by GET /api/todos/{id}/comments
+type CommentFiltersDTO struct {
+	Limit  *string `form:"limit,omitempty"`
+	Cursor *string `form:"cursor,omitempty"`
+}
+
+// NewCommentFiltersDTO reads the filters from a URL query; empty ones are left nil.
+func NewCommentFiltersDTO(q url.Values) CommentFiltersDTO {
+	get := func(key string) *string {
+		if v := q.Get(key); v != "" {
+			return &v
+		}
+		return nil
+	}
+	return CommentFiltersDTO{
+		Limit:  get("limit"),
+		Cursor: get("cursor"),
+	}
+}
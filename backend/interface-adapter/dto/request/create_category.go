@@ -4,4 +4,5 @@ type CreateCategoryDTO struct {
     Name        string  `json:"name"`
     Color       string  `json:"color"`
     Description *string `json:"description,omitempty"`
+    WorkspaceID *string `json:"workspaceId,omitempty"` // omitted: a personal category
 }
@@ -1,5 +1,6 @@
 package request
 
 type CreateTagDTO struct {
-    Name string `json:"name"`
+    Name        string  `json:"name"`
+    WorkspaceID *string `json:"workspaceId,omitempty"` // omitted: a personal tag
 }
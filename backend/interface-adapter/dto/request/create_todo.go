@@ -3,10 +3,15 @@ package request
 import "time"
 
 type CreateTodoDTO struct {
-	Title      string     `json:"title"`
-	Body       *string    `json:"body"`
-	DueDate    *time.Time `json:"dueDate"`
-	Status     string     `json:"status"`
-	CategoryID *string    `json:"categoryId"`
-	TagIDs     []string   `json:"tagIds"`
+	Title        string         `json:"title"`
+	Body         *string        `json:"body"`
+	DueDate      *time.Time     `json:"dueDate"`
+	Status       string         `json:"status"`
+	Priority     string         `json:"priority"`
+	CategoryID   *string        `json:"categoryId"`
+	TagIDs       []string       `json:"tagIds"`
+	Recurrence   *RecurrenceDTO `json:"recurrence"`
+	AutoComplete bool           `json:"autoComplete"`
+	// WorkspaceID puts the todo in a shared workspace; omitted, it is personal
+	WorkspaceID *string `json:"workspaceId"`
 }
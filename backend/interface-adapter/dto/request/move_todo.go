@@ -0,0 +1,9 @@
+package request
+
+// body of PATCH /api/todos/{id}/move; set at most one of beforeId and afterId,
+// or neither to move the todo to the bottom of the column
+type MoveTodoDTO struct {
+	Status   string  `json:"status,omitempty"`   // the column to move to; empty keeps the current one
+	BeforeID *string `json:"beforeId,omitempty"` // the todo to sit directly above
+	AfterID  *string `json:"afterId,omitempty"`  // the todo to sit directly below
+}
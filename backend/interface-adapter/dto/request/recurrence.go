@@ -0,0 +1,12 @@
+package request
+
+import "time"
+
+// RFC 5545-style repeat rule, e.g. {"frequency":"WEEKLY","interval":2,"byWeekday":["MO","TH"]}
+type RecurrenceDTO struct {
+	Frequency string     `json:"frequency"`
+	Interval  int        `json:"interval,omitempty"` // defaults to 1
+	ByWeekday []string   `json:"byWeekday,omitempty"`
+	Until     *time.Time `json:"until,omitempty"`
+	Count     *int       `json:"count,omitempty"`
+}
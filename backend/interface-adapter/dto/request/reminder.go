@@ -0,0 +1,8 @@
+package request
+
+// body of POST /api/todos/{id}/reminders; set exactly one of remindAt and offsetMinutes
+type CreateReminderDTO struct {
+	RemindAt      *string `json:"remindAt,omitempty"`      // RFC 3339, or "2026-11-02T09:00" in the user's timezone
+	OffsetMinutes *int    `json:"offsetMinutes,omitempty"` // minutes before the due date
+	Channel       string  `json:"channel,omitempty"`       // "inapp" (default), "email" or "webhook"
+}
@@ -1,11 +1,41 @@
 package request
 
-// add fields here
-// does not implement filtering logic
+import "net/url"
+
+// query parameters accepted by GET /api/todos
 type TodoFiltersDTO struct {
-    Status     *string `form:"status,omitempty"`
-    CategoryID *string `form:"categoryId,omitempty"`
-    TagID      *string `form:"tagId,omitempty"`
+    Status      *string `form:"status,omitempty"`
+    CategoryID  *string `form:"categoryId,omitempty"`
+    TagID       *string `form:"tagId,omitempty"`
     DueDateFrom *string `form:"dueDateFrom,omitempty"`
     DueDateTo   *string `form:"dueDateTo,omitempty"`
+    SortBy      *string `form:"sortBy,omitempty"`
+    Order       *string `form:"order,omitempty"` // "asc" or "desc"
+    Limit       *string `form:"limit,omitempty"`
+    Cursor      *string `form:"cursor,omitempty"`
+    WorkspaceID *string `form:"workspaceId,omitempty"` // a workspace's todos instead of the caller's own
+    Assignee    *string `form:"assignee,omitempty"`    // a user ID, or "me" for the caller
+}
+
+// NewTodoFiltersDTO reads the filters from a URL query; empty values are left nil.
+func NewTodoFiltersDTO(q url.Values) TodoFiltersDTO {
+    get := func(key string) *string {
+        if v := q.Get(key); v != "" {
+            return &v
+        }
+        return nil
+    }
+    return TodoFiltersDTO{
+        Status:      get("status"),
+        CategoryID:  get("categoryId"),
+        TagID:       get("tagId"),
+        DueDateFrom: get("dueDateFrom"),
+        DueDateTo:   get("dueDateTo"),
+        SortBy:      get("sortBy"),
+        Order:       get("order"),
+        Limit:       get("limit"),
+        Cursor:      get("cursor"),
+        WorkspaceID: get("workspaceId"),
+        Assignee:    get("assignee"),
+    }
 }
@@ -0,0 +1,33 @@
+package request
+
+import "net/url"
+
+// query parameters accepted by GET /api/todos/search
+type TodoSearchDTO struct {
+	Query       string  `form:"q"`
+	WorkspaceID *string `form:"workspaceId,omitempty"` // only this workspace's todos
+	Status      *string `form:"status,omitempty"`
+	CategoryID  *string `form:"categoryId,omitempty"`
+	TagID       *string `form:"tagId,omitempty"`
+	Limit       *string `form:"limit,omitempty"`
+	Cursor      *string `form:"cursor,omitempty"`
+}
+
+// NewTodoSearchDTO reads the search from a URL query; empty filters are left nil.
+func NewTodoSearchDTO(q url.Values) TodoSearchDTO {
+	get := func(key string) *string {
+		if v := q.Get(key); v != "" {
+			return &v
+		}
+		return nil
+	}
+	return TodoSearchDTO{
+		Query:       q.Get("q"),
+		WorkspaceID: get("workspaceId"),
+		Status:      get("status"),
+		CategoryID:  get("categoryId"),
+		TagID:       get("tagId"),
+		Limit:       get("limit"),
+		Cursor:      get("cursor"),
+	}
+}
@@ -1,12 +1,19 @@
 package request
 
-import "time"
+import (
+    "encoding/json"
+    "time"
+)
 
 type UpdateTodoDTO struct {
-    Title      *string    `json:"title,omitempty"`
-    Body       *string    `json:"body,omitempty"`
-    DueDate    *time.Time `json:"dueDate,omitempty"`
-    Status     *string    `json:"status,omitempty"`
-    CategoryID *string    `json:"categoryId,omitempty"`
-    TagIDs     *[]string  `json:"tagIds,omitempty"`
+    Title        *string    `json:"title,omitempty"`
+    Body         *string    `json:"body,omitempty"`
+    DueDate      *time.Time `json:"dueDate,omitempty"`
+    Status       *string    `json:"status,omitempty"`
+    Priority     *string    `json:"priority,omitempty"`
+    CategoryID   *string    `json:"categoryId,omitempty"`
+    TagIDs       *[]string  `json:"tagIds,omitempty"`
+    AutoComplete *bool      `json:"autoComplete,omitempty"`
+    // absent leaves the rule unchanged, null stops the todo from recurring
+    Recurrence json.RawMessage `json:"recurrence,omitempty"`
 }
@@ -0,0 +1,22 @@
+package request
+
+// body of POST /api/workspaces and PATCH /api/workspaces/{id}
+type WorkspaceDTO struct {
+	Name string `json:"name"`
+}
+
+// body of POST /api/workspaces/{id}/invitations
+type InviteMemberDTO struct {
+	Email string `json:"email"`
+	Role  string `json:"role"` // "owner", "editor" or "viewer"
+}
+
+// body of POST /api/invitations/{id}/accept
+type AcceptInvitationDTO struct {
+	Token string `json:"token"` // from the invitation's creation, passed on by the owner
+}
+
+// body of PATCH /api/workspaces/{id}/members/{userId}
+type UpdateMemberDTO struct {
+	Role string `json:"role"`
+}
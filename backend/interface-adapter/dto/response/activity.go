@@ -0,0 +1,27 @@
+package response
+
+import "time"
+
+// one audit log entry
+type ActivityResponseDTO struct {
+	ID         string           `json:"id"`
+	Resource   string           `json:"resource"`
+	ResourceID string           `json:"resourceId"`
+	Action     string           `json:"action"`
+	ActorID    string           `json:"actorId"`
+	Changes    []FieldChangeDTO `json:"changes"`
+	CreatedAt  time.Time        `json:"createdAt"`
+}
+
+// one field's value before and after the change; null when unset
+type FieldChangeDTO struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type ActivityListResponseDTO struct {
+	Items      []ActivityResponseDTO `json:"items"`
+	Total      int                   `json:"total"`
+	NextCursor *string               `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+type AttachmentResponseDTO struct {
+	ID          string    `json:"id"`
+	TodoID      string    `json:"todoId"`
+	UserID      string    `json:"userId"` // the uploader
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"` // in bytes
+	CreatedAt   time.Time `json:"createdAt"`
+}
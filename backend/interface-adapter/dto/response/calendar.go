@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+// CalendarFeedResponseDTO reports whether the caller has a feed; the token
+// itself is only shown when it is issued.
+type CalendarFeedResponseDTO struct {
+	Active    bool       `json:"active"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// CalendarFeedTokenResponseDTO is returned once, when a feed token is issued.
+type CalendarFeedTokenResponseDTO struct {
+	Token     string    `json:"token"`
+	Path      string    `json:"path"` // e.g. "/api/calendar/<token>.ics"
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -8,6 +8,8 @@ type CategoryResponseDTO struct {
     Color       string     `json:"color"`
     Description *string    `json:"description,omitempty"`
     UserID      string     `json:"userId"`
+    WorkspaceID *string    `json:"workspaceId"` // null for a personal category
+    Version     int        `json:"version"` // for If-Match on PUT/DELETE
     CreatedAt   time.Time  `json:"createdAt"`
     UpdatedAt   time.Time  `json:"updatedAt"`
 }
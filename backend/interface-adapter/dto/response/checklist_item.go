@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type ChecklistItemResponseDTO struct {
+	ID        string    `json:"id"`
+	TodoID    string    `json:"todoId"`
+	Title     string    `json:"title"`
+	Done      bool      `json:"done"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// returned when an item changes; todo carries the refreshed progress and,
+// if the change auto-completed a recurring parent, its next occurrence
+type ChecklistItemUpdateResponseDTO struct {
+	Item ChecklistItemResponseDTO `json:"item"`
+	Todo TodoStatusResponseDTO    `json:"todo"`
+}
@@ -0,0 +1,19 @@
+package response
+
+import "time"
+
+type CommentResponseDTO struct {
+	ID        string    `json:"id"`
+	TodoID    string    `json:"todoId"`
+	UserID    string    `json:"userId"` // the author
+	Body      string    `json:"body"`
+	Edited    bool      `json:"edited"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CommentListResponseDTO struct {
+	Items      []CommentResponseDTO `json:"items"`
+	Total      int                  `json:"total"`
+	NextCursor *string              `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,16 @@
+package response
+
+// ErrorResponseDTO is the body of every non-2xx JSON response:
+// {"error":{"code":"validation","message":"...","fields":{"title":"..."}}}
+// References that cannot be used are also listed by ID:
+// {"error":{..., "ids":{"tagIds":["..."]}}}
+type ErrorResponseDTO struct {
+    Error ErrorDetailDTO `json:"error"`
+}
+
+type ErrorDetailDTO struct {
+    Code    string              `json:"code"`
+    Message string              `json:"message"`
+    Fields  map[string]string   `json:"fields,omitempty"`
+    IDs     map[string][]string `json:"ids,omitempty"`
+}
@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+// EventDTO is the data of one change-feed message. Data is the record as
+// saved (a todo, category or tag response), omitted for deletions.
+type EventDTO struct {
+	Resource string    `json:"resource"`
+	Action   string    `json:"action"`
+	ID       string    `json:"id"`
+	Data     any       `json:"data,omitempty"`
+	At       time.Time `json:"at"`
+}
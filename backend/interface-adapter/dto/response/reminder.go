@@ -0,0 +1,26 @@
+package response
+
+import "time"
+
+type ReminderResponseDTO struct {
+	ID            string     `json:"id"`
+	TodoID        string     `json:"todoId"`
+	RemindAt      *time.Time `json:"remindAt,omitempty"`
+	OffsetMinutes *int       `json:"offsetMinutes,omitempty"`
+	Channel       string     `json:"channel"`
+	FireAt        time.Time  `json:"fireAt"`
+	Status        string     `json:"status"` // pending, sent, failed or cancelled
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"lastError,omitempty"`
+	SentAt        *time.Time `json:"sentAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+type NotificationResponseDTO struct {
+	ID        string     `json:"id"`
+	TodoID    string     `json:"todoId"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+}
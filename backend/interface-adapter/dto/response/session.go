@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+type SessionResponseDTO struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	Current    bool      `json:"current"` // the session this request was made with
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
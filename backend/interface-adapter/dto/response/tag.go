@@ -3,9 +3,11 @@ package response
 import "time"
 
 type TagResponseDTO struct {
-    ID        string    `json:"id"`
-    Name      string    `json:"name"`
-    UserID    string    `json:"userId"`
-    CreatedAt time.Time `json:"createdAt"`
-    UpdatedAt time.Time `json:"updatedAt"`
+    ID          string    `json:"id"`
+    Name        string    `json:"name"`
+    UserID      string    `json:"userId"`
+    WorkspaceID *string   `json:"workspaceId"` // null for a personal tag
+    Version     int       `json:"version"` // for If-Match on PUT/DELETE
+    CreatedAt   time.Time `json:"createdAt"`
+    UpdatedAt   time.Time `json:"updatedAt"`
 }
@@ -3,15 +3,91 @@ package response
 import "time"
 
 type TodoResponseDTO struct {
-    ID          string     `json:"id"`
-    Title       string     `json:"title"`
-    Body        *string    `json:"body,omitempty"`
-    Status      string     `json:"status"`
-    DueDate     *time.Time `json:"dueDate,omitempty"`
-    CompletedAt *time.Time `json:"completedAt,omitempty"`
-    UserID      string     `json:"userId"`
-    CategoryID  *string    `json:"categoryId,omitempty"`
-    TagIDs      []string   `json:"tagIds,omitempty"`
-    CreatedAt   time.Time  `json:"createdAt"`
-    UpdatedAt   time.Time  `json:"updatedAt"`
+    ID           string                 `json:"id"`
+    Title        string                 `json:"title"`
+    Body         *string                `json:"body,omitempty"`
+    Status       string                 `json:"status"`
+    Priority     string                 `json:"priority"`
+    Rank         string                 `json:"rank"` // orders todos within a status column
+    DueDate      *time.Time             `json:"dueDate,omitempty"`
+    CompletedAt  *time.Time             `json:"completedAt,omitempty"`
+    UserID       string                 `json:"userId"`
+    WorkspaceID  *string                `json:"workspaceId"` // null for a personal todo
+    CategoryID   *string                `json:"categoryId,omitempty"`
+    TagIDs       []string               `json:"tagIds,omitempty"`
+    Assignees    []AssigneeResponseDTO  `json:"assignees"` // in the order they were assigned
+    Recurrence   *RecurrenceResponseDTO `json:"recurrence,omitempty"`
+    AutoComplete bool                   `json:"autoComplete"`
+    Progress     ChecklistProgressDTO   `json:"progress"`
+    Version      int                    `json:"version"` // also sent as the ETag of GET /todos/{id}
+    DeletedAt    *time.Time             `json:"deletedAt,omitempty"` // only on todos in the trash
+    CreatedAt    time.Time              `json:"createdAt"`
+    UpdatedAt    time.Time              `json:"updatedAt"`
+}
+
+type AssigneeResponseDTO struct {
+    UserID    string  `json:"userId"`
+    Name      *string `json:"name"`
+    AvatarURL *string `json:"avatarUrl"`
+}
+
+// checklist completion, e.g. {"done": 2, "total": 5}
+type ChecklistProgressDTO struct {
+    Done  int `json:"done"`
+    Total int `json:"total"`
+}
+
+type RecurrenceResponseDTO struct {
+    Frequency string     `json:"frequency"`
+    Interval  int        `json:"interval"`
+    ByWeekday []string   `json:"byWeekday,omitempty"`
+    Until     *time.Time `json:"until,omitempty"`
+    Count     *int       `json:"count,omitempty"`
+}
+
+// returned when a status change may have spawned the next occurrence of a recurring todo
+type TodoStatusResponseDTO struct {
+    TodoResponseDTO
+    NextOccurrence *TodoResponseDTO `json:"nextOccurrence,omitempty"`
+}
+
+type TodoListResponseDTO struct {
+    Items      []TodoResponseDTO `json:"items"`
+    Total      int               `json:"total"`
+    NextCursor *string           `json:"nextCursor,omitempty"`
+}
+
+// one search result; snippets are split into matched and unmatched runs,
+// e.g. [{"text":"Buy "},{"text":"milk","match":true}]
+type TodoSearchHitDTO struct {
+    TodoResponseDTO
+    Rank         float64          `json:"rank"`
+    TitleSnippet []TextSegmentDTO `json:"titleSnippet"`
+    BodySnippet  []TextSegmentDTO `json:"bodySnippet,omitempty"`
+}
+
+type TextSegmentDTO struct {
+    Text  string `json:"text"`
+    Match bool   `json:"match,omitempty"`
+}
+
+type TodoSearchResponseDTO struct {
+    Items      []TodoSearchHitDTO `json:"items"`
+    Total      int                `json:"total"`
+    NextCursor *string            `json:"nextCursor,omitempty"`
+}
+
+// per-todo outcome of POST /api/todos/bulk; todo is omitted for deletes and failures
+type BulkTodoResultDTO struct {
+    ID             string           `json:"id"`
+    OK             bool             `json:"ok"`
+    Todo           *TodoResponseDTO `json:"todo,omitempty"`
+    NextOccurrence *TodoResponseDTO `json:"nextOccurrence,omitempty"`
+    Error          *ErrorDetailDTO  `json:"error,omitempty"`
+}
+
+type BulkTodosResponseDTO struct {
+    Results   []BulkTodoResultDTO `json:"results"`
+    Succeeded int                 `json:"succeeded"`
+    Failed    int                 `json:"failed"`
 }
@@ -0,0 +1,16 @@
+package response
+
+// ImportResultResponseDTO reports what POST /api/import created or, on a dry
+// run, would create.
+type ImportResultResponseDTO struct {
+	DryRun     bool                 `json:"dryRun"`
+	Todos      int                  `json:"todos"`      // todos created
+	Categories []string             `json:"categories"` // names of categories created
+	Tags       []string             `json:"tags"`       // names of tags created
+	Duplicates []ImportDuplicateDTO `json:"duplicates"` // todos skipped as duplicates
+}
+
+type ImportDuplicateDTO struct {
+	Index int    `json:"index"` // 1-based position of the todo in the file
+	Title string `json:"title"`
+}
@@ -0,0 +1,39 @@
+package response
+
+import "time"
+
+type WorkspaceResponseDTO struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"` // the caller's: owner, editor or viewer
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// returned by GET /api/workspaces/{id}
+type WorkspaceDetailResponseDTO struct {
+	WorkspaceResponseDTO
+	Members []WorkspaceMemberResponseDTO `json:"members"` // oldest first
+}
+
+type WorkspaceMemberResponseDTO struct {
+	UserID   string    `json:"userId"`
+	Email    string    `json:"email,omitempty"`
+	Name     *string   `json:"name,omitempty"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+type WorkspaceInvitationResponseDTO struct {
+	ID            string    `json:"id"`
+	WorkspaceID   string    `json:"workspaceId"`
+	WorkspaceName *string   `json:"workspaceName,omitempty"` // only on invitations the caller received
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	InvitedBy     string    `json:"invitedBy"`
+	CreatedAt     time.Time `json:"createdAt"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	// Token is only set when the invitation is created; the owner passes it
+	// on to the invitee, who needs it to accept.
+	Token string `json:"token,omitempty"`
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ariangn/todo-fullstack/backend/application/activity"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/request"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+type ActivityController struct {
+	listUC activity.ListUseCase
+}
+
+func NewActivityController(lUC activity.ListUseCase) *ActivityController {
+	return &ActivityController{lUC}
+}
+
+// List pages through the caller's activity feed, newest first, optionally
+// narrowed to one resource kind with ?resource=todo|category|tag.
+func (ac *ActivityController) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	dto := request.NewActivityFiltersDTO(r.URL.Query())
+	var q repository.ActivityQuery
+	var err error
+	if q.Limit, q.Offset, err = parsePageParams(dto.Limit, dto.Cursor); err != nil {
+		writeError(w, err)
+		return
+	}
+	if dto.Resource != nil {
+		q.Resource = entity.ActivityResource(*dto.Resource)
+	}
+
+	page, err := ac.listUC.Execute(r.Context(), userID, q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toActivityListResponseDTO(page))
+}
+
+// parsePageParams reads ?limit= and ?cursor=; range checks are left to the use case.
+func parsePageParams(limit, cursor *string) (int, int, error) {
+	var n, offset int
+	var err error
+	if limit != nil {
+		if n, err = strconv.Atoi(*limit); err != nil {
+			return 0, 0, activity.ErrInvalidPageSize
+		}
+	}
+	if cursor != nil {
+		if offset, err = decodeCursor(*cursor); err != nil {
+			return 0, 0, apperror.InvalidField("cursor", errors.New("invalid cursor"))
+		}
+	}
+	return n, offset, nil
+}
+
+func toActivityListResponseDTO(page *repository.ActivityPage) response.ActivityListResponseDTO {
+	respDTO := response.ActivityListResponseDTO{
+		Items: make([]response.ActivityResponseDTO, 0, len(page.Entries)),
+		Total: page.Total,
+	}
+	for _, a := range page.Entries {
+		changes := make([]response.FieldChangeDTO, 0, len(a.Changes))
+		for _, c := range a.Changes {
+			changes = append(changes, response.FieldChangeDTO{Field: c.Field, Before: c.Before, After: c.After})
+		}
+		respDTO.Items = append(respDTO.Items, response.ActivityResponseDTO{
+			ID:         a.ID,
+			Resource:   string(a.Resource),
+			ResourceID: a.ResourceID,
+			Action:     string(a.Action),
+			ActorID:    a.ActorID,
+			Changes:    changes,
+			CreatedAt:  a.CreatedAt,
+		})
+	}
+	if page.NextOffset != nil {
+		cursor := encodeCursor(*page.NextOffset)
+		respDTO.NextCursor = &cursor
+	}
+	return respDTO
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ariangn/todo-fullstack/backend/application/attachment"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// AttachmentController serves /api/todos/{id}/attachments.
+type AttachmentController struct {
+	uploadUC   attachment.UploadUseCase
+	listUC     attachment.ListUseCase
+	downloadUC attachment.DownloadUseCase
+	deleteUC   attachment.DeleteUseCase
+}
+
+func NewAttachmentController(
+	uUC attachment.UploadUseCase,
+	lUC attachment.ListUseCase,
+	dlUC attachment.DownloadUseCase,
+	dUC attachment.DeleteUseCase,
+) *AttachmentController {
+	return &AttachmentController{uUC, lUC, dlUC, dUC}
+}
+
+// Upload attaches the file sent as multipart/form-data in the "file" field.
+// Its type is sniffed from the contents; the client's Content-Type is ignored.
+func (ac *AttachmentController) Upload(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	file, header, cleanup, err := openUpload(w, r, entity.MaxAttachmentSize)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cleanup()
+	contentType, err := sniffContentType(file)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	a, err := ac.uploadUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), attachment.Upload{
+		FileName:    header.Filename,
+		ContentType: contentType,
+		Size:        header.Size,
+		Body:        file,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toAttachmentResponseDTO(a))
+}
+
+func (ac *AttachmentController) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	attachments, err := ac.listUC.Execute(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respDTO := make([]response.AttachmentResponseDTO, 0, len(attachments))
+	for _, a := range attachments {
+		respDTO = append(respDTO, toAttachmentResponseDTO(a))
+	}
+	writeJSON(w, http.StatusOK, respDTO)
+}
+
+// Download redirects to a short-lived signed URL for the file, so its
+// contents never pass through this server.
+func (ac *AttachmentController) Download(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	url, err := ac.downloadUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "attachmentId"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// the URL expires, so neither the redirect nor the link may be cached
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+func (ac *AttachmentController) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	if err := ac.deleteUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "attachmentId")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sniffContentType detects the media type from the first bytes of f, without
+// parameters, and rewinds it.
+func sniffContentType(f io.ReadSeeker) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if err != nil {
+		return "", err
+	}
+	return mediaType, nil
+}
+
+func toAttachmentResponseDTO(a *entity.Attachment) response.AttachmentResponseDTO {
+	return response.AttachmentResponseDTO{
+		ID:          a.ID,
+		TodoID:      a.TodoID,
+		UserID:      a.UserID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt,
+	}
+}
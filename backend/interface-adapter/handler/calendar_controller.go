@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ariangn/todo-fullstack/backend/application/calendar"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// CalendarController serves the iCalendar feed and /api/calendar/feed, where
+// its token is managed.
+type CalendarController struct {
+	getUC    calendar.GetUseCase
+	rotateUC calendar.RotateUseCase
+	revokeUC calendar.RevokeUseCase
+	feedUC   calendar.FeedUseCase
+}
+
+func NewCalendarController(
+	gUC calendar.GetUseCase,
+	rUC calendar.RotateUseCase,
+	rvUC calendar.RevokeUseCase,
+	fUC calendar.FeedUseCase,
+) *CalendarController {
+	return &CalendarController{gUC, rUC, rvUC, fUC}
+}
+
+// Feed renders GET /api/calendar/{token}.ics. It is a public route: calendar
+// apps cannot sign in, so the token in the URL is the credential.
+// ?type=todo exports VTODOs instead of the default VEVENTs.
+func (cc *CalendarController) Feed(w http.ResponseWriter, r *http.Request) {
+	component := icsEvent
+	switch r.URL.Query().Get("type") {
+	case "", "event":
+	case "todo":
+		component = icsTodo
+	default:
+		writeError(w, calendar.ErrInvalidComponent)
+		return
+	}
+
+	feed, err := cc.feedUC.Execute(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	body := renderICS(feed, component)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if notModified(w, r, listETag([]string{string(body)})) {
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="todos.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (cc *CalendarController) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	feed, err := cc.getUC.Execute(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := response.CalendarFeedResponseDTO{}
+	if feed != nil {
+		resp.Active = true
+		resp.CreatedAt = &feed.CreatedAt
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Rotate issues a new feed URL; the previous one stops working.
+func (cc *CalendarController) Rotate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	token, feed, err := cc.rotateUC.Execute(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, response.CalendarFeedTokenResponseDTO{
+		Token:     token,
+		Path:      "/api/calendar/" + token + ".ics",
+		CreatedAt: feed.CreatedAt,
+	})
+}
+
+func (cc *CalendarController) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	if err := cc.revokeUC.Execute(r.Context(), userID); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
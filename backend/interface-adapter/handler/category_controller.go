@@ -10,6 +10,8 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/ariangn/todo-fullstack/backend/application/category"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/request"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
@@ -32,63 +34,58 @@ func NewCategoryController(
 }
 
 func (cc *CategoryController) Create(w http.ResponseWriter, r *http.Request) {
-	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
 	var dto request.CreateCategoryDTO
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
-		http.Error(w, "invalid request payload", http.StatusBadRequest)
+		writeError(w, errInvalidPayload(err))
 		return
 	}
 	categoryEntity, err := cc.createUC.Execute(
 		r.Context(),
 		userID,
+		dto.WorkspaceID,
 		dto.Name,
 		dto.Color,
 		dto.Description,
 	)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
-	respDTO := response.CategoryResponseDTO{
-		ID:          categoryEntity.ID,
-		Name:        categoryEntity.Name,
-		Color:       categoryEntity.Color,
-		Description: categoryEntity.Description,
-		UserID:      categoryEntity.UserID,
-		CreatedAt:   categoryEntity.CreatedAt,
-		UpdatedAt:   categoryEntity.UpdatedAt,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(respDTO)
+	respDTO := toCategoryResponseDTO(categoryEntity)
+	writeJSON(w, http.StatusCreated, respDTO)
 }
 
 func (cc *CategoryController) List(w http.ResponseWriter, r *http.Request) {
-	userID, _ := middleware.GetUserIDFromContext(r.Context())
-	categories, err := cc.listUC.Execute(r.Context(), userID)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+	categories, err := cc.listUC.Execute(r.Context(), userID, workspaceParam(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
+		return
+	}
+	parts := []string{}
+	for _, c := range categories {
+		parts = append(parts, memberTag(c.ID, c.Version))
+	}
+	if notModified(w, r, listETag(parts)) {
 		return
 	}
-	var respList []response.CategoryResponseDTO
+	respList := []response.CategoryResponseDTO{}
 	for _, c := range categories {
-		respList = append(respList, response.CategoryResponseDTO{
-			ID:          c.ID,
-			Name:        c.Name,
-			Color:       c.Color,
-			Description: c.Description,
-			UserID:      c.UserID,
-			CreatedAt:   c.CreatedAt,
-			UpdatedAt:   c.UpdatedAt,
-		})
-	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(respList)
+		respList = append(respList, toCategoryResponseDTO(c))
+	}
+	writeJSON(w, http.StatusOK, respList)
 }
 
 func (cc *CategoryController) Update(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
 	raw, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Printf("DEBUG: could not read body: %v", err)
@@ -99,8 +96,7 @@ func (cc *CategoryController) Update(w http.ResponseWriter, r *http.Request) {
 	// 1) auth
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
+		writeError(w, errUnauthorized)
 		return
 	}
 
@@ -109,16 +105,14 @@ func (cc *CategoryController) Update(w http.ResponseWriter, r *http.Request) {
 
 	// 3) ensure body wasn’t empty
 	if len(raw) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": "request body was empty"})
+		writeError(w, apperror.Validation("request body was empty"))
 		return
 	}
 
 	// 4) decode into DTO
 	var dto request.UpdateCategoryDTO
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": "invalid JSON: " + err.Error()})
+		writeError(w, errInvalidPayload(err))
 		return
 	}
 
@@ -130,37 +124,49 @@ func (cc *CategoryController) Update(w http.ResponseWriter, r *http.Request) {
 		getString(dto.Name),
 		getString(dto.Color),
 		getString(dto.Description),
+		ifMatchVersions(r),
 	)
 	if err != nil {
 		log.Printf("DEBUG: updateUC.Execute returned error: %v", err)
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, err)
 		return
 	}
 
 	// 6) success response
-	respDTO := response.CategoryResponseDTO{
-		ID:          updatedEntity.ID,
-		Name:        updatedEntity.Name,
-		Color:       updatedEntity.Color,
-		Description: updatedEntity.Description,
-		UserID:      updatedEntity.UserID,
-		CreatedAt:   updatedEntity.CreatedAt,
-		UpdatedAt:   updatedEntity.UpdatedAt,
-	}
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(respDTO)
+	respDTO := toCategoryResponseDTO(updatedEntity)
+	w.Header().Set("ETag", versionETag(updatedEntity.Version))
+	writeJSON(w, http.StatusOK, respDTO)
 }
 
 func (cc *CategoryController) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+
 	id := chi.URLParam(r, "id")
-	if err := cc.deleteUC.Execute(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := cc.deleteUC.Execute(r.Context(), userID, id, ifMatchVersions(r)); err != nil {
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func toCategoryResponseDTO(c *entity.Category) response.CategoryResponseDTO {
+	return response.CategoryResponseDTO{
+		ID:          c.ID,
+		Name:        c.Name,
+		Color:       c.Color,
+		Description: c.Description,
+		UserID:      c.UserID,
+		WorkspaceID: c.WorkspaceID,
+		Version:     c.Version,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
 // getString safely dereferences a *string, returning an empty string if nil.
 func getString(s *string) string {
 	if s == nil {
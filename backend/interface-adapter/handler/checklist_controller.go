@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ariangn/todo-fullstack/backend/application/checklist"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/request"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// ChecklistController serves /api/todos/{id}/items.
+type ChecklistController struct {
+	createUC  checklist.CreateUseCase
+	listUC    checklist.ListUseCase
+	updateUC  checklist.UpdateUseCase
+	reorderUC checklist.ReorderUseCase
+	deleteUC  checklist.DeleteUseCase
+}
+
+func NewChecklistController(
+	cUC checklist.CreateUseCase,
+	lUC checklist.ListUseCase,
+	uUC checklist.UpdateUseCase,
+	rUC checklist.ReorderUseCase,
+	dUC checklist.DeleteUseCase,
+) *ChecklistController {
+	return &ChecklistController{cUC, lUC, uUC, rUC, dUC}
+}
+
+func (cc *ChecklistController) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.CreateChecklistItemDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	item, err := cc.createUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), dto.Title)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toChecklistItemResponseDTO(item))
+}
+
+func (cc *ChecklistController) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	items, err := cc.listUC.Execute(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toChecklistItemResponseDTOs(items))
+}
+
+func (cc *ChecklistController) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.UpdateChecklistItemDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	result, err := cc.updateUC.Execute(
+		r.Context(),
+		userID,
+		chi.URLParam(r, "id"),
+		chi.URLParam(r, "itemId"),
+		dto.Title,
+		dto.Done,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response.ChecklistItemUpdateResponseDTO{
+		Item: toChecklistItemResponseDTO(result.Item),
+		Todo: toTodoStatusResponseDTO(result.Todo, result.Next),
+	})
+}
+
+func (cc *ChecklistController) Reorder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.ReorderChecklistItemsDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	items, err := cc.reorderUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), dto.ItemIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toChecklistItemResponseDTOs(items))
+}
+
+func (cc *ChecklistController) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	if err := cc.deleteUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "itemId")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toChecklistItemResponseDTO(item *entity.ChecklistItem) response.ChecklistItemResponseDTO {
+	return response.ChecklistItemResponseDTO{
+		ID:        item.ID,
+		TodoID:    item.TodoID,
+		Title:     item.Title,
+		Done:      item.Done,
+		Position:  item.Position,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
+
+func toChecklistItemResponseDTOs(items []*entity.ChecklistItem) []response.ChecklistItemResponseDTO {
+	respList := []response.ChecklistItemResponseDTO{}
+	for _, item := range items {
+		respList = append(respList, toChecklistItemResponseDTO(item))
+	}
+	return respList
+}
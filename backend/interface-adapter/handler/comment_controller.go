@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ariangn/todo-fullstack/backend/application/comment"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/request"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// CommentController serves /api/todos/{id}/comments.
+type CommentController struct {
+	createUC comment.CreateUseCase
+	listUC   comment.ListUseCase
+	updateUC comment.UpdateUseCase
+	deleteUC comment.DeleteUseCase
+}
+
+func NewCommentController(
+	cUC comment.CreateUseCase,
+	lUC comment.ListUseCase,
+	uUC comment.UpdateUseCase,
+	dUC comment.DeleteUseCase,
+) *CommentController {
+	return &CommentController{cUC, lUC, uUC, dUC}
+}
+
+func (cc *CommentController) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.CommentDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	c, err := cc.createUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), dto.Body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toCommentResponseDTO(c))
+}
+
+// List pages through the todo's comments, oldest first.
+func (cc *CommentController) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	dto := request.NewCommentFiltersDTO(r.URL.Query())
+	q := repository.CommentQuery{TodoID: chi.URLParam(r, "id")}
+	var err error
+	if q.Limit, q.Offset, err = parsePageParams(dto.Limit, dto.Cursor); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := cc.listUC.Execute(r.Context(), userID, q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respDTO := response.CommentListResponseDTO{
+		Items: make([]response.CommentResponseDTO, 0, len(page.Comments)),
+		Total: page.Total,
+	}
+	for _, c := range page.Comments {
+		respDTO.Items = append(respDTO.Items, toCommentResponseDTO(c))
+	}
+	if page.NextOffset != nil {
+		cursor := encodeCursor(*page.NextOffset)
+		respDTO.NextCursor = &cursor
+	}
+	writeJSON(w, http.StatusOK, respDTO)
+}
+
+func (cc *CommentController) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.CommentDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	c, err := cc.updateUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "commentId"), dto.Body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toCommentResponseDTO(c))
+}
+
+func (cc *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	if err := cc.deleteUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "commentId")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toCommentResponseDTO(c *entity.Comment) response.CommentResponseDTO {
+	return response.CommentResponseDTO{
+		ID:        c.ID,
+		TodoID:    c.TodoID,
+		UserID:    c.UserID,
+		Body:      c.Body,
+		Edited:    c.Edited,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/event"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+const (
+	// heartbeatInterval keeps idle streams alive through proxies that drop quiet connections.
+	heartbeatInterval = 25 * time.Second
+	// maxStreamAge ends a stream after the access token's lifetime, so the
+	// reconnect goes back through AuthMiddleware and a revoked session stops
+	// receiving. It also picks up workspaces joined or left meanwhile.
+	maxStreamAge = 15 * time.Minute
+)
+
+type EventController struct {
+	bus             event.Bus
+	listWorkspaceUC workspace.ListUseCase
+}
+
+func NewEventController(bus event.Bus, lwUC workspace.ListUseCase) *EventController {
+	return &EventController{bus, lwUC}
+}
+
+// Stream sends the caller's todo, category and tag changes, and those made in
+// their workspaces, as Server-Sent Events. A client reconnecting with Last-Event-ID (or ?lastEventId=) first
+// gets the events it missed; if they are no longer kept, it gets a "reset"
+// event and should reload instead.
+func (ec *EventController) Stream(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	lastID := int64(0)
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("lastEventId")
+	}
+	if raw != "" {
+		var err error
+		if lastID, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			lastID = -1 // unknown position; Subscribe reports it as incomplete
+		}
+	}
+
+	memberships, err := ec.listWorkspaceUC.Execute(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	workspaceIDs := make([]string, len(memberships))
+	for i, m := range memberships {
+		workspaceIDs[i] = m.Workspace.ID
+	}
+
+	rc := http.NewResponseController(w)
+	// the stream outlives the server's WriteTimeout
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	sub, backlog, complete := ec.bus.Subscribe(userID, workspaceIDs, lastID)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if !complete {
+		io.WriteString(w, "event: reset\ndata: {}\n\n")
+	}
+	for _, e := range backlog {
+		if err := writeEvent(w, e); err != nil {
+			return
+		}
+	}
+	if _, err := io.WriteString(w, ": connected\n\n"); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	expired := time.NewTimer(maxStreamAge)
+	defer expired.Stop()
+
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-expired.C:
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				// fell too far behind; the client reconnects and replays from its last ID
+				return
+			}
+			err = writeEvent(w, e)
+		case <-heartbeat.C:
+			_, err = io.WriteString(w, ": ping\n\n")
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes e as one SSE message.
+func writeEvent(w io.Writer, e event.Event) error {
+	data, err := json.Marshal(response.EventDTO{
+		Resource: string(e.Resource),
+		Action:   string(e.Action),
+		ID:       e.EntityID,
+		Data:     eventData(e.Entity),
+		At:       e.At,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Name(), data)
+	return err
+}
+
+// eventData renders an event's record as the matching REST response.
+func eventData(v any) any {
+	switch v := v.(type) {
+	case *entity.Todo:
+		return toTodoResponseDTO(v)
+	case *entity.Category:
+		return toCategoryResponseDTO(v)
+	case *entity.Tag:
+		return toTagResponseDTO(v)
+	case *entity.Notification:
+		return toNotificationResponseDTO(v)
+	case *entity.Comment:
+		return toCommentResponseDTO(v)
+	case *entity.Attachment:
+		return toAttachmentResponseDTO(v)
+	}
+	return nil
+}
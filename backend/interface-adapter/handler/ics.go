@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ariangn/todo-fullstack/backend/application/calendar"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+)
+
+// iCalendar components a todo can be exported as. Calendar apps show VEVENTs;
+// task apps read VTODOs, which can also carry the todo's progress.
+const (
+	icsEvent = "VEVENT"
+	icsTodo  = "VTODO"
+)
+
+// icsRefresh is how often subscribed calendar apps are asked to reload.
+const icsRefresh = "PT1H"
+
+// renderICS writes feed as an RFC 5545 calendar with one component per todo.
+func renderICS(feed *calendar.Feed, component string) []byte {
+	var w icsWriter
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//todo-fullstack//Todos//EN")
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:Todos")
+	w.line("X-WR-TIMEZONE:" + feed.Location.String())
+	w.line("REFRESH-INTERVAL;VALUE=DURATION:" + icsRefresh)
+	w.line("X-PUBLISHED-TTL:" + icsRefresh)
+	for _, t := range feed.Todos {
+		writeICSTodo(&w, feed, t, component)
+	}
+	w.line("END:VCALENDAR")
+	return w.buf.Bytes()
+}
+
+func writeICSTodo(w *icsWriter, feed *calendar.Feed, t *entity.Todo, component string) {
+	completed := t.Status == entity.StatusCompleted
+
+	w.line("BEGIN:" + component)
+	w.line("UID:" + t.ID)
+	// DTSTAMP follows the todo rather than the request, so an unchanged feed
+	// renders byte for byte the same and keeps its ETag
+	w.line("DTSTAMP:" + icsTime(t.UpdatedAt))
+	w.line("CREATED:" + icsTime(t.CreatedAt))
+	w.line("LAST-MODIFIED:" + icsTime(t.UpdatedAt))
+
+	summary := t.Title
+	if component == icsEvent && completed {
+		summary = "✓ " + summary
+	}
+	w.line("SUMMARY:" + icsText(summary))
+	if p, ok := icsPriorities[t.Priority]; ok {
+		w.line("PRIORITY:" + p)
+	}
+
+	var description []string
+	if t.Body != nil && *t.Body != "" {
+		description = append(description, *t.Body)
+	}
+
+	if component == icsTodo {
+		w.line("DUE:" + icsTime(*t.DueDate))
+		w.line("STATUS:" + icsTodoStatus(t.Status))
+		if completed {
+			w.line("PERCENT-COMPLETE:100")
+		}
+		if t.CompletedAt != nil {
+			w.line("COMPLETED:" + icsTime(*t.CompletedAt))
+		}
+	} else {
+		// no DTEND: an event starting at a date-time lasts no time at all
+		w.line("DTSTART:" + icsTime(*t.DueDate))
+		w.line("STATUS:CONFIRMED")
+		w.line("TRANSP:TRANSPARENT")
+		// VEVENT has no completion properties, so the description says it
+		if t.CompletedAt != nil {
+			description = append(description, "Completed "+t.CompletedAt.In(feed.Location).Format("Mon, 2 Jan 2006 15:04 MST"))
+		}
+	}
+	if len(description) > 0 {
+		w.line("DESCRIPTION:" + icsText(strings.Join(description, "\n\n")))
+	}
+
+	var categories []string
+	if t.CategoryID != nil {
+		if name, ok := feed.Categories[*t.CategoryID]; ok {
+			categories = append(categories, icsText(name))
+		}
+	}
+	for _, id := range t.TagIDs {
+		if name, ok := feed.Tags[id]; ok {
+			categories = append(categories, icsText(name))
+		}
+	}
+	if len(categories) > 0 {
+		w.line("CATEGORIES:" + strings.Join(categories, ","))
+	}
+
+	// the rule lives on the open occurrence only, so the calendar app expands
+	// the upcoming ones and completed occurrences stay single
+	if t.Recurrence != nil {
+		w.line("RRULE:" + t.Recurrence.String())
+	}
+	w.line("END:" + component)
+}
+
+// icsPriorities follows RFC 5545, where 1 is the highest priority; HIGH, MEDIUM
+// and LOW land on the values of its three-level scheme. NONE is left out.
+var icsPriorities = map[entity.Priority]string{
+	entity.PriorityUrgent: "1",
+	entity.PriorityHigh:   "2",
+	entity.PriorityMedium: "5",
+	entity.PriorityLow:    "9",
+}
+
+func icsTodoStatus(s entity.Status) string {
+	switch s {
+	case entity.StatusCompleted:
+		return "COMPLETED"
+	case entity.StatusInProgress:
+		return "IN-PROCESS"
+	}
+	return "NEEDS-ACTION"
+}
+
+// icsTime formats t as a UTC DATE-TIME.
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+var icsTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", "",
+)
+
+// icsText escapes s as a TEXT value.
+func icsText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+// icsWriter emits content lines with CRLF endings, folding any line longer
+// than 75 octets without splitting a UTF-8 sequence.
+type icsWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *icsWriter) line(s string) {
+	const limit = 75
+	for first := true; ; first = false {
+		room := limit
+		if !first {
+			w.buf.WriteByte(' ')
+			room-- // the leading space counts towards the limit
+		}
+		if len(s) <= room {
+			w.buf.WriteString(s)
+			break
+		}
+		cut := room
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n")
+		s = s[cut:]
+	}
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ariangn/todo-fullstack/backend/application/notification"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// NotificationController serves the in-app reminder inbox, /api/notifications.
+type NotificationController struct {
+	listUC     notification.ListUseCase
+	markReadUC notification.MarkReadUseCase
+}
+
+func NewNotificationController(lUC notification.ListUseCase, mUC notification.MarkReadUseCase) *NotificationController {
+	return &NotificationController{lUC, mUC}
+}
+
+func (nc *NotificationController) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	notifications, err := nc.listUC.Execute(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respList := []response.NotificationResponseDTO{}
+	for _, n := range notifications {
+		respList = append(respList, toNotificationResponseDTO(n))
+	}
+	writeJSON(w, http.StatusOK, respList)
+}
+
+func (nc *NotificationController) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	n, err := nc.markReadUC.Execute(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toNotificationResponseDTO(n))
+}
+
+func toNotificationResponseDTO(n *entity.Notification) response.NotificationResponseDTO {
+	return response.NotificationResponseDTO{
+		ID:        n.ID,
+		TodoID:    n.TodoID,
+		Title:     n.Title,
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+		ReadAt:    n.ReadAt,
+	}
+}
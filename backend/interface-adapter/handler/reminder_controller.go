@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ariangn/todo-fullstack/backend/application/reminder"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/request"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// ReminderController serves /api/todos/{id}/reminders.
+type ReminderController struct {
+	createUC reminder.CreateUseCase
+	listUC   reminder.ListUseCase
+	deleteUC reminder.DeleteUseCase
+}
+
+func NewReminderController(
+	cUC reminder.CreateUseCase,
+	lUC reminder.ListUseCase,
+	dUC reminder.DeleteUseCase,
+) *ReminderController {
+	return &ReminderController{cUC, lUC, dUC}
+}
+
+func (rc *ReminderController) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.CreateReminderDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	created, err := rc.createUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), reminder.CreateRequest{
+		RemindAt:      dto.RemindAt,
+		OffsetMinutes: dto.OffsetMinutes,
+		Channel:       entity.ReminderChannel(dto.Channel),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toReminderResponseDTO(created))
+}
+
+func (rc *ReminderController) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	reminders, err := rc.listUC.Execute(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respList := []response.ReminderResponseDTO{}
+	for _, rem := range reminders {
+		respList = append(respList, toReminderResponseDTO(rem))
+	}
+	writeJSON(w, http.StatusOK, respList)
+}
+
+func (rc *ReminderController) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	if err := rc.deleteUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "reminderId")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toReminderResponseDTO(rem *entity.Reminder) response.ReminderResponseDTO {
+	return response.ReminderResponseDTO{
+		ID:            rem.ID,
+		TodoID:        rem.TodoID,
+		RemindAt:      rem.RemindAt,
+		OffsetMinutes: rem.OffsetMinutes,
+		Channel:       string(rem.Channel),
+		FireAt:        rem.FireAt,
+		Status:        string(rem.Status),
+		Attempts:      rem.Attempts,
+		LastError:     rem.LastError,
+		SentAt:        rem.SentAt,
+		CreatedAt:     rem.CreatedAt,
+	}
+}
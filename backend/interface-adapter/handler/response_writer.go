@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+)
+
+const codeInternal = "internal"
+
+var statusByKind = map[apperror.Kind]int{
+	apperror.KindValidation:   http.StatusBadRequest,
+	apperror.KindNotFound:     http.StatusNotFound,
+	apperror.KindForbidden:    http.StatusForbidden,
+	apperror.KindConflict:     http.StatusConflict,
+	apperror.KindUnauthorized: http.StatusUnauthorized,
+
+	apperror.KindPreconditionFailed: http.StatusPreconditionFailed,
+}
+
+// writeJSON sends v as a JSON body with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to encode response: %v", err)
+	}
+}
+
+// writeError sends err in the standard error envelope.
+func writeError(w http.ResponseWriter, err error) {
+	status, detail := toErrorDetail(err)
+	writeJSON(w, status, response.ErrorResponseDTO{Error: detail})
+}
+
+// toErrorDetail maps err to its HTTP status and envelope body. Errors that are
+// not an *apperror.Error are logged and reported as a generic 500 so internals don't leak.
+func toErrorDetail(err error) (int, response.ErrorDetailDTO) {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		log.Printf("internal error: %v", err)
+		return http.StatusInternalServerError, response.ErrorDetailDTO{Code: codeInternal, Message: "internal server error"}
+	}
+
+	status, ok := statusByKind[appErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	return status, response.ErrorDetailDTO{
+		Code:    string(appErr.Kind),
+		Message: appErr.Message,
+		Fields:  appErr.Fields,
+		IDs:     appErr.IDs,
+	}
+}
+
+// errUnauthorized is returned when a protected handler runs without a user in context.
+var errUnauthorized = apperror.Unauthorized("unauthorized")
+
+// errInvalidPayload is returned for request bodies that are not valid JSON for the DTO.
+func errInvalidPayload(err error) error {
+	return apperror.Validation("invalid request payload: " + err.Error())
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ariangn/todo-fullstack/backend/application/session"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// SessionController serves /api/auth/refresh and /api/auth/sessions.
+type SessionController struct {
+	refreshUC   session.RefreshUseCase
+	listUC      session.ListUseCase
+	revokeUC    session.RevokeUseCase
+	revokeAllUC session.RevokeAllUseCase
+}
+
+func NewSessionController(
+	rfUC session.RefreshUseCase,
+	lUC session.ListUseCase,
+	rUC session.RevokeUseCase,
+	raUC session.RevokeAllUseCase,
+) *SessionController {
+	return &SessionController{rfUC, lUC, rUC, raUC}
+}
+
+// Refresh rotates the refresh cookie and issues a new access cookie. It is a
+// public route: the access token is usually already expired when it is called.
+func (sc *SessionController) Refresh(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(refreshTokenCookie)
+	if err != nil || cookie.Value == "" {
+		writeError(w, session.ErrInvalidRefreshToken)
+		return
+	}
+
+	tokens, err := sc.refreshUC.Execute(r.Context(), cookie.Value)
+	if err != nil {
+		clearSessionCookies(w)
+		writeError(w, err)
+		return
+	}
+
+	setSessionCookies(w, tokens)
+	writeJSON(w, http.StatusOK, map[string]string{"message": "session refreshed"})
+}
+
+func (sc *SessionController) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+	currentID, _ := middleware.GetSessionIDFromContext(r.Context())
+
+	sessions, err := sc.listUC.Execute(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respList := []response.SessionResponseDTO{}
+	for _, s := range sessions {
+		respList = append(respList, response.SessionResponseDTO{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			Current:    s.ID == currentID,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, respList)
+}
+
+// Revoke signs out one session; revoking the current one also clears its cookies.
+func (sc *SessionController) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if err := sc.revokeUC.Execute(r.Context(), userID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+	if currentID, _ := middleware.GetSessionIDFromContext(r.Context()); currentID == id {
+		clearSessionCookies(w)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAll signs out every session of the user, including the current one.
+func (sc *SessionController) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	if err := sc.revokeAllUC.Execute(r.Context(), userID); err != nil {
+		writeError(w, err)
+		return
+	}
+	clearSessionCookies(w)
+	w.WriteHeader(http.StatusNoContent)
+}
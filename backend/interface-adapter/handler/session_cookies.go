@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ariangn/todo-fullstack/backend/application/session"
+)
+
+const (
+	accessTokenCookie  = "token"
+	refreshTokenCookie = "refresh_token"
+)
+
+// setSessionCookies stores both tokens in HTTP-only cookies that live as long
+// as the tokens themselves.
+func setSessionCookies(w http.ResponseWriter, tokens *session.Tokens) {
+	http.SetCookie(w, sessionCookie(accessTokenCookie, tokens.AccessToken, int(session.AccessTokenTTL.Seconds())))
+	http.SetCookie(w, sessionCookie(refreshTokenCookie, tokens.RefreshToken, int(session.RefreshTokenTTL.Seconds())))
+}
+
+// clearSessionCookies expires both cookies by setting MaxAge to -1.
+func clearSessionCookies(w http.ResponseWriter) {
+	http.SetCookie(w, sessionCookie(accessTokenCookie, "", -1))
+	http.SetCookie(w, sessionCookie(refreshTokenCookie, "", -1))
+}
+
+func sessionCookie(name, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true, // HTTPS only in prod
+		SameSite: http.SameSiteNoneMode,
+		MaxAge:   maxAge,
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/ariangn/todo-fullstack/backend/application/tag"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/request"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
@@ -33,14 +35,14 @@ func NewTagController(
 func (tc *TagController) Create(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok || userID == "" {
-		http.Error(w, "unauthorized: user ID not found", http.StatusUnauthorized)
+		writeError(w, errUnauthorized)
 		return
 	}
 
 	// read and log body safely
 	bodyBytes, err := io.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, "could not read request body", http.StatusBadRequest)
+		writeError(w, apperror.Validation("could not read request body"))
 		return
 	}
 	defer r.Body.Close()
@@ -50,58 +52,94 @@ func (tc *TagController) Create(w http.ResponseWriter, r *http.Request) {
 
 	var dto request.CreateTagDTO
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
-		http.Error(w, "invalid request payload: "+err.Error(), http.StatusBadRequest)
+		writeError(w, errInvalidPayload(err))
 		return
 	}
 
-	tagEntity, err := tc.createUC.Execute(r.Context(), userID, dto.Name)
+	tagEntity, err := tc.createUC.Execute(r.Context(), userID, dto.WorkspaceID, dto.Name)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
-	respDTO := response.TagResponseDTO{
-		ID:        tagEntity.ID,
-		Name:      tagEntity.Name,
-		UserID:    tagEntity.UserID,
-		CreatedAt: tagEntity.CreatedAt,
-		UpdatedAt: tagEntity.UpdatedAt,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(respDTO)
+	respDTO := toTagResponseDTO(tagEntity)
+	writeJSON(w, http.StatusCreated, respDTO)
 }
 
 func (tc *TagController) List(w http.ResponseWriter, r *http.Request) {
-	userID, _ := middleware.GetUserIDFromContext(r.Context())
-	tags, err := tc.listUC.Execute(r.Context(), userID)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+	tags, err := tc.listUC.Execute(r.Context(), userID, workspaceParam(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
+		return
+	}
+	parts := []string{}
+	for _, t := range tags {
+		parts = append(parts, memberTag(t.ID, t.Version))
+	}
+	if notModified(w, r, listETag(parts)) {
 		return
 	}
-	var respList []response.TagResponseDTO
+	respList := []response.TagResponseDTO{}
 	for _, t := range tags {
-		respList = append(respList, response.TagResponseDTO{
-			ID:        t.ID,
-			Name:      t.Name,
-			UserID:    t.UserID,
-			CreatedAt: t.CreatedAt,
-			UpdatedAt: t.UpdatedAt,
-		})
+		respList = append(respList, toTagResponseDTO(t))
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(respList)
+	writeJSON(w, http.StatusOK, respList)
 }
 
 func (tc *TagController) Update(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+
+	var dto request.UpdateTagDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	tagEntity, err := tc.updateUC.Execute(r.Context(), userID, id, getString(dto.Name), ifMatchVersions(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respDTO := toTagResponseDTO(tagEntity)
+	w.Header().Set("ETag", versionETag(tagEntity.Version))
+	writeJSON(w, http.StatusOK, respDTO)
 }
 
 func (tc *TagController) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
 	id := chi.URLParam(r, "id")
-	if err := tc.deleteUC.Execute(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := tc.deleteUC.Execute(r.Context(), userID, id, ifMatchVersions(r)); err != nil {
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func toTagResponseDTO(t *entity.Tag) response.TagResponseDTO {
+	return response.TagResponseDTO{
+		ID:          t.ID,
+		Name:        t.Name,
+		UserID:      t.UserID,
+		WorkspaceID: t.WorkspaceID,
+		Version:     t.Version,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
@@ -3,16 +3,22 @@ package handler
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/ariangn/todo-fullstack/backend/application/todo"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
 	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 	"github.com/ariangn/todo-fullstack/backend/domain/valueobject"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/request"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
@@ -27,6 +33,14 @@ type TodoController struct {
 	toggleStatus todo.ToggleStatusUseCase
 	deleteUC     todo.DeleteUseCase
 	duplicateUC  todo.DuplicateUseCase
+	searchUC     todo.SearchUseCase
+	bulkUC       todo.BulkUseCase
+	listTrashUC  todo.ListTrashUseCase
+	restoreUC    todo.RestoreUseCase
+	historyUC    todo.HistoryUseCase
+	moveUC       todo.MoveUseCase
+	assignUC     todo.AssignUseCase
+	unassignUC   todo.UnassignUseCase
 }
 
 func NewTodoController(
@@ -37,6 +51,14 @@ func NewTodoController(
 	tUC todo.ToggleStatusUseCase,
 	dUC todo.DeleteUseCase,
 	dupUC todo.DuplicateUseCase,
+	sUC todo.SearchUseCase,
+	bUC todo.BulkUseCase,
+	ltUC todo.ListTrashUseCase,
+	rUC todo.RestoreUseCase,
+	hUC todo.HistoryUseCase,
+	mUC todo.MoveUseCase,
+	aUC todo.AssignUseCase,
+	unUC todo.UnassignUseCase,
 ) *TodoController {
 	return &TodoController{
 		createUC:     cUC,
@@ -46,6 +68,14 @@ func NewTodoController(
 		toggleStatus: tUC,
 		deleteUC:     dUC,
 		duplicateUC:  dupUC,
+		searchUC:     sUC,
+		bulkUC:       bUC,
+		listTrashUC:  ltUC,
+		restoreUC:    rUC,
+		historyUC:    hUC,
+		moveUC:       mUC,
+		assignUC:     aUC,
+		unassignUC:   unUC,
 	}
 }
 
@@ -53,7 +83,7 @@ func (tc *TodoController) Create(w http.ResponseWriter, r *http.Request) {
 
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, errUnauthorized)
 		return
 	}
 
@@ -63,7 +93,7 @@ func (tc *TodoController) Create(w http.ResponseWriter, r *http.Request) {
 	var dto request.CreateTodoDTO
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
 		log.Println("❌ Failed to decode todo DTO:", err)
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		writeError(w, errInvalidPayload(err))
 		return
 	}
 
@@ -74,135 +104,173 @@ func (tc *TodoController) Create(w http.ResponseWriter, r *http.Request) {
 	if dto.DueDate != nil {
 		dvo, err := valueobject.NewDueDateVO(*dto.DueDate)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, err)
 			return
 		}
 		dueDateVO = &dvo
 	}
 
+	recurrence, err := toRecurrence(dto.Recurrence)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	todoEntity, err := tc.createUC.Execute(
 		r.Context(),
 		userID,
 		dto.Title,
 		dto.Body,
 		entity.Status(dto.Status),
+		entity.Priority(dto.Priority),
 		dueDateVO,
 		dto.CategoryID,
 		dto.TagIDs,
+		recurrence,
+		dto.AutoComplete,
+		dto.WorkspaceID,
 	)
 	if err != nil {
 		log.Printf("❌ Usecase CreateTodo error: %v", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	respDTO := response.TodoResponseDTO{
-		ID:          todoEntity.ID,
-		Title:       todoEntity.Title,
-		Body:        todoEntity.Body,
-		Status:      string(todoEntity.Status),
-		DueDate:     todoEntity.DueDate,
-		CompletedAt: todoEntity.CompletedAt,
-		UserID:      todoEntity.UserID,
-		CategoryID:  todoEntity.CategoryID,
-		TagIDs:      todoEntity.TagIDs,
-		CreatedAt:   todoEntity.CreatedAt,
-		UpdatedAt:   todoEntity.UpdatedAt,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(respDTO)
+		writeError(w, err)
+		return
+	}
+
+	respDTO := toTodoResponseDTO(todoEntity)
+	writeJSON(w, http.StatusCreated, respDTO)
 }
 
 func (tc *TodoController) List(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, errUnauthorized)
 		return
 	}
 
-	todos, err := tc.listUC.Execute(r.Context(), userID)
+	query, err := parseTodoFilters(request.NewTodoFiltersDTO(r.URL.Query()))
 	if err != nil {
-		log.Printf("error in listUC.Execute: %v\n", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-	var respList []response.TodoResponseDTO
-	for _, t := range todos {
-		respList = append(respList, response.TodoResponseDTO{
-			ID:          t.ID,
-			Title:       t.Title,
-			Body:        t.Body,
-			Status:      string(t.Status),
-			DueDate:     t.DueDate,
-			CompletedAt: t.CompletedAt,
-			UserID:      t.UserID,
-			CategoryID:  t.CategoryID,
-			TagIDs:      t.TagIDs,
-			CreatedAt:   t.CreatedAt,
-			UpdatedAt:   t.UpdatedAt,
+	if query.AssigneeID != nil && *query.AssigneeID == "me" {
+		query.AssigneeID = &userID
+	}
+
+	page, err := tc.listUC.Execute(r.Context(), userID, query)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respDTO := response.TodoListResponseDTO{
+		Items: make([]response.TodoResponseDTO, 0, len(page.Todos)),
+		Total: page.Total,
+	}
+	for _, t := range page.Todos {
+		respDTO.Items = append(respDTO.Items, toTodoResponseDTO(t))
+	}
+	if page.NextOffset != nil {
+		cursor := encodeCursor(*page.NextOffset)
+		respDTO.NextCursor = &cursor
+	}
+
+	// the page is fully described by its members' versions and assignees, so
+	// a client that polls with If-None-Match gets a bodyless 304 until
+	// something changes
+	parts := make([]string, 0, len(page.Todos)+2)
+	for _, t := range page.Todos {
+		parts = append(parts, todoTag(t))
+	}
+	parts = append(parts, strconv.Itoa(page.Total))
+	if respDTO.NextCursor != nil {
+		parts = append(parts, *respDTO.NextCursor)
+	}
+	if notModified(w, r, listETag(parts)) {
+		return
+	}
+	writeJSON(w, http.StatusOK, respDTO)
+}
+
+func (tc *TodoController) Search(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	search, err := parseTodoSearch(request.NewTodoSearchDTO(r.URL.Query()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := tc.searchUC.Execute(r.Context(), userID, search)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respDTO := response.TodoSearchResponseDTO{
+		Items: make([]response.TodoSearchHitDTO, 0, len(page.Hits)),
+		Total: page.Total,
+	}
+	for _, hit := range page.Hits {
+		respDTO.Items = append(respDTO.Items, response.TodoSearchHitDTO{
+			TodoResponseDTO: toTodoResponseDTO(hit.Todo),
+			Rank:            hit.Rank,
+			TitleSnippet:    toTextSegmentDTOs(hit.TitleSnippet),
+			BodySnippet:     toTextSegmentDTOs(hit.BodySnippet),
 		})
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(respList)
+	if page.NextOffset != nil {
+		cursor := encodeCursor(*page.NextOffset)
+		respDTO.NextCursor = &cursor
+	}
+	writeJSON(w, http.StatusOK, respDTO)
 }
 
 func (tc *TodoController) GetByID(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, errUnauthorized)
 		return
 	}
 
 	id := chi.URLParam(r, "id")
-	todoEntity, err := tc.findByIDUC.Execute(r.Context(), id)
+	todoEntity, err := tc.findByIDUC.Execute(r.Context(), userID, id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-	if todoEntity == nil || todoEntity.UserID != userID {
-		http.Error(w, "todo not found", http.StatusNotFound)
+	if notModified(w, r, todoETag(todoEntity)) {
 		return
 	}
 
-	respDTO := response.TodoResponseDTO{
-		ID:          todoEntity.ID,
-		Title:       todoEntity.Title,
-		Body:        todoEntity.Body,
-		Status:      string(todoEntity.Status),
-		DueDate:     todoEntity.DueDate,
-		CompletedAt: todoEntity.CompletedAt,
-		UserID:      todoEntity.UserID,
-		CategoryID:  todoEntity.CategoryID,
-		TagIDs:      todoEntity.TagIDs,
-		CreatedAt:   todoEntity.CreatedAt,
-		UpdatedAt:   todoEntity.UpdatedAt,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(respDTO)
+	respDTO := toTodoResponseDTO(todoEntity)
+	writeJSON(w, http.StatusOK, respDTO)
 }
 
 func (tc *TodoController) Update(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, errUnauthorized)
 		return
 	}
 
 	id := chi.URLParam(r, "id")
-	existing, err := tc.findByIDUC.Execute(r.Context(), id)
+	existing, err := tc.findByIDUC.Execute(r.Context(), userID, id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-	if existing == nil || existing.UserID != userID {
-		http.Error(w, "todo not found", http.StatusNotFound)
+	if !repository.VersionMatches(ifMatchVersions(r), existing.Version) {
+		writeError(w, repository.ErrStaleVersion)
 		return
 	}
 
 	var dto request.UpdateTodoDTO
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
-		http.Error(w, "invalid request payload", http.StatusBadRequest)
+		writeError(w, errInvalidPayload(err))
 		return
 	}
 
@@ -222,11 +290,14 @@ func (tc *TodoController) Update(w http.ResponseWriter, r *http.Request) {
 			existing.CompletedAt = nil
 		}
 	}
+	if dto.Priority != nil {
+		existing.Priority = entity.Priority(*dto.Priority)
+	}
 	if dto.DueDate != nil {
 		// dto.DueDate is *time.Time; use its value directly
 		dvo, err := valueobject.NewDueDateVO(*dto.DueDate)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, err)
 			return
 		}
 		t := dvo.Time()
@@ -238,46 +309,48 @@ func (tc *TodoController) Update(w http.ResponseWriter, r *http.Request) {
 	if dto.TagIDs != nil {
 		existing.TagIDs = *dto.TagIDs
 	}
+	if dto.AutoComplete != nil {
+		existing.AutoComplete = *dto.AutoComplete
+	}
+	if len(dto.Recurrence) > 0 {
+		var rec *request.RecurrenceDTO
+		if err := json.Unmarshal(dto.Recurrence, &rec); err != nil {
+			writeError(w, errInvalidPayload(err))
+			return
+		}
+		if existing.Recurrence, err = toRecurrence(rec); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
 	existing.UpdatedAt = time.Now().UTC()
 
-	updated, err := tc.updateUC.Execute(r.Context(), existing)
+	updated, next, err := tc.updateUC.Execute(r.Context(), userID, existing)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	respDTO := response.TodoResponseDTO{
-		ID:          updated.ID,
-		Title:       updated.Title,
-		Body:        updated.Body,
-		Status:      string(updated.Status),
-		DueDate:     updated.DueDate,
-		CompletedAt: updated.CompletedAt,
-		UserID:      updated.UserID,
-		CategoryID:  updated.CategoryID,
-		TagIDs:      updated.TagIDs,
-		CreatedAt:   updated.CreatedAt,
-		UpdatedAt:   updated.UpdatedAt,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(respDTO)
+	w.Header().Set("ETag", todoETag(updated))
+	respDTO := toTodoStatusResponseDTO(updated, next)
+	writeJSON(w, http.StatusOK, respDTO)
 }
 
 func (tc *TodoController) ToggleStatus(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, errUnauthorized)
 		return
 	}
 
 	id := chi.URLParam(r, "id")
-	existing, err := tc.findByIDUC.Execute(r.Context(), id)
+	existing, err := tc.findByIDUC.Execute(r.Context(), userID, id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-	if existing == nil || existing.UserID != userID {
-		http.Error(w, "todo not found", http.StatusNotFound)
+	if !repository.VersionMatches(ifMatchVersions(r), existing.Version) {
+		writeError(w, repository.ErrStaleVersion)
 		return
 	}
 
@@ -286,56 +359,129 @@ func (tc *TodoController) ToggleStatus(w http.ResponseWriter, r *http.Request) {
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		log.Printf("Failed to decode body: %v", err)
-		http.Error(w, "invalid request payload", http.StatusBadRequest)
+		writeError(w, errInvalidPayload(err))
 		return
 	}
 
 	newStatus := entity.Status(body.Status)
 
-	updated, err := tc.toggleStatus.Execute(r.Context(), id, newStatus)
+	updated, next, err := tc.toggleStatus.Execute(r.Context(), userID, id, newStatus)
 	if err != nil {
 		log.Printf("Failed to toggle status: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", todoETag(updated))
+	respDTO := toTodoStatusResponseDTO(updated, next)
+	writeJSON(w, http.StatusOK, respDTO)
+}
+
+// Move changes a todo's status and its position within the status column in
+// one write, for dragging cards on the board.
+func (tc *TodoController) Move(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
 		return
 	}
 
-	respDTO := response.TodoResponseDTO{
-		ID:          updated.ID,
-		Title:       updated.Title,
-		Body:        updated.Body,
-		Status:      string(updated.Status),
-		DueDate:     updated.DueDate,
-		CompletedAt: updated.CompletedAt,
-		UserID:      updated.UserID,
-		CategoryID:  updated.CategoryID,
-		TagIDs:      updated.TagIDs,
-		CreatedAt:   updated.CreatedAt,
-		UpdatedAt:   updated.UpdatedAt,
+	id := chi.URLParam(r, "id")
+	existing, err := tc.findByIDUC.Execute(r.Context(), userID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !repository.VersionMatches(ifMatchVersions(r), existing.Version) {
+		writeError(w, repository.ErrStaleVersion)
+		return
+	}
+
+	var dto request.MoveTodoDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(respDTO)
+
+	updated, next, err := tc.moveUC.Execute(r.Context(), userID, id, todo.MoveRequest{
+		Status:   entity.Status(dto.Status),
+		BeforeID: dto.BeforeID,
+		AfterID:  dto.AfterID,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", todoETag(updated))
+	respDTO := toTodoStatusResponseDTO(updated, next)
+	writeJSON(w, http.StatusOK, respDTO)
+}
+
+// Assign adds an assignee to a todo and returns the todo.
+func (tc *TodoController) Assign(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.AssignTodoDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	updated, err := tc.assignUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), dto.UserID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", todoETag(updated))
+	respDTO := toTodoResponseDTO(updated)
+	writeJSON(w, http.StatusOK, respDTO)
+}
+
+// Unassign takes an assignee off a todo and returns the todo.
+func (tc *TodoController) Unassign(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	updated, err := tc.unassignUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "userId"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", todoETag(updated))
+	respDTO := toTodoResponseDTO(updated)
+	writeJSON(w, http.StatusOK, respDTO)
 }
 
 func (tc *TodoController) Delete(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, errUnauthorized)
 		return
 	}
 
 	id := chi.URLParam(r, "id")
-	existing, err := tc.findByIDUC.Execute(r.Context(), id)
+	existing, err := tc.findByIDUC.Execute(r.Context(), userID, id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-	if existing == nil || existing.UserID != userID {
-		http.Error(w, "todo not found", http.StatusNotFound)
+	if !repository.VersionMatches(ifMatchVersions(r), existing.Version) {
+		writeError(w, repository.ErrStaleVersion)
 		return
 	}
 
-	if err := tc.deleteUC.Execute(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := tc.deleteUC.Execute(r.Context(), userID, id); err != nil {
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -344,40 +490,364 @@ func (tc *TodoController) Delete(w http.ResponseWriter, r *http.Request) {
 func (tc *TodoController) Duplicate(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, errUnauthorized)
 		return
 	}
 
-	id := chi.URLParam(r, "id")
-	existing, err := tc.findByIDUC.Execute(r.Context(), id)
+	dup, err := tc.duplicateUC.Execute(r.Context(), userID, chi.URLParam(r, "id"))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
-	if existing == nil || existing.UserID != userID {
-		http.Error(w, "todo not found", http.StatusNotFound)
+
+	respDTO := toTodoResponseDTO(dup)
+	writeJSON(w, http.StatusOK, respDTO)
+}
+
+// Bulk applies one action to many todos and reports an outcome per todo.
+// The request as a whole fails only when its action or payload is invalid.
+func (tc *TodoController) Bulk(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
 		return
 	}
 
-	dup, err := tc.duplicateUC.Execute(r.Context(), id)
+	var dto request.BulkTodosDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	results, err := tc.bulkUC.Execute(r.Context(), userID, todo.BulkRequest{
+		IDs:         dto.IDs,
+		WorkspaceID: dto.WorkspaceID,
+		Action:      todo.BulkAction(dto.Action),
+		Status:      entity.Status(dto.Status),
+		CategoryID:  dto.CategoryID,
+		TagIDs:      dto.TagIDs,
+		DueDate:     dto.DueDate,
+	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	respDTO := response.TodoResponseDTO{
-		ID:          dup.ID,
-		Title:       dup.Title,
-		Body:        dup.Body,
-		Status:      string(dup.Status),
-		DueDate:     dup.DueDate,
-		CompletedAt: dup.CompletedAt,
-		UserID:      dup.UserID,
-		CategoryID:  dup.CategoryID,
-		TagIDs:      dup.TagIDs,
-		CreatedAt:   dup.CreatedAt,
-		UpdatedAt:   dup.UpdatedAt,
+	resp := response.BulkTodosResponseDTO{Results: []response.BulkTodoResultDTO{}}
+	for _, res := range results {
+		item := response.BulkTodoResultDTO{ID: res.ID, OK: res.Err == nil}
+		if res.Err != nil {
+			_, detail := toErrorDetail(res.Err)
+			item.Error = &detail
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+		if res.Todo != nil {
+			todoDTO := toTodoResponseDTO(res.Todo)
+			item.Todo = &todoDTO
+		}
+		if res.Next != nil {
+			nextDTO := toTodoResponseDTO(res.Next)
+			item.NextOccurrence = &nextDTO
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Trash lists the caller's deleted todos, or with ?workspaceId= a
+// workspace's, which can still be restored.
+func (tc *TodoController) Trash(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	todos, err := tc.listTrashUC.Execute(r.Context(), userID, workspaceParam(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	items := make([]response.TodoResponseDTO, 0, len(todos))
+	for _, t := range todos {
+		items = append(items, toTodoResponseDTO(t))
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func (tc *TodoController) Restore(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	restored, err := tc.restoreUC.Execute(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("ETag", todoETag(restored))
+	writeJSON(w, http.StatusOK, toTodoResponseDTO(restored))
+}
+
+// History pages through the todo's audit log, newest first.
+func (tc *TodoController) History(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	dto := request.NewActivityFiltersDTO(r.URL.Query())
+	limit, offset, err := parsePageParams(dto.Limit, dto.Cursor)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	page, err := tc.historyUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toActivityListResponseDTO(page))
+}
+
+func toTodoResponseDTO(t *entity.Todo) response.TodoResponseDTO {
+	return response.TodoResponseDTO{
+		ID:           t.ID,
+		Title:        t.Title,
+		Body:         t.Body,
+		Status:       string(t.Status),
+		Priority:     string(t.Priority),
+		Rank:         t.Rank,
+		DueDate:      t.DueDate,
+		CompletedAt:  t.CompletedAt,
+		UserID:       t.UserID,
+		WorkspaceID:  t.WorkspaceID,
+		CategoryID:   t.CategoryID,
+		TagIDs:       t.TagIDs,
+		Assignees:    toAssigneeResponseDTOs(t.Assignees),
+		Recurrence:   toRecurrenceResponseDTO(t.Recurrence),
+		AutoComplete: t.AutoComplete,
+		Progress: response.ChecklistProgressDTO{
+			Done:  t.ItemsDone,
+			Total: t.ItemsTotal,
+		},
+		Version:   t.Version,
+		DeletedAt: t.DeletedAt,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func toAssigneeResponseDTOs(assignees []entity.Assignee) []response.AssigneeResponseDTO {
+	dtos := make([]response.AssigneeResponseDTO, 0, len(assignees))
+	for _, a := range assignees {
+		dtos = append(dtos, response.AssigneeResponseDTO{UserID: a.UserID, Name: a.Name, AvatarURL: a.AvatarURL})
+	}
+	return dtos
+}
+
+func toTodoStatusResponseDTO(updated, next *entity.Todo) response.TodoStatusResponseDTO {
+	resp := response.TodoStatusResponseDTO{TodoResponseDTO: toTodoResponseDTO(updated)}
+	if next != nil {
+		nextDTO := toTodoResponseDTO(next)
+		resp.NextOccurrence = &nextDTO
+	}
+	return resp
+}
+
+func toRecurrenceResponseDTO(r *entity.Recurrence) *response.RecurrenceResponseDTO {
+	if r == nil {
+		return nil
+	}
+	dto := &response.RecurrenceResponseDTO{
+		Frequency: string(r.Frequency),
+		Interval:  r.Interval,
+		Until:     r.Until,
+		Count:     r.Count,
+	}
+	for _, d := range r.ByWeekday {
+		dto.ByWeekday = append(dto.ByWeekday, entity.WeekdayCode(d))
+	}
+	return dto
+}
+
+// toRecurrence converts the request rule; nil means "does not recur".
+// Rule semantics are checked later by Todo.SetRecurrence.
+func toRecurrence(dto *request.RecurrenceDTO) (*entity.Recurrence, error) {
+	if dto == nil {
+		return nil, nil
+	}
+	r := &entity.Recurrence{
+		Frequency: entity.Frequency(dto.Frequency),
+		Interval:  dto.Interval,
+		Until:     dto.Until,
+		Count:     dto.Count,
+	}
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+	for _, code := range dto.ByWeekday {
+		d, ok := entity.ParseWeekday(code)
+		if !ok {
+			return nil, apperror.InvalidField("recurrence", fmt.Errorf("unknown weekday %q, expected one of MO TU WE TH FR SA SU", code))
+		}
+		r.ByWeekday = append(r.ByWeekday, d)
+	}
+	return r, nil
+}
+
+// parseTodoFilters converts raw query-string filters into a repository query.
+// Semantic checks (valid status, sort field, page size) are left to the use case.
+func parseTodoFilters(dto request.TodoFiltersDTO) (repository.TodoQuery, error) {
+	var q repository.TodoQuery
+
+	if dto.Status != nil {
+		s := entity.Status(*dto.Status)
+		q.Status = &s
+	}
+	q.CategoryID = dto.CategoryID
+	q.TagID = dto.TagID
+	q.WorkspaceID = dto.WorkspaceID
+	q.AssigneeID = dto.Assignee
+
+	if dto.DueDateFrom != nil {
+		t, _, err := parseDateParam(*dto.DueDateFrom)
+		if err != nil {
+			return q, apperror.InvalidField("dueDateFrom", err)
+		}
+		q.DueDateFrom = &t
+	}
+	if dto.DueDateTo != nil {
+		t, dateOnly, err := parseDateParam(*dto.DueDateTo)
+		if err != nil {
+			return q, apperror.InvalidField("dueDateTo", err)
+		}
+		if dateOnly {
+			// a bare date includes the whole day
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		q.DueDateTo = &t
+	}
+
+	if dto.SortBy != nil {
+		q.SortBy = sortFieldsByParam[*dto.SortBy]
+		if q.SortBy == "" {
+			return q, todo.ErrInvalidSortField
+		}
+	}
+	if dto.Order != nil {
+		switch *dto.Order {
+		case "asc":
+		case "desc":
+			q.SortDesc = true
+		default:
+			return q, apperror.InvalidField("order", errors.New(`order must be "asc" or "desc"`))
+		}
+	}
+
+	if dto.Limit != nil {
+		n, err := strconv.Atoi(*dto.Limit)
+		if err != nil {
+			return q, todo.ErrInvalidPageSize
+		}
+		q.Limit = n
+	}
+	if dto.Cursor != nil {
+		offset, err := decodeCursor(*dto.Cursor)
+		if err != nil {
+			return q, apperror.InvalidField("cursor", errors.New("invalid cursor"))
+		}
+		q.Offset = offset
+	}
+	return q, nil
+}
+
+// parseTodoSearch converts the search query string; like parseTodoFilters it
+// leaves status and page-size checks to the use case.
+func parseTodoSearch(dto request.TodoSearchDTO) (repository.TodoSearch, error) {
+	var q repository.TodoSearch
+
+	query, err := valueobject.NewSearchQueryVO(dto.Query)
+	if err != nil {
+		return q, apperror.InvalidField("q", err)
+	}
+	q.Query = query
+	q.WorkspaceID = dto.WorkspaceID
+
+	if dto.Status != nil {
+		s := entity.Status(*dto.Status)
+		q.Status = &s
+	}
+	q.CategoryID = dto.CategoryID
+	q.TagID = dto.TagID
+
+	if dto.Limit != nil {
+		n, err := strconv.Atoi(*dto.Limit)
+		if err != nil {
+			return q, todo.ErrInvalidPageSize
+		}
+		q.Limit = n
+	}
+	if dto.Cursor != nil {
+		offset, err := decodeCursor(*dto.Cursor)
+		if err != nil {
+			return q, apperror.InvalidField("cursor", errors.New("invalid cursor"))
+		}
+		q.Offset = offset
+	}
+	return q, nil
+}
+
+func toTextSegmentDTOs(segs []repository.TextSegment) []response.TextSegmentDTO {
+	if segs == nil {
+		return nil
+	}
+	dtos := make([]response.TextSegmentDTO, 0, len(segs))
+	for _, seg := range segs {
+		dtos = append(dtos, response.TextSegmentDTO{Text: seg.Text, Match: seg.Match})
+	}
+	return dtos
+}
+
+var sortFieldsByParam = map[string]repository.TodoSortField{
+	"createdAt": repository.TodoSortCreatedAt,
+	"updatedAt": repository.TodoSortUpdatedAt,
+	"dueDate":   repository.TodoSortDueDate,
+	"title":     repository.TodoSortTitle,
+	"status":    repository.TodoSortStatus,
+	"priority":  repository.TodoSortPriority,
+	"rank":      repository.TodoSortRank,
+}
+
+// parseDateParam accepts either RFC3339 or YYYY-MM-DD (interpreted as UTC midnight).
+func parseDateParam(v string) (t time.Time, dateOnly bool, err error) {
+	if t, err = time.Parse(time.RFC3339, v); err == nil {
+		return t, false, nil
+	}
+	if t, err = time.Parse(time.DateOnly, v); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, errors.New("expected RFC3339 or YYYY-MM-DD")
+}
+
+// cursors are opaque to clients; today they wrap the offset of the next page
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid cursor")
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(respDTO)
+	return offset, nil
 }
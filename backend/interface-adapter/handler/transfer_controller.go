@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/application/transfer"
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// maxImportBytes caps the size of an uploaded import file.
+const maxImportBytes = 5 << 20
+
+// transferContentTypes is the media type each format is served as and
+// recognized by on upload, with its file extension.
+var transferContentTypes = map[transfer.Format]struct{ mediaType, ext string }{
+	transfer.FormatJSON:    {"application/json", "json"},
+	transfer.FormatCSV:     {"text/csv", "csv"},
+	transfer.FormatTodoTxt: {"text/plain", "txt"},
+}
+
+var errInvalidDryRun = apperror.InvalidField("dryRun", errors.New("dryRun must be true or false"))
+
+// TransferController serves /api/export and /api/import.
+type TransferController struct {
+	exportUC transfer.ExportUseCase
+	importUC transfer.ImportUseCase
+}
+
+func NewTransferController(eUC transfer.ExportUseCase, iUC transfer.ImportUseCase) *TransferController {
+	return &TransferController{eUC, iUC}
+}
+
+// Export downloads all of the caller's todos as ?format=json (the default),
+// csv or todotxt.
+func (tc *TransferController) Export(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	format := transfer.FormatJSON
+	if v := r.URL.Query().Get("format"); v != "" {
+		f, err := transfer.ParseFormat(v)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		format = f
+	}
+
+	doc, err := tc.exportUC.Execute(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ct := transferContentTypes[format]
+	contentType := ct.mediaType
+	if format != transfer.FormatJSON {
+		contentType += "; charset=utf-8"
+	}
+	filename := fmt.Sprintf("todos-%s.%s", time.Now().In(doc.Location).Format("20060102"), ct.ext)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+	if err := transfer.Encode(w, format, doc); err != nil {
+		// the status is already sent; the client sees a truncated file
+		log.Printf("export for user %s failed: %v", userID, err)
+	}
+}
+
+// Import reads a file uploaded as the request body. The format comes from
+// ?format=, or else from the Content-Type. With ?dryRun=true nothing is
+// written and the response reports what would have been created.
+func (tc *TransferController) Import(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	format, err := importFormat(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	dryRun := false
+	if v := r.URL.Query().Get("dryRun"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			writeError(w, errInvalidDryRun)
+			return
+		}
+	}
+
+	// a 5 MB file takes longer than the server's timeouts allow; see uploadTimeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Now().Add(uploadTimeout))
+	_ = rc.SetWriteDeadline(time.Now().Add(uploadTimeout))
+	body := http.MaxBytesReader(w, r.Body, maxImportBytes)
+	result, err := tc.importUC.Execute(r.Context(), userID, body, transfer.ImportRequest{Format: format, DryRun: dryRun})
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			err = apperror.Validation(fmt.Sprintf("the file is larger than %d MB", maxImportBytes>>20))
+		}
+		writeError(w, err)
+		return
+	}
+
+	resp := response.ImportResultResponseDTO{
+		DryRun:     result.DryRun,
+		Todos:      len(result.Todos),
+		Categories: append([]string{}, result.Categories...),
+		Tags:       append([]string{}, result.Tags...),
+		Duplicates: []response.ImportDuplicateDTO{},
+	}
+	for _, d := range result.Duplicates {
+		resp.Duplicates = append(resp.Duplicates, response.ImportDuplicateDTO{Index: d.Index, Title: d.Title})
+	}
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, resp)
+}
+
+// importFormat picks the upload's format from ?format= or the Content-Type.
+func importFormat(r *http.Request) (transfer.Format, error) {
+	if v := r.URL.Query().Get("format"); v != "" {
+		return transfer.ParseFormat(v)
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	for f, ct := range transferContentTypes {
+		if ct.mediaType == mediaType {
+			return f, nil
+		}
+	}
+	return "", transfer.ErrInvalidFormat
+}
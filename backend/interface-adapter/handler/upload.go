@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+)
+
+const (
+	// uploadOverheadBytes leaves room for the multipart framing around a file.
+	uploadOverheadBytes = 1 << 20
+	// uploadMemory is how much of an upload is held in memory; the rest is
+	// spooled to a temporary file.
+	uploadMemory = 1 << 20
+	// uploadTimeout replaces the server's ReadTimeout and WriteTimeout, which
+	// are sized for JSON bodies; the write deadline is counted from the start
+	// of the request, so a slow upload would otherwise lose its response.
+	uploadTimeout = 2 * time.Minute
+)
+
+var errMissingFile = apperror.InvalidField("file", errors.New("send the file as multipart/form-data in a field named file"))
+
+// openUpload parses a multipart body and opens its "file" field, refusing
+// bodies much larger than maxFileBytes. cleanup closes the file and removes
+// any temporary files the parse spooled to disk. The file itself may still
+// be up to uploadOverheadBytes over maxFileBytes; callers check its size.
+func openUpload(w http.ResponseWriter, r *http.Request, maxFileBytes int64) (multipart.File, *multipart.FileHeader, func(), error) {
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Now().Add(uploadTimeout))
+	_ = rc.SetWriteDeadline(time.Now().Add(uploadTimeout))
+	r.Body = http.MaxBytesReader(w, r.Body, maxFileBytes+uploadOverheadBytes)
+	if err := r.ParseMultipartForm(uploadMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, nil, nil, apperror.InvalidField("file", fmt.Errorf("file cannot be larger than %d MB", maxFileBytes>>20))
+		}
+		return nil, nil, nil, errMissingFile
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		_ = r.MultipartForm.RemoveAll()
+		return nil, nil, nil, errMissingFile
+	}
+	cleanup := func() {
+		file.Close()
+		_ = r.MultipartForm.RemoveAll()
+	}
+	return file, header, cleanup, nil
+}
@@ -3,11 +3,16 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"bytes"
 	"io"
 
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ariangn/todo-fullstack/backend/application/session"
 	"github.com/ariangn/todo-fullstack/backend/application/user"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/request"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
 	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
@@ -17,17 +22,27 @@ type UserController struct {
 	registerUC user.RegisterUseCase
 	loginUC    user.LoginUseCase
 	findByIDUC user.FindByIDUseCase // ← new use‐case for fetching by ID
+	logoutUC   session.LogoutUseCase
+	// setAvatarUC and avatarUC handle uploaded avatars
+	setAvatarUC user.SetAvatarUseCase
+	avatarUC    user.AvatarUseCase
 }
 
 func NewUserController(
 	rUC user.RegisterUseCase,
 	lUC user.LoginUseCase,
 	fbUC user.FindByIDUseCase,
+	loUC session.LogoutUseCase,
+	saUC user.SetAvatarUseCase,
+	aUC user.AvatarUseCase,
 ) *UserController {
 	return &UserController{
-		registerUC: rUC,
-		loginUC:    lUC,
-		findByIDUC: fbUC,
+		registerUC:  rUC,
+		loginUC:     lUC,
+		findByIDUC:  fbUC,
+		logoutUC:    loUC,
+		setAvatarUC: saUC,
+		avatarUC:    aUC,
 	}
 }
 
@@ -36,7 +51,7 @@ func (uc *UserController) Register(w http.ResponseWriter, r *http.Request) {
 	r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes)) // Re-use body
 	var dto request.CreateUserDTO
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
-		http.Error(w, "invalid request payload", http.StatusBadRequest)
+		writeError(w, errInvalidPayload(err))
 		return
 	}
 
@@ -49,65 +64,41 @@ func (uc *UserController) Register(w http.ResponseWriter, r *http.Request) {
 		dto.AvatarURL,
 	)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
-
-	respDTO := response.UserResponseDTO{
-		ID:        userEntity.ID,
-		Email:     userEntity.Email,
-		Name:      userEntity.Name,
-		AvatarURL: userEntity.AvatarURL,
-		Timezone:  userEntity.Timezone,
-		CreatedAt: userEntity.CreatedAt,
-		UpdatedAt: userEntity.UpdatedAt,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(respDTO)
+	writeJSON(w, http.StatusCreated, toUserResponseDTO(userEntity))
 }
 
 func (uc *UserController) Login(w http.ResponseWriter, r *http.Request) {
 	var dto request.LoginUserDTO
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
-		http.Error(w, "invalid request payload", http.StatusBadRequest)
+		writeError(w, errInvalidPayload(err))
 		return
 	}
 
-	token, err := uc.loginUC.Execute(r.Context(), dto.Email, dto.Password)
+	tokens, err := uc.loginUC.Execute(r.Context(), dto.Email, dto.Password, r.UserAgent())
 	if err != nil {
-		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		writeError(w, err)
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "token",
-		Value:    token,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteNoneMode,
-		MaxAge:   60 * 60 * 24, // 1 day
-	})
+	setSessionCookies(w, tokens)
 
 	// Optionally return user info or a success message
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"message": "login successful"})
+	writeJSON(w, http.StatusOK, map[string]string{"message": "login successful"})
 }
 
+// Logout revokes the session behind the refresh cookie, if any, and clears both cookies.
 func (uc *UserController) Logout(w http.ResponseWriter, r *http.Request) {
-	// Expire the cookie by setting MaxAge to -1
-	http.SetCookie(w, &http.Cookie{
-		Name:     "token",
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true, // HTTPS only in prod
-		SameSite: http.SameSiteNoneMode,
-		MaxAge:   -1,
-	})
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"message": "logout successful"})
+	if cookie, err := r.Cookie(refreshTokenCookie); err == nil && cookie.Value != "" {
+		if err := uc.logoutUC.Execute(r.Context(), cookie.Value); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	clearSessionCookies(w)
+	writeJSON(w, http.StatusOK, map[string]string{"message": "logout successful"})
 }
 
 // Me returns the currently authenticated user's info (requires AuthMiddleware).
@@ -115,26 +106,75 @@ func (uc *UserController) Me(w http.ResponseWriter, r *http.Request) {
 	// Extract userID from context (populated by AuthMiddleware)
 	userID, ok := middleware.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, errUnauthorized)
 		return
 	}
 
 	// Use the FindByID use-case to fetch user details
 	userEntity, err := uc.findByIDUC.Execute(r.Context(), userID)
 	if err != nil {
-		http.Error(w, "user not found", http.StatusNotFound)
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toUserResponseDTO(userEntity))
+}
+
+// SetAvatar replaces the caller's avatar with the PNG, JPEG or WebP image
+// sent as multipart/form-data in the "file" field.
+func (uc *UserController) SetAvatar(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	file, _, cleanup, err := openUpload(w, r, user.MaxAvatarBytes)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
+	defer cleanup()
+
+	userEntity, err := uc.setAvatarUC.Execute(r.Context(), userID, file)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toUserResponseDTO(userEntity))
+}
+
+// Avatar serves an uploaded avatar's URL by redirecting to a signed URL for
+// the thumbnail of ?size= (64 or 256, the default). It is a public route, so
+// that <img> tags work anywhere; the version in the path authorizes it.
+func (uc *UserController) Avatar(w http.ResponseWriter, r *http.Request) {
+	size := user.AvatarSizes[len(user.AvatarSizes)-1]
+	if v := r.URL.Query().Get("size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, user.ErrInvalidAvatarSize)
+			return
+		}
+		size = n
+	}
+
+	url, err := uc.avatarUC.Execute(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "version"), size)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// the redirect may be reused for as long as the signed URL stays valid
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(user.AvatarURLTTL.Seconds())/2))
+	http.Redirect(w, r, url, http.StatusFound)
+}
 
-	respDTO := response.UserResponseDTO{
-		ID:        userEntity.ID,
-		Email:     userEntity.Email,
-		Name:      userEntity.Name,
-		AvatarURL: userEntity.AvatarURL,
-		Timezone:  userEntity.Timezone,
-		CreatedAt: userEntity.CreatedAt,
-		UpdatedAt: userEntity.UpdatedAt,
+func toUserResponseDTO(u *entity.User) response.UserResponseDTO {
+	return response.UserResponseDTO{
+		ID:        u.ID,
+		Email:     u.Email,
+		Name:      u.Name,
+		AvatarURL: u.AvatarURL,
+		Timezone:  u.Timezone,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(respDTO)
 }
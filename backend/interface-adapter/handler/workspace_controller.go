@@ -0,0 +1,346 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ariangn/todo-fullstack/backend/application/workspace"
+	"github.com/ariangn/todo-fullstack/backend/domain/entity"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/request"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/middleware"
+)
+
+// WorkspaceController serves /api/workspaces and the caller's invitations
+// under /api/invitations.
+type WorkspaceController struct {
+	createUC           workspace.CreateUseCase
+	listUC             workspace.ListUseCase
+	getUC              workspace.GetUseCase
+	updateUC           workspace.UpdateUseCase
+	deleteUC           workspace.DeleteUseCase
+	inviteUC           workspace.InviteUseCase
+	listInvitationsUC  workspace.ListInvitationsUseCase
+	receivedUC         workspace.ReceivedInvitationsUseCase
+	acceptUC           workspace.AcceptInvitationUseCase
+	deleteInvitationUC workspace.DeleteInvitationUseCase
+	updateMemberUC     workspace.UpdateMemberUseCase
+	removeMemberUC     workspace.RemoveMemberUseCase
+}
+
+func NewWorkspaceController(
+	cUC workspace.CreateUseCase,
+	lUC workspace.ListUseCase,
+	gUC workspace.GetUseCase,
+	uUC workspace.UpdateUseCase,
+	dUC workspace.DeleteUseCase,
+	iUC workspace.InviteUseCase,
+	liUC workspace.ListInvitationsUseCase,
+	riUC workspace.ReceivedInvitationsUseCase,
+	aUC workspace.AcceptInvitationUseCase,
+	diUC workspace.DeleteInvitationUseCase,
+	umUC workspace.UpdateMemberUseCase,
+	rmUC workspace.RemoveMemberUseCase,
+) *WorkspaceController {
+	return &WorkspaceController{
+		createUC:           cUC,
+		listUC:             lUC,
+		getUC:              gUC,
+		updateUC:           uUC,
+		deleteUC:           dUC,
+		inviteUC:           iUC,
+		listInvitationsUC:  liUC,
+		receivedUC:         riUC,
+		acceptUC:           aUC,
+		deleteInvitationUC: diUC,
+		updateMemberUC:     umUC,
+		removeMemberUC:     rmUC,
+	}
+}
+
+func (wc *WorkspaceController) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.WorkspaceDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	created, err := wc.createUC.Execute(r.Context(), userID, dto.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toWorkspaceResponseDTO(created, entity.RoleOwner))
+}
+
+func (wc *WorkspaceController) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	memberships, err := wc.listUC.Execute(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respList := make([]response.WorkspaceResponseDTO, 0, len(memberships))
+	for _, m := range memberships {
+		respList = append(respList, toWorkspaceResponseDTO(m.Workspace, m.Role))
+	}
+	writeJSON(w, http.StatusOK, respList)
+}
+
+func (wc *WorkspaceController) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+	wc.writeDetail(w, r, userID, chi.URLParam(r, "id"))
+}
+
+// Update renames the workspace; only owners may, so the caller's role in the
+// response is always owner.
+func (wc *WorkspaceController) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.WorkspaceDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	updated, err := wc.updateUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), dto.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toWorkspaceResponseDTO(updated, entity.RoleOwner))
+}
+
+func (wc *WorkspaceController) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	if err := wc.deleteUC.Execute(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (wc *WorkspaceController) Invite(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.InviteMemberDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	token, inv, err := wc.inviteUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), dto.Email, entity.WorkspaceRole(dto.Role))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := toInvitationResponseDTO(inv, nil)
+	resp.Token = token
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// Invitations lists the workspace's open invitations, for its owners.
+func (wc *WorkspaceController) Invitations(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	invitations, err := wc.listInvitationsUC.Execute(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respList := make([]response.WorkspaceInvitationResponseDTO, 0, len(invitations))
+	for _, inv := range invitations {
+		respList = append(respList, toInvitationResponseDTO(inv, nil))
+	}
+	writeJSON(w, http.StatusOK, respList)
+}
+
+// Received lists the invitations sent to the caller's email address.
+func (wc *WorkspaceController) Received(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	received, err := wc.receivedUC.Execute(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respList := make([]response.WorkspaceInvitationResponseDTO, 0, len(received))
+	for _, ri := range received {
+		respList = append(respList, toInvitationResponseDTO(ri.Invitation, ri.Workspace))
+	}
+	writeJSON(w, http.StatusOK, respList)
+}
+
+// Accept joins the workspace and returns it as GetByID does.
+func (wc *WorkspaceController) Accept(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.AcceptInvitationDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	m, err := wc.acceptUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), dto.Token)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	wc.writeDetail(w, r, userID, m.WorkspaceID)
+}
+
+// DeleteInvitation declines an invitation the caller received, or revokes
+// one an owner sent.
+func (wc *WorkspaceController) DeleteInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	if err := wc.deleteInvitationUC.Execute(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateMember changes a member's role and returns the workspace as GetByID does.
+func (wc *WorkspaceController) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	var dto request.UpdateMemberDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		writeError(w, errInvalidPayload(err))
+		return
+	}
+
+	workspaceID := chi.URLParam(r, "id")
+	_, err := wc.updateMemberUC.Execute(r.Context(), userID, workspaceID, chi.URLParam(r, "userId"), entity.WorkspaceRole(dto.Role))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	wc.writeDetail(w, r, userID, workspaceID)
+}
+
+// RemoveMember removes a member, or lets the caller leave when userId is their own.
+func (wc *WorkspaceController) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	if err := wc.removeMemberUC.Execute(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "userId")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (wc *WorkspaceController) writeDetail(w http.ResponseWriter, r *http.Request, userID, workspaceID string) {
+	d, err := wc.getUC.Execute(r.Context(), userID, workspaceID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := response.WorkspaceDetailResponseDTO{
+		WorkspaceResponseDTO: toWorkspaceResponseDTO(d.Workspace, d.Role),
+		Members:              make([]response.WorkspaceMemberResponseDTO, 0, len(d.Members)),
+	}
+	for _, mi := range d.Members {
+		m := response.WorkspaceMemberResponseDTO{
+			UserID:   mi.Member.UserID,
+			Role:     string(mi.Member.Role),
+			JoinedAt: mi.Member.CreatedAt,
+		}
+		if mi.User != nil {
+			m.Email, m.Name = mi.User.Email, mi.User.Name
+		}
+		resp.Members = append(resp.Members, m)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// workspaceParam reads ?workspaceId=, which points a list endpoint at a
+// workspace instead of the caller's personal records.
+func workspaceParam(r *http.Request) *string {
+	if id := r.URL.Query().Get("workspaceId"); id != "" {
+		return &id
+	}
+	return nil
+}
+
+func toWorkspaceResponseDTO(ws *entity.Workspace, role entity.WorkspaceRole) response.WorkspaceResponseDTO {
+	return response.WorkspaceResponseDTO{
+		ID:        ws.ID,
+		Name:      ws.Name,
+		Role:      string(role),
+		CreatedAt: ws.CreatedAt,
+		UpdatedAt: ws.UpdatedAt,
+	}
+}
+
+// toInvitationResponseDTO names the workspace when ws is given.
+func toInvitationResponseDTO(inv *entity.WorkspaceInvitation, ws *entity.Workspace) response.WorkspaceInvitationResponseDTO {
+	dto := response.WorkspaceInvitationResponseDTO{
+		ID:          inv.ID,
+		WorkspaceID: inv.WorkspaceID,
+		Email:       inv.Email,
+		Role:        string(inv.Role),
+		InvitedBy:   inv.InvitedBy,
+		CreatedAt:   inv.CreatedAt,
+		ExpiresAt:   inv.ExpiresAt,
+	}
+	if ws != nil {
+		dto.WorkspaceName = &ws.Name
+	}
+	return dto
+}
@@ -2,42 +2,68 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"time"
 
+	"github.com/ariangn/todo-fullstack/backend/domain/apperror"
+	"github.com/ariangn/todo-fullstack/backend/domain/repository"
 	"github.com/ariangn/todo-fullstack/backend/infrastructure/auth"
+	"github.com/ariangn/todo-fullstack/backend/interface-adapter/dto/response"
 )
 
 type ctxKey string
 
-const userIDKey ctxKey = "userID"
+const (
+	userIDKey    ctxKey = "userID"
+	sessionIDKey ctxKey = "sessionID"
+)
 
-// AuthMiddleware validates the token from HTTP-only cookie and stores the user ID in context.
-func AuthMiddleware(authClient auth.AuthClientInterface) func(http.Handler) http.Handler {
+// AuthMiddleware validates the token from HTTP-only cookie, checks that its
+// session has not been revoked, and stores the user and session IDs in context.
+func AuthMiddleware(authClient auth.AuthClientInterface, sessionRepo repository.SessionRepository) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Read the "token" cookie
 			cookie, err := r.Cookie("token")
 			if err != nil || cookie.Value == "" {
-				http.Error(w, "missing or invalid token", http.StatusUnauthorized)
+				writeUnauthorized(w, "missing or invalid token")
 				return
 			}
 
 			// Validate the JWT and extract claims
 			claims, err := authClient.ValidateToken(cookie.Value)
 			if err != nil {
-				http.Error(w, "invalid token", http.StatusUnauthorized)
+				writeUnauthorized(w, "invalid token")
 				return
 			}
 
 			// Expect the “sub” claim to be the user’s ID
 			sub, ok := claims["sub"].(string)
 			if !ok {
-				http.Error(w, "invalid token subject", http.StatusUnauthorized)
+				writeUnauthorized(w, "invalid token subject")
 				return
 			}
 
-			// Store userID in context for downstream handlers
+			// The “sid” claim must name a live session of the same user
+			sid, ok := claims["sid"].(string)
+			if !ok {
+				writeUnauthorized(w, "invalid token session")
+				return
+			}
+			session, err := sessionRepo.FindByID(r.Context(), sid)
+			if err != nil {
+				writeUnauthorized(w, "could not verify session")
+				return
+			}
+			if session == nil || session.UserID != sub || !session.Active(time.Now().UTC()) {
+				writeUnauthorized(w, "session revoked or expired")
+				return
+			}
+
+			// Store userID and sessionID in context for downstream handlers
 			ctx := context.WithValue(r.Context(), userIDKey, sub)
+			ctx = context.WithValue(ctx, sessionIDKey, sid)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -48,3 +74,18 @@ func GetUserIDFromContext(ctx context.Context) (string, bool) {
 	id, ok := ctx.Value(userIDKey).(string)
 	return id, ok
 }
+
+// GetSessionIDFromContext extracts the ID of the session the request was made with.
+func GetSessionIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(sessionIDKey).(string)
+	return id, ok
+}
+
+// writeUnauthorized emits the same error envelope as the handler package.
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(response.ErrorResponseDTO{
+		Error: response.ErrorDetailDTO{Code: string(apperror.KindUnauthorized), Message: message},
+	})
+}
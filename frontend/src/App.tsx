@@ -8,6 +8,7 @@ import {
   login as loginService,
   signup as signupService,
   logout as logoutService,
+  refreshSession,
 } from "./services/authService";
 
 // user type
@@ -45,6 +46,15 @@ export default function App() {
     })();
   }, []);
 
+  // access tokens live 15 minutes; renew them while signed in
+  useEffect(() => {
+    if (!user) return;
+    const id = setInterval(async () => {
+      if (!(await refreshSession())) setUser(null);
+    }, 10 * 60 * 1000);
+    return () => clearInterval(id);
+  }, [user]);
+
   // auth handlers
   async function handleLogin(email: string, password: string) {
     const loggedIn = await loginService(email, password);
@@ -65,8 +65,21 @@ export async function logout(): Promise<void> {
   }
 }
 
+// trades the refresh_token cookie for fresh cookies; false once the session is gone
+export async function refreshSession(): Promise<boolean> {
+  const res = await fetch(`${API}/auth/refresh`, {
+    method: "POST",
+    credentials: "include",
+  });
+  return res.ok;
+}
+
 export async function getUserFromCookie(): Promise<User | null> {
-  const res = await fetch(`${API}/auth/me`, { credentials: "include" });
+  let res = await fetch(`${API}/auth/me`, { credentials: "include" });
+  // the short-lived access token may have expired since the last visit
+  if (res.status === 401 && (await refreshSession())) {
+    res = await fetch(`${API}/auth/me`, { credentials: "include" });
+  }
   if (!res.ok) return null;
   return (await res.json()) as User;
 }
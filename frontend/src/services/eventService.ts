@@ -0,0 +1,25 @@
+export type ChangeEvent = {
+  resource: "todo" | "category" | "tag";
+  action: "created" | "updated" | "deleted";
+  id: string;
+  data?: unknown; // the record as the REST endpoints return it; absent when deleted
+  at: string;
+};
+
+const API = import.meta.env.VITE_API_URL as string;
+
+const EVENT_NAMES = ["todo", "category", "tag"].flatMap((r) =>
+  ["created", "updated", "deleted"].map((a) => `${r}.${a}`),
+);
+
+// subscribeToChanges follows the signed-in user's changes from other tabs and
+// devices. onReset is called when missed events cannot be replayed and the
+// caller should reload. EventSource reconnects with Last-Event-ID by itself.
+export function subscribeToChanges(onChange: (e: ChangeEvent) => void, onReset: () => void): () => void {
+  const source = new EventSource(`${API}/events`, { withCredentials: true });
+  for (const name of EVENT_NAMES) {
+    source.addEventListener(name, (msg) => onChange(JSON.parse((msg as MessageEvent).data) as ChangeEvent));
+  }
+  source.addEventListener("reset", onReset);
+  return () => source.close();
+}
@@ -1,3 +1,11 @@
+export type Recurrence = {
+  frequency: "DAILY" | "WEEKLY" | "MONTHLY" | "YEARLY";
+  interval?: number;
+  byWeekday?: ("MO" | "TU" | "WE" | "TH" | "FR" | "SA" | "SU")[];
+  until?: string;
+  count?: number;
+};
+
 export type Todo = {
   id: string;
   title: string;
@@ -8,16 +16,83 @@ export type Todo = {
   tags?: string[];
   categoryId?: string;
   category?: { id: string; color: string; name: string };
+  recurrence?: Recurrence;
+  autoComplete: boolean;
+  progress: { done: number; total: number };
+  version: number;
   createdAt: string;
   updatedAt: string;
 };
 
 const API = import.meta.env.VITE_API_URL as string;
 
-export async function fetchAllTodos(): Promise<Todo[]> {
-  const res = await fetch(`${API}/todos`, { credentials: "include" });
+export type TodoPage = {
+  items: Todo[];
+  total: number;
+  nextCursor?: string;
+};
+
+export type TodoFilters = {
+  status?: Todo["status"];
+  categoryId?: string;
+  tagId?: string;
+  dueDateFrom?: string;
+  dueDateTo?: string;
+  sortBy?: "createdAt" | "updatedAt" | "dueDate" | "title" | "status";
+  order?: "asc" | "desc";
+  limit?: number;
+  cursor?: string;
+};
+
+export async function fetchTodos(filters: TodoFilters = {}): Promise<TodoPage> {
+  const params = new URLSearchParams();
+  for (const [key, value] of Object.entries(filters)) {
+    if (value !== undefined && value !== "") params.set(key, String(value));
+  }
+  const qs = params.toString();
+  const res = await fetch(`${API}/todos${qs ? `?${qs}` : ""}`, { credentials: "include" });
   if (!res.ok) throw new Error("Failed to fetch todos");
-  return (await res.json()) as Todo[];
+  return (await res.json()) as TodoPage;
+}
+
+// follows nextCursor until every page matching the filters has been loaded
+export async function fetchAllTodos(filters: TodoFilters = {}): Promise<Todo[]> {
+  const todos: Todo[] = [];
+  let cursor: string | undefined;
+  do {
+    const page = await fetchTodos({ limit: 200, ...filters, cursor });
+    todos.push(...page.items);
+    cursor = page.nextCursor;
+  } while (cursor);
+  return todos;
+}
+
+export type TextSegment = { text: string; match?: boolean };
+
+export type TodoSearchHit = Todo & {
+  rank: number;
+  titleSnippet: TextSegment[];
+  bodySnippet?: TextSegment[];
+};
+
+export type TodoSearchPage = {
+  items: TodoSearchHit[];
+  total: number;
+  nextCursor?: string;
+};
+
+// q supports prefixes (milk*) and phrases ("buy milk"); filters narrow the matches
+export async function searchTodos(
+  q: string,
+  filters: Pick<TodoFilters, "status" | "categoryId" | "tagId" | "limit" | "cursor"> = {}
+): Promise<TodoSearchPage> {
+  const params = new URLSearchParams({ q });
+  for (const [key, value] of Object.entries(filters)) {
+    if (value !== undefined && value !== "") params.set(key, String(value));
+  }
+  const res = await fetch(`${API}/todos/search?${params}`, { credentials: "include" });
+  if (!res.ok) throw new Error("Failed to search todos");
+  return (await res.json()) as TodoSearchPage;
 }
 
 export async function createTodo(data: {
@@ -93,3 +168,33 @@ export async function duplicateTodo(id: string): Promise<Todo> {
   if (!res.ok) throw new Error("Failed to duplicate todo");
   return (await res.json()) as Todo;
 }
+
+export type BulkTodoAction =
+  | { action: "set_status"; status: "TODO" | "IN_PROGRESS" | "COMPLETED" }
+  | { action: "set_category"; categoryId: string | null }
+  | { action: "add_tags" | "remove_tags"; tagIds: string[] }
+  | { action: "set_due_date"; dueDate: string | null }
+  | { action: "delete" | "duplicate" };
+
+export type BulkTodoResult = {
+  id: string;
+  ok: boolean;
+  todo?: Todo;
+  nextOccurrence?: Todo;
+  error?: { code: string; message: string };
+};
+
+// applies one action to many todos; failures are reported per todo
+export async function bulkTodos(
+  ids: string[],
+  action: BulkTodoAction
+): Promise<{ results: BulkTodoResult[]; succeeded: number; failed: number }> {
+  const res = await fetch(`${API}/todos/bulk`, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    credentials: "include",
+    body: JSON.stringify({ ids, ...action }),
+  });
+  if (!res.ok) throw new Error("Failed to apply bulk action");
+  return await res.json();
+}